@@ -137,6 +137,9 @@ var IcdDbRegion string
 var IcdDbDeploymentId string
 var IcdDbBackupId string
 var IcdDbTaskId string
+var IcdDbSqlMigrationHost string
+var IcdDbSqlMigrationPassword string
+var KafkaBootstrapServers string
 var KmsInstanceID string
 var CrkID string
 var KmsAccountID string
@@ -188,8 +191,11 @@ var IsImageEncryptionKey string
 var IsWinImage string
 var IsCosBucketName string
 var IsCosBucketCRN string
+var IsFlowLogID string
+var IsFlowLogBucketRegion string
 var Image_cos_url string
 var Image_cos_url_encrypted string
+var Image_source_file string
 var Image_operating_system string
 
 // Transit Gateway Power Virtual Server
@@ -232,6 +238,8 @@ var ClusterName string
 // Satellite instance
 var Satellite_location_id string
 var Satellite_Resource_instance_id string
+var Satellite_ssh_public_key string
+var Satellite_ssh_private_key string
 
 // Dedicated host
 var HostPoolID string
@@ -274,6 +282,10 @@ var CeResourceGroupID string
 var CeProjectId string
 var CeServiceInstanceID string
 var CeResourceKeyID string
+var CeDomainMappingName string
+var CeTLSCert string
+var CeTLSKey string
+var CeSecretsManagerCertificateID string
 
 func init() {
 	testlogger := os.Getenv("TF_LOG")
@@ -631,6 +643,17 @@ func init() {
 		fmt.Println("[INFO] Set the environment variable IS_COS_BUCKET_CRN for testing ibm_is_image_export_job else it is set to default value 'bucket-27200-lwx4cfvcue'")
 	}
 
+	IsFlowLogID = os.Getenv("IS_FLOW_LOG_ID")
+	if IsFlowLogID == "" {
+		fmt.Println("[WARN] Set the environment variable IS_FLOW_LOG_ID with the ID of a flow log collector that has written flow logs for testing ibm_is_flow_log_analytics data source")
+	}
+
+	IsFlowLogBucketRegion = os.Getenv("IS_FLOW_LOG_BUCKET_REGION")
+	if IsFlowLogBucketRegion == "" {
+		IsFlowLogBucketRegion = "us-south"
+		fmt.Println("[INFO] Set the environment variable IS_FLOW_LOG_BUCKET_REGION for testing ibm_is_flow_log_analytics data source else it is set to default value 'us-south'")
+	}
+
 	InstanceName = os.Getenv("IS_INSTANCE_NAME")
 	if InstanceName == "" {
 		InstanceName = "placement-check-ins" // for next gen infrastructure
@@ -796,6 +819,24 @@ func init() {
 		IcdDbTaskId = "crn:v1:bluemix:public:databases-for-redis:au-syd:a/40ddc34a953a8c02f10987b59085b60e:367b0a22-05bb-41e3-a1ed-ded1ff0889e5:task:882013a6-2751-4df7-a77a-98d258638704"
 		fmt.Println("[INFO] Set the environment variable ICD_DB_TASK_ID for testing ibm_cloud_databases else it is set to default value 'crn:v1:bluemix:public:databases-for-redis:au-syd:a/40ddc34a953a8c02f10987b59085b60e:367b0a22-05bb-41e3-a1ed-ded1ff0889e5:task:882013a6-2751-4df7-a77a-98d258638704'")
 	}
+
+	IcdDbSqlMigrationHost = os.Getenv("ICD_DB_SQL_MIGRATION_HOST")
+	if IcdDbSqlMigrationHost == "" {
+		IcdDbSqlMigrationHost = "localhost"
+		fmt.Println("[INFO] Set the environment variable ICD_DB_SQL_MIGRATION_HOST for testing ibm_database_sql_migration against a PostgreSQL server else it is set to default value 'localhost'")
+	}
+
+	IcdDbSqlMigrationPassword = os.Getenv("ICD_DB_SQL_MIGRATION_PASSWORD")
+	if IcdDbSqlMigrationPassword == "" {
+		IcdDbSqlMigrationPassword = "postgres"
+		fmt.Println("[INFO] Set the environment variable ICD_DB_SQL_MIGRATION_PASSWORD for the postgres user of the PostgreSQL server else it is set to default value 'postgres'")
+	}
+
+	KafkaBootstrapServers = os.Getenv("KAFKA_BOOTSTRAP_SERVERS")
+	if KafkaBootstrapServers == "" {
+		KafkaBootstrapServers = "localhost:9092"
+		fmt.Println("[INFO] Set the environment variable KAFKA_BOOTSTRAP_SERVERS for testing ibm_event_streams_acl and ibm_event_streams_quota against a Kafka broker with an authorizer else it is set to default value 'localhost:9092'")
+	}
 	// Added for Power Colo Testing
 	Pi_image = os.Getenv("PI_IMAGE")
 	if Pi_image == "" {
@@ -1011,6 +1052,10 @@ func init() {
 		Image_cos_url_encrypted = "cos://us-south/cosbucket-vpc-image-gen2/rhel-guest-image-7.0-encrypted.qcow2"
 		fmt.Println("[WARN] Set the environment variable IMAGE_COS_URL_ENCRYPTED with a VALID COS Image SQL URL for testing ibm_is_image resources on staging/test")
 	}
+	Image_source_file = os.Getenv("IMAGE_SOURCE_FILE")
+	if Image_source_file == "" {
+		fmt.Println("[WARN] Set the environment variable IMAGE_SOURCE_FILE with the path of a local qcow2 or vhd image for testing ibm_is_image resources with source_file")
+	}
 	Image_operating_system = os.Getenv("IMAGE_OPERATING_SYSTEM")
 	if Image_operating_system == "" {
 		Image_operating_system = "red-7-amd64"
@@ -1290,6 +1335,12 @@ func init() {
 		fmt.Println("[INFO] Set the environment variable SATELLITE_RESOURCE_INSTANCE_ID for ibm_cos_bucket satellite location resource or datasource else tests will fail if this is not set correctly")
 	}
 
+	Satellite_ssh_public_key = os.Getenv("SATELLITE_SSH_PUBLIC_KEY")
+	Satellite_ssh_private_key = os.Getenv("SATELLITE_SSH_PRIVATE_KEY")
+	if Satellite_ssh_public_key == "" || Satellite_ssh_private_key == "" {
+		fmt.Println("[INFO] Set the environment variables SATELLITE_SSH_PUBLIC_KEY and SATELLITE_SSH_PRIVATE_KEY for attaching ibm_satellite_host resources over SSH else tests will fail if this is not set correctly")
+	}
+
 	HostPoolID = os.Getenv("IBM_CONTAINER_DEDICATEDHOST_POOL_ID")
 	if HostPoolID == "" {
 		fmt.Println("[INFO] Set the environment variable IBM_CONTAINER_DEDICATEDHOST_POOL_ID for ibm_container_vpc_cluster resource to test dedicated host functionality")
@@ -1456,6 +1507,26 @@ func init() {
 		fmt.Println("[WARN] Set the environment variable IBM_CODE_ENGINE_RESOURCE_KEY_ID with the ID of a resource key to access a service instance")
 	}
 
+	CeDomainMappingName = os.Getenv("IBM_CODE_ENGINE_DOMAIN_MAPPING_NAME")
+	if CeDomainMappingName == "" {
+		fmt.Println("[WARN] Set the environment variable IBM_CODE_ENGINE_DOMAIN_MAPPING_NAME with the name of a custom domain for Code Engine domain mappings")
+	}
+
+	CeTLSCert = os.Getenv("IBM_CODE_ENGINE_TLS_CERT")
+	if CeTLSCert == "" {
+		fmt.Println("[WARN] Set the environment variable IBM_CODE_ENGINE_TLS_CERT with the PEM encoded certificate of the custom domain")
+	}
+
+	CeTLSKey = os.Getenv("IBM_CODE_ENGINE_TLS_KEY")
+	if CeTLSKey == "" {
+		fmt.Println("[WARN] Set the environment variable IBM_CODE_ENGINE_TLS_KEY with the PEM encoded private key of the custom domain")
+	}
+
+	CeSecretsManagerCertificateID = os.Getenv("IBM_CODE_ENGINE_SECRETS_MANAGER_CERTIFICATE_ID")
+	if CeSecretsManagerCertificateID == "" {
+		fmt.Println("[WARN] Set the environment variable IBM_CODE_ENGINE_SECRETS_MANAGER_CERTIFICATE_ID with the ID of a Secrets Manager certificate of the custom domain in the instance SECRETS_MANAGER_INSTANCE_ID")
+	}
+
 }
 
 var TestAccProviders map[string]*schema.Provider
@@ -1570,6 +1641,28 @@ func TestAccPreCheckImage(t *testing.T) {
 		t.Fatal("IMAGE_OPERATING_SYSTEM must be set for acceptance tests")
 	}
 }
+func TestAccPreCheckImageSourceFile(t *testing.T) {
+	TestAccPreCheck(t)
+	if Image_source_file == "" {
+		t.Fatal("IMAGE_SOURCE_FILE must be set for acceptance tests")
+	}
+	if CosCRN == "" {
+		t.Fatal("IBM_COS_CRN must be set for acceptance tests")
+	}
+	if Image_operating_system == "" {
+		t.Fatal("IMAGE_OPERATING_SYSTEM must be set for acceptance tests")
+	}
+}
+func TestAccPreCheckFlowLogAnalytics(t *testing.T) {
+	TestAccPreCheck(t)
+	if IsFlowLogID == "" {
+		t.Fatal("IS_FLOW_LOG_ID must be set for acceptance tests")
+	}
+	if CosCRN == "" {
+		t.Fatal("IBM_COS_CRN must be set for acceptance tests")
+	}
+}
+
 func TestAccPreCheckEncryptedImage(t *testing.T) {
 	TestAccPreCheck(t)
 	if Image_cos_url_encrypted == "" {
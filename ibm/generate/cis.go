@@ -0,0 +1,154 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package generate
+
+import (
+	"fmt"
+
+	"github.com/IBM-Cloud/bluemix-go/api/resource/resourcev2/controllerv2"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+const cisServiceName = "internet-svcs"
+
+// cisPerPage is the maximum page size of the CIS list APIs.
+const cisPerPage = 1000
+
+var cisGenerators = []resourceGenerator{
+	{"ibm_cis", listCISInstances},
+	{"ibm_cis_domain", listCISDomains},
+	{"ibm_cis_dns_record", listCISDNSRecords},
+}
+
+func listCISInstances(meta interface{}) ([]importable, error) {
+	crns, err := cisInstances(meta)
+	if err != nil {
+		return nil, err
+	}
+	importables := make([]importable, 0, len(crns))
+	for _, crn := range sortedKeys(crns) {
+		importables = append(importables, importable{id: crn, name: crns[crn]})
+	}
+	return importables, nil
+}
+
+// listCISDomains lists the domains of all CIS instances, the import ID of a
+// domain is <zone_id>:<crn>.
+func listCISDomains(meta interface{}) ([]importable, error) {
+	zones, err := cisZones(meta)
+	if err != nil {
+		return nil, err
+	}
+	importables := make([]importable, 0, len(zones))
+	for _, zone := range zones {
+		importables = append(importables, importable{
+			id:   flex.ConvertCisToTfTwoVar(zone.id, zone.crn),
+			name: zone.name,
+		})
+	}
+	return importables, nil
+}
+
+// listCISDNSRecords lists the DNS records of all domains, the import ID of a
+// DNS record is <record_id>:<zone_id>:<crn>.
+func listCISDNSRecords(meta interface{}) ([]importable, error) {
+	zones, err := cisZones(meta)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := meta.(conns.ClientSession).CisDNSRecordClientSession()
+	if err != nil {
+		return nil, err
+	}
+	importables := []importable{}
+	for _, zone := range zones {
+		sess.Crn = core.StringPtr(zone.crn)
+		sess.ZoneIdentifier = core.StringPtr(zone.id)
+		opt := sess.NewListAllDnsRecordsOptions()
+		opt.SetPerPage(cisPerPage)
+		for page := int64(1); ; page++ {
+			opt.SetPage(page)
+			result, response, err := sess.ListAllDnsRecords(opt)
+			if err != nil {
+				return nil, fmt.Errorf("%s\n%s", err, response)
+			}
+			for _, record := range result.Result {
+				importables = append(importables, importable{
+					id:   flex.ConvertCisToTfThreeVar(*record.ID, zone.id, zone.crn),
+					name: *record.Type + "_" + *record.Name,
+				})
+			}
+			if page*cisPerPage >= *result.ResultInfo.TotalCount {
+				break
+			}
+		}
+	}
+	return importables, nil
+}
+
+// cisInstances returns the names of the CIS instances by CRN.
+func cisInstances(meta interface{}) (map[string]string, error) {
+	rsCatClient, err := meta.(conns.ClientSession).ResourceCatalogAPI()
+	if err != nil {
+		return nil, err
+	}
+	serviceOff, err := rsCatClient.ResourceCatalog().FindByName(cisServiceName, true)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error retrieving service offering: %s", err)
+	}
+	rsConClient, err := meta.(conns.ClientSession).ResourceControllerAPIV2()
+	if err != nil {
+		return nil, err
+	}
+	instances, err := rsConClient.ResourceServiceInstanceV2().ListInstances(controllerv2.ServiceInstanceQuery{
+		ServiceID: serviceOff[0].ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	crns := make(map[string]string, len(instances))
+	for _, instance := range instances {
+		crns[instance.ID] = instance.Name
+	}
+	return crns, nil
+}
+
+type cisZone struct {
+	id   string
+	name string
+	crn  string
+}
+
+func cisZones(meta interface{}) ([]cisZone, error) {
+	crns, err := cisInstances(meta)
+	if err != nil {
+		return nil, err
+	}
+	cisClient, err := meta.(conns.ClientSession).CisZonesV1ClientSession()
+	if err != nil {
+		return nil, err
+	}
+	zones := []cisZone{}
+	for _, crn := range sortedKeys(crns) {
+		cisClient.Crn = core.StringPtr(crn)
+		opt := cisClient.NewListZonesOptions()
+		opt.SetPerPage(cisPerPage)
+		for page := int64(1); ; page++ {
+			opt.SetPage(page)
+			result, response, err := cisClient.ListZones(opt)
+			if err != nil {
+				return nil, fmt.Errorf("%s\n%s", err, response)
+			}
+			for _, zone := range result.Result {
+				zones = append(zones, cisZone{id: *zone.ID, name: *zone.Name, crn: crn})
+			}
+			if page*cisPerPage >= *result.ResultInfo.TotalCount {
+				break
+			}
+		}
+	}
+	return zones, nil
+}
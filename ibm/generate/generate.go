@@ -0,0 +1,265 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+// Package generate writes Terraform configuration and import blocks for the
+// resources that already exist in an IBM Cloud account, so that they can be
+// brought under Terraform with `terraform plan` and `terraform apply`.
+package generate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/provider"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// importable is an existing resource that is brought under Terraform.
+type importable struct {
+	// id is the import ID, in the format of the resource type, such as
+	// <record_id>:<zone_id>:<crn> for ibm_cis_dns_record.
+	id string
+	// name is used to derive the name of the resource in the configuration.
+	name string
+}
+
+// resourceGenerator lists the existing resources of a resource type with the
+// clients of the provider.
+type resourceGenerator struct {
+	resourceType string
+	list         func(meta interface{}) ([]importable, error)
+}
+
+// serviceGenerators are the services that can be generated, the resource
+// types are listed in dependency order.
+var serviceGenerators = map[string][]resourceGenerator{
+	"vpc": vpcGenerators,
+	"cis": cisGenerators,
+	"iam": iamGenerators,
+}
+
+// generatedResource is a resource that is read with the Read function of its
+// resource type.
+type generatedResource struct {
+	resourceType string
+	name         string
+	importID     string
+	resource     *schema.Resource
+	data         *schema.ResourceData
+}
+
+func (g *generatedResource) address() string {
+	return g.resourceType + "." + g.name
+}
+
+// Run runs the generate command with the command line arguments that follow
+// `generate`. The provider is configured with the environment variables that
+// the provider block falls back to, such as IC_API_KEY and IC_REGION.
+func Run(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	services := flags.String("services", "", "Comma separated services to generate: "+strings.Join(supportedServices(), ", "))
+	resourceTypes := flags.String("resource-types", "", "Comma separated resource types to generate, defaults to all resource types of the services")
+	out := flags.String("out", "", "Directory to write one <service>.tf file per service to, defaults to standard output")
+	verbose := flags.Bool("verbose", false, "Print the provider logs")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: terraform-provider-ibm generate -services vpc,cis,iam [options]\n\n")
+		fmt.Fprintf(flags.Output(), "Writes the configuration and Terraform 1.5 import blocks of existing resources.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *services == "" {
+		flags.Usage()
+		return fmt.Errorf("-services is required")
+	}
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	selected, err := selectGenerators(splitList(*services), splitList(*resourceTypes))
+	if err != nil {
+		return err
+	}
+
+	ibmProvider := provider.Provider()
+	diags := ibmProvider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{}))
+	if diags.HasError() {
+		return fmt.Errorf("[ERROR] Error configuring the provider: %s", diags[0].Summary)
+	}
+	meta := ibmProvider.Meta()
+
+	generated := make(map[string][]*generatedResource)
+	names := make(map[string]map[string]bool)
+	for _, service := range sortedKeys(selected) {
+		for _, generator := range selected[service] {
+			resources, err := generateResources(ibmProvider.ResourcesMap[generator.resourceType], generator, meta, names)
+			if err != nil {
+				return err
+			}
+			generated[service] = append(generated[service], resources...)
+		}
+	}
+
+	// IDs of generated resources are written as references to them
+	references := make(map[string]string)
+	for _, resources := range generated {
+		for _, r := range resources {
+			references[r.data.Id()] = r.address()
+		}
+	}
+
+	for _, service := range sortedKeys(selected) {
+		file := hclwrite.NewEmptyFile()
+		for i, r := range generated[service] {
+			if i > 0 {
+				file.Body().AppendNewline()
+			}
+			writeImport(file.Body(), r)
+			writeResource(file.Body(), r, references)
+		}
+		if *out == "" {
+			fmt.Fprintf(os.Stdout, "# %s\n\n", service)
+			if _, err := file.WriteTo(os.Stdout); err != nil {
+				return err
+			}
+			continue
+		}
+		path := filepath.Join(*out, service+".tf")
+		if err := os.WriteFile(path, file.Bytes(), 0644); err != nil {
+			return fmt.Errorf("[ERROR] Error writing %s: %s", path, err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d resources to %s\n", len(generated[service]), path)
+	}
+	return nil
+}
+
+// generateResources lists the resources of the generator, and imports and
+// reads each of them like `terraform import` does.
+func generateResources(r *schema.Resource, generator resourceGenerator, meta interface{}, names map[string]map[string]bool) ([]*generatedResource, error) {
+	importables, err := generator.list(meta)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error listing %s: %s", generator.resourceType, err)
+	}
+	if names[generator.resourceType] == nil {
+		names[generator.resourceType] = make(map[string]bool)
+	}
+
+	resources := make([]*generatedResource, 0, len(importables))
+	for _, imp := range importables {
+		data, err := readResource(r, imp.id, meta)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping %s %s: %s\n", generator.resourceType, imp.id, err)
+			continue
+		}
+		if data == nil {
+			continue
+		}
+		resources = append(resources, &generatedResource{
+			resourceType: generator.resourceType,
+			name:         resourceName(imp.name, names[generator.resourceType]),
+			importID:     imp.id,
+			resource:     r,
+			data:         data,
+		})
+	}
+	return resources, nil
+}
+
+// readResource runs the importer and the Read function of the resource type,
+// it returns nil when the resource no longer exists.
+func readResource(r *schema.Resource, id string, meta interface{}) (data *schema.ResourceData, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("read failed: %v", p)
+		}
+	}()
+
+	ctx := context.Background()
+	state := &terraform.InstanceState{
+		ID:         id,
+		Attributes: map[string]string{"id": id},
+	}
+	if r.Importer != nil && (r.Importer.StateContext != nil || r.Importer.State != nil) {
+		d := r.Data(state)
+		var imported []*schema.ResourceData
+		if r.Importer.StateContext != nil {
+			imported, err = r.Importer.StateContext(ctx, d, meta)
+		} else {
+			imported, err = r.Importer.State(d, meta)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(imported) == 0 {
+			return nil, nil
+		}
+		state = imported[0].State()
+	}
+
+	state, diags := r.RefreshWithoutUpgrade(ctx, state, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s", diags[0].Summary)
+	}
+	if state == nil || state.ID == "" {
+		return nil, nil
+	}
+	return r.Data(state), nil
+}
+
+func selectGenerators(services, resourceTypes []string) (map[string][]resourceGenerator, error) {
+	wanted := make(map[string]bool, len(resourceTypes))
+	for _, resourceType := range resourceTypes {
+		wanted[resourceType] = true
+	}
+
+	selected := make(map[string][]resourceGenerator)
+	for _, service := range services {
+		generators, ok := serviceGenerators[service]
+		if !ok {
+			return nil, fmt.Errorf("unsupported service %q, the supported services are %s", service, strings.Join(supportedServices(), ", "))
+		}
+		for _, generator := range generators {
+			if len(wanted) == 0 || wanted[generator.resourceType] {
+				selected[service] = append(selected[service], generator)
+				delete(wanted, generator.resourceType)
+			}
+		}
+	}
+	if len(wanted) > 0 {
+		return nil, fmt.Errorf("unsupported resource types for the services %s: %s", strings.Join(services, ", "), strings.Join(sortedKeys(wanted), ", "))
+	}
+	return selected, nil
+}
+
+func supportedServices() []string {
+	return sortedKeys(serviceGenerators)
+}
+
+func splitList(s string) []string {
+	list := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,151 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceName(t *testing.T) {
+	used := map[string]bool{}
+	cases := []struct {
+		name     string
+		expected string
+	}{
+		{"My VPC", "my_vpc"},
+		{"my-vpc", "my-vpc"},
+		{"my vpc", "my_vpc_2"},
+		{"10.0.0.0/24", "r_10_0_0_0_24"},
+		{"*", "resource"},
+	}
+	for _, c := range cases {
+		if actual := resourceName(c.name, used); actual != c.expected {
+			t.Errorf("resourceName(%q) = %q, expected %q", c.name, actual, c.expected)
+		}
+	}
+}
+
+func TestWriteResource(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"vpc": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"classic_access": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"address_prefix_management": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "auto",
+			},
+			"zone": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"total_ipv4_address_count"},
+			},
+			"total_ipv4_address_count": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"zone"},
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"api_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"crn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"direction": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                      "example",
+		"vpc":                       "r006-vpc",
+		"address_prefix_management": "manual",
+		"zone":                      "us-south-1",
+		"total_ipv4_address_count":  256,
+		"tags":                      []interface{}{"env:test", "app:web"},
+		"api_key":                   "secret",
+		"rule": []interface{}{
+			map[string]interface{}{"direction": "inbound", "port": 22},
+			map[string]interface{}{"direction": "outbound"},
+		},
+	})
+	d.SetId("r006-subnet")
+	d.Set("crn", "crn:v1:subnet")
+
+	file := hclwrite.NewEmptyFile()
+	generated := &generatedResource{
+		resourceType: "ibm_is_subnet",
+		name:         "example",
+		importID:     "r006-subnet",
+		resource:     r,
+		data:         d,
+	}
+	writeImport(file.Body(), generated)
+	writeResource(file.Body(), generated, map[string]string{
+		"r006-vpc":    "ibm_is_vpc.example",
+		"r006-subnet": "ibm_is_subnet.example",
+	})
+
+	expected := `import {
+  to = ibm_is_subnet.example
+  id = "r006-subnet"
+}
+
+resource "ibm_is_subnet" "example" {
+  name                      = "example"
+  vpc                       = ibm_is_vpc.example.id
+  address_prefix_management = "manual"
+  # api_key is sensitive and is not read, set it before applying
+  tags                     = ["app:web", "env:test"]
+  total_ipv4_address_count = 256
+  rule {
+    direction = "inbound"
+    port      = 22
+  }
+  rule {
+    direction = "outbound"
+  }
+}
+`
+	if actual := string(hclwrite.Format(file.Bytes())); actual != expected {
+		t.Errorf("unexpected configuration:\n%s\nexpected:\n%s", actual, expected)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package generate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// resourceName derives a valid and unique resource name from the name of an
+// existing resource.
+func resourceName(name string, used map[string]bool) string {
+	name = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "_"), "_-")
+	if name == "" {
+		name = "resource"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "r_" + name
+	}
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = name + "_" + strconv.Itoa(i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// writeImport writes the Terraform 1.5 import block of the resource.
+func writeImport(body *hclwrite.Body, r *generatedResource) {
+	block := body.AppendNewBlock("import", nil).Body()
+	block.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: r.resourceType},
+		hcl.TraverseAttr{Name: r.name},
+	})
+	block.SetAttributeValue("id", cty.StringVal(r.importID))
+	body.AppendNewline()
+}
+
+// writeResource writes the resource block with the arguments that are read
+// from the existing resource.
+func writeResource(body *hclwrite.Body, r *generatedResource, references map[string]string) {
+	values := make(map[string]interface{}, len(r.resource.Schema))
+	for key := range r.resource.Schema {
+		if value, ok := r.data.GetOk(key); ok {
+			values[key] = value
+		}
+	}
+	block := body.AppendNewBlock("resource", []string{r.resourceType, r.name}).Body()
+	writeSchema(block, r.resource.Schema, values, references, r.data.Id())
+}
+
+// writeSchema writes the arguments of the schema. Computed only and
+// deprecated attributes, and arguments that are unset or equal to their
+// default, are omitted.
+func writeSchema(body *hclwrite.Body, schemaMap map[string]*schema.Schema, values map[string]interface{}, references map[string]string, selfID string) {
+	written := make(map[string]bool)
+	for _, key := range argumentOrder(schemaMap) {
+		s := schemaMap[key]
+		value, ok := values[key]
+		if !ok || isOmitted(s, value) || conflictsWithWritten(s, written) {
+			continue
+		}
+		written[key] = true
+		if s.Sensitive {
+			body.AppendUnstructuredTokens(hclwrite.Tokens{{
+				Type:  hclsyntax.TokenComment,
+				Bytes: []byte(fmt.Sprintf("# %s is sensitive and is not read, set it before applying\n", key)),
+			}})
+			continue
+		}
+		if isBlock(s) {
+			nested := s.Elem.(*schema.Resource)
+			for _, item := range listValue(value) {
+				if itemValues, ok := item.(map[string]interface{}); ok {
+					block := body.AppendNewBlock(key, nil).Body()
+					writeSchema(block, nested.Schema, itemValues, references, selfID)
+				}
+			}
+			continue
+		}
+		body.SetAttributeRaw(key, valueTokens(value, references, selfID))
+	}
+}
+
+// argumentOrder returns the arguments of the schema, required arguments
+// first and nested blocks last.
+func argumentOrder(schemaMap map[string]*schema.Schema) []string {
+	keys := make([]string, 0, len(schemaMap))
+	for key, s := range schemaMap {
+		if (s.Computed && !s.Optional && !s.Required) || s.Deprecated != "" {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		si, sj := schemaMap[keys[i]], schemaMap[keys[j]]
+		if si.Required != sj.Required {
+			return si.Required
+		}
+		if bi, bj := isBlock(si), isBlock(sj); bi != bj {
+			return bj
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// conflictsWithWritten reports whether an argument that conflicts with the
+// argument has already been written.
+func conflictsWithWritten(s *schema.Schema, written map[string]bool) bool {
+	for _, keys := range [][]string{s.ConflictsWith, s.ExactlyOneOf} {
+		for _, key := range keys {
+			if written[key[strings.LastIndex(key, ".")+1:]] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isOmitted reports whether the value is equal to the default of the
+// argument, or is a zero value of an argument without a default.
+func isOmitted(s *schema.Schema, value interface{}) bool {
+	if s.Default != nil {
+		return reflect.DeepEqual(s.Default, value)
+	}
+	if s.Required {
+		return false
+	}
+	switch v := value.(type) {
+	case nil:
+		return true
+	case *schema.Set:
+		return v.Len() == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return reflect.ValueOf(value).IsZero()
+}
+
+func isBlock(s *schema.Schema) bool {
+	_, ok := s.Elem.(*schema.Resource)
+	return ok
+}
+
+func listValue(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case *schema.Set:
+		return v.List()
+	case []interface{}:
+		return v
+	}
+	return nil
+}
+
+// valueTokens returns the tokens of a value, strings that are the IDs of other
+// generated resources are written as references to them.
+func valueTokens(value interface{}, references map[string]string, selfID string) hclwrite.Tokens {
+	switch v := value.(type) {
+	case string:
+		if address, ok := references[v]; ok && v != selfID {
+			parts := strings.SplitN(address, ".", 2)
+			return hclwrite.TokensForTraversal(hcl.Traversal{
+				hcl.TraverseRoot{Name: parts[0]},
+				hcl.TraverseAttr{Name: parts[1]},
+				hcl.TraverseAttr{Name: "id"},
+			})
+		}
+		return hclwrite.TokensForValue(cty.StringVal(v))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v))
+	case int:
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(v)))
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(v))
+	case *schema.Set:
+		return tupleTokens(sortedList(v.List()), references, selfID)
+	case []interface{}:
+		return tupleTokens(v, references, selfID)
+	case map[string]interface{}:
+		attrs := make(map[string]cty.Value, len(v))
+		for key, item := range v {
+			attrs[key] = cty.StringVal(fmt.Sprint(item))
+		}
+		return hclwrite.TokensForValue(cty.MapVal(attrs))
+	}
+	return hclwrite.TokensForValue(cty.StringVal(fmt.Sprint(value)))
+}
+
+func tupleTokens(items []interface{}, references map[string]string, selfID string) hclwrite.Tokens {
+	tuple := make([]hclwrite.Tokens, 0, len(items))
+	for _, item := range items {
+		tuple = append(tuple, valueTokens(item, references, selfID))
+	}
+	return hclwrite.TokensForTuple(tuple)
+}
+
+// sortedList sorts the elements of a set so that the output is stable.
+func sortedList(items []interface{}) []interface{} {
+	sort.SliceStable(items, func(i, j int) bool {
+		return fmt.Sprint(items[i]) < fmt.Sprint(items[j])
+	})
+	return items
+}
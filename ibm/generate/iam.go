@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package generate
+
+import (
+	"fmt"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/platform-services-go-sdk/iamidentityv1"
+)
+
+var iamGenerators = []resourceGenerator{
+	{"ibm_iam_access_group", listAccessGroups},
+	{"ibm_iam_service_id", listServiceIDs},
+}
+
+// listAccessGroups lists the access groups of the account, the public access
+// group is managed by IAM and is not listed.
+func listAccessGroups(meta interface{}) ([]importable, error) {
+	iamAccessGroupsClient, err := meta.(conns.ClientSession).IAMAccessGroupsV2()
+	if err != nil {
+		return nil, err
+	}
+	userDetails, err := meta.(conns.ClientSession).BluemixUserDetails()
+	if err != nil {
+		return nil, err
+	}
+	importables := []importable{}
+	offset := int64(0)
+	limit := int64(100)
+	listAccessGroupOption := iamAccessGroupsClient.NewListAccessGroupsOptions(userDetails.UserAccount)
+	listAccessGroupOption.SetHidePublicAccess(true)
+	listAccessGroupOption.SetLimit(limit)
+	for {
+		listAccessGroupOption.SetOffset(offset)
+		retreivedGroups, detailedResponse, err := iamAccessGroupsClient.ListAccessGroups(listAccessGroupOption)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error retrieving access groups: %s. API Response is: %s", err, detailedResponse)
+		}
+		for _, group := range retreivedGroups.Groups {
+			importables = append(importables, importable{id: *group.ID, name: *group.Name})
+		}
+		offset = offset + limit
+		if len(retreivedGroups.Groups) == 0 || offset >= *retreivedGroups.TotalCount {
+			return importables, nil
+		}
+	}
+}
+
+func listServiceIDs(meta interface{}) ([]importable, error) {
+	iamClient, err := meta.(conns.ClientSession).IAMIdentityV1API()
+	if err != nil {
+		return nil, err
+	}
+	userDetails, err := meta.(conns.ClientSession).BluemixUserDetails()
+	if err != nil {
+		return nil, err
+	}
+	importables := []importable{}
+	start := ""
+	var pg int64 = 100
+	for {
+		listServiceIDOptions := iamidentityv1.ListServiceIdsOptions{
+			AccountID: &userDetails.UserAccount,
+			Pagesize:  &pg,
+		}
+		if start != "" {
+			listServiceIDOptions.Pagetoken = &start
+		}
+		serviceIDs, resp, err := iamClient.ListServiceIds(&listServiceIDOptions)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error listing Service Ids %s %s", err, resp)
+		}
+		for _, serviceID := range serviceIDs.Serviceids {
+			importables = append(importables, importable{id: *serviceID.ID, name: *serviceID.Name})
+		}
+		start = flex.GetNextIAM(serviceIDs.Next)
+		if start == "" {
+			return importables, nil
+		}
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package generate
+
+import (
+	"fmt"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+)
+
+var vpcGenerators = []resourceGenerator{
+	{"ibm_is_vpc", listVPCs},
+	{"ibm_is_public_gateway", listPublicGateways},
+	{"ibm_is_network_acl", listNetworkACLs},
+	{"ibm_is_subnet", listSubnets},
+	{"ibm_is_security_group", listSecurityGroups},
+	{"ibm_is_security_group_rule", listSecurityGroupRules},
+	{"ibm_is_ssh_key", listSSHKeys},
+}
+
+func listVPCs(meta interface{}) ([]importable, error) {
+	vpcs, err := vpcs(meta)
+	if err != nil {
+		return nil, err
+	}
+	importables := make([]importable, 0, len(vpcs))
+	for _, vpc := range vpcs {
+		importables = append(importables, importable{id: *vpc.ID, name: *vpc.Name})
+	}
+	return importables, nil
+}
+
+func vpcs(meta interface{}) ([]vpcv1.VPC, error) {
+	sess, err := meta.(conns.ClientSession).VpcV1API()
+	if err != nil {
+		return nil, err
+	}
+	vpcs := []vpcv1.VPC{}
+	start := ""
+	listOptions := &vpcv1.ListVpcsOptions{}
+	for {
+		if start != "" {
+			listOptions.Start = &start
+		}
+		result, response, err := sess.ListVpcs(listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("%s\n%s", err, response)
+		}
+		vpcs = append(vpcs, result.Vpcs...)
+		start = flex.GetNext(result.Next)
+		if start == "" {
+			return vpcs, nil
+		}
+	}
+}
+
+// vpcDefaults returns the IDs of the default security groups and network
+// ACLs of the VPCs. They are created and deleted with their VPC, and are not
+// generated.
+func vpcDefaults(meta interface{}) (map[string]bool, error) {
+	vpcs, err := vpcs(meta)
+	if err != nil {
+		return nil, err
+	}
+	defaults := map[string]bool{}
+	for _, vpc := range vpcs {
+		if vpc.DefaultSecurityGroup != nil {
+			defaults[*vpc.DefaultSecurityGroup.ID] = true
+		}
+		if vpc.DefaultNetworkACL != nil {
+			defaults[*vpc.DefaultNetworkACL.ID] = true
+		}
+	}
+	return defaults, nil
+}
+
+func listPublicGateways(meta interface{}) ([]importable, error) {
+	sess, err := meta.(conns.ClientSession).VpcV1API()
+	if err != nil {
+		return nil, err
+	}
+	importables := []importable{}
+	start := ""
+	listOptions := &vpcv1.ListPublicGatewaysOptions{}
+	for {
+		if start != "" {
+			listOptions.Start = &start
+		}
+		result, response, err := sess.ListPublicGateways(listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("%s\n%s", err, response)
+		}
+		for _, gateway := range result.PublicGateways {
+			importables = append(importables, importable{id: *gateway.ID, name: *gateway.Name})
+		}
+		start = flex.GetNext(result.Next)
+		if start == "" {
+			return importables, nil
+		}
+	}
+}
+
+func listNetworkACLs(meta interface{}) ([]importable, error) {
+	defaults, err := vpcDefaults(meta)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := meta.(conns.ClientSession).VpcV1API()
+	if err != nil {
+		return nil, err
+	}
+	importables := []importable{}
+	start := ""
+	listOptions := &vpcv1.ListNetworkAclsOptions{}
+	for {
+		if start != "" {
+			listOptions.Start = &start
+		}
+		result, response, err := sess.ListNetworkAcls(listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("%s\n%s", err, response)
+		}
+		for _, acl := range result.NetworkAcls {
+			if defaults[*acl.ID] {
+				continue
+			}
+			importables = append(importables, importable{id: *acl.ID, name: *acl.Name})
+		}
+		start = flex.GetNext(result.Next)
+		if start == "" {
+			return importables, nil
+		}
+	}
+}
+
+func listSubnets(meta interface{}) ([]importable, error) {
+	sess, err := meta.(conns.ClientSession).VpcV1API()
+	if err != nil {
+		return nil, err
+	}
+	importables := []importable{}
+	start := ""
+	listOptions := &vpcv1.ListSubnetsOptions{}
+	for {
+		if start != "" {
+			listOptions.Start = &start
+		}
+		result, response, err := sess.ListSubnets(listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("%s\n%s", err, response)
+		}
+		for _, subnet := range result.Subnets {
+			importables = append(importables, importable{id: *subnet.ID, name: *subnet.Name})
+		}
+		start = flex.GetNext(result.Next)
+		if start == "" {
+			return importables, nil
+		}
+	}
+}
+
+func listSecurityGroups(meta interface{}) ([]importable, error) {
+	groups, err := securityGroups(meta)
+	if err != nil {
+		return nil, err
+	}
+	importables := make([]importable, 0, len(groups))
+	for _, group := range groups {
+		importables = append(importables, importable{id: *group.ID, name: *group.Name})
+	}
+	return importables, nil
+}
+
+// listSecurityGroupRules lists the rules of the security groups, the import ID
+// of a rule is <security_group_id>.<rule_id>.
+func listSecurityGroupRules(meta interface{}) ([]importable, error) {
+	groups, err := securityGroups(meta)
+	if err != nil {
+		return nil, err
+	}
+	importables := []importable{}
+	for _, group := range groups {
+		for i, rule := range group.Rules {
+			var ruleID *string
+			switch rulex := rule.(type) {
+			case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll:
+				ruleID = rulex.ID
+			case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolIcmp:
+				ruleID = rulex.ID
+			case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp:
+				ruleID = rulex.ID
+			}
+			if ruleID == nil {
+				continue
+			}
+			importables = append(importables, importable{
+				id:   *group.ID + "." + *ruleID,
+				name: fmt.Sprintf("%s_rule_%d", *group.Name, i+1),
+			})
+		}
+	}
+	return importables, nil
+}
+
+// securityGroups lists the security groups that are not the default security
+// group of a VPC.
+func securityGroups(meta interface{}) ([]vpcv1.SecurityGroup, error) {
+	defaults, err := vpcDefaults(meta)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := meta.(conns.ClientSession).VpcV1API()
+	if err != nil {
+		return nil, err
+	}
+	groups := []vpcv1.SecurityGroup{}
+	start := ""
+	listOptions := &vpcv1.ListSecurityGroupsOptions{}
+	for {
+		if start != "" {
+			listOptions.Start = &start
+		}
+		result, response, err := sess.ListSecurityGroups(listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("%s\n%s", err, response)
+		}
+		for _, group := range result.SecurityGroups {
+			if !defaults[*group.ID] {
+				groups = append(groups, group)
+			}
+		}
+		start = flex.GetNext(result.Next)
+		if start == "" {
+			return groups, nil
+		}
+	}
+}
+
+func listSSHKeys(meta interface{}) ([]importable, error) {
+	sess, err := meta.(conns.ClientSession).VpcV1API()
+	if err != nil {
+		return nil, err
+	}
+	importables := []importable{}
+	start := ""
+	listOptions := &vpcv1.ListKeysOptions{}
+	for {
+		if start != "" {
+			listOptions.Start = &start
+		}
+		result, response, err := sess.ListKeys(listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("%s\n%s", err, response)
+		}
+		for _, key := range result.Keys {
+			importables = append(importables, importable{id: *key.ID, name: *key.Name})
+		}
+		start = flex.GetNext(result.Next)
+		if start == "" {
+			return importables, nil
+		}
+	}
+}
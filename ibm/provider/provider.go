@@ -20,6 +20,7 @@ import (
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/classicinfrastructure"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/cloudant"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/cloudfoundry"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/cloudinit"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/cloudshell"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/codeengine"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/contextbasedrestrictions"
@@ -224,32 +225,33 @@ func Provider() *schema.Provider {
 			"ibm_app_route":          cloudfoundry.DataSourceIBMAppRoute(),
 
 			// // AppID
-			"ibm_appid_action_url":               appid.DataSourceIBMAppIDActionURL(),
-			"ibm_appid_apm":                      appid.DataSourceIBMAppIDAPM(),
-			"ibm_appid_application":              appid.DataSourceIBMAppIDApplication(),
-			"ibm_appid_application_scopes":       appid.DataSourceIBMAppIDApplicationScopes(),
-			"ibm_appid_application_roles":        appid.DataSourceIBMAppIDApplicationRoles(),
-			"ibm_appid_applications":             appid.DataSourceIBMAppIDApplications(),
-			"ibm_appid_audit_status":             appid.DataSourceIBMAppIDAuditStatus(),
-			"ibm_appid_cloud_directory_template": appid.DataSourceIBMAppIDCloudDirectoryTemplate(),
-			"ibm_appid_cloud_directory_user":     appid.DataSourceIBMAppIDCloudDirectoryUser(),
-			"ibm_appid_idp_cloud_directory":      appid.DataSourceIBMAppIDIDPCloudDirectory(),
-			"ibm_appid_idp_custom":               appid.DataSourceIBMAppIDIDPCustom(),
-			"ibm_appid_idp_facebook":             appid.DataSourceIBMAppIDIDPFacebook(),
-			"ibm_appid_idp_google":               appid.DataSourceIBMAppIDIDPGoogle(),
-			"ibm_appid_idp_saml":                 appid.DataSourceIBMAppIDIDPSAML(),
-			"ibm_appid_idp_saml_metadata":        appid.DataSourceIBMAppIDIDPSAMLMetadata(),
-			"ibm_appid_languages":                appid.DataSourceIBMAppIDLanguages(),
-			"ibm_appid_mfa":                      appid.DataSourceIBMAppIDMFA(),
-			"ibm_appid_mfa_channel":              appid.DataSourceIBMAppIDMFAChannel(),
-			"ibm_appid_password_regex":           appid.DataSourceIBMAppIDPasswordRegex(),
-			"ibm_appid_token_config":             appid.DataSourceIBMAppIDTokenConfig(),
-			"ibm_appid_redirect_urls":            appid.DataSourceIBMAppIDRedirectURLs(),
-			"ibm_appid_role":                     appid.DataSourceIBMAppIDRole(),
-			"ibm_appid_roles":                    appid.DataSourceIBMAppIDRoles(),
-			"ibm_appid_theme_color":              appid.DataSourceIBMAppIDThemeColor(),
-			"ibm_appid_theme_text":               appid.DataSourceIBMAppIDThemeText(),
-			"ibm_appid_user_roles":               appid.DataSourceIBMAppIDUserRoles(),
+			"ibm_appid_action_url":                   appid.DataSourceIBMAppIDActionURL(),
+			"ibm_appid_apm":                          appid.DataSourceIBMAppIDAPM(),
+			"ibm_appid_application":                  appid.DataSourceIBMAppIDApplication(),
+			"ibm_appid_application_scopes":           appid.DataSourceIBMAppIDApplicationScopes(),
+			"ibm_appid_application_roles":            appid.DataSourceIBMAppIDApplicationRoles(),
+			"ibm_appid_applications":                 appid.DataSourceIBMAppIDApplications(),
+			"ibm_appid_audit_status":                 appid.DataSourceIBMAppIDAuditStatus(),
+			"ibm_appid_cloud_directory_template":     appid.DataSourceIBMAppIDCloudDirectoryTemplate(),
+			"ibm_appid_cloud_directory_user":         appid.DataSourceIBMAppIDCloudDirectoryUser(),
+			"ibm_appid_cloud_directory_users_export": appid.DataSourceIBMAppIDCloudDirectoryUsersExport(),
+			"ibm_appid_idp_cloud_directory":          appid.DataSourceIBMAppIDIDPCloudDirectory(),
+			"ibm_appid_idp_custom":                   appid.DataSourceIBMAppIDIDPCustom(),
+			"ibm_appid_idp_facebook":                 appid.DataSourceIBMAppIDIDPFacebook(),
+			"ibm_appid_idp_google":                   appid.DataSourceIBMAppIDIDPGoogle(),
+			"ibm_appid_idp_saml":                     appid.DataSourceIBMAppIDIDPSAML(),
+			"ibm_appid_idp_saml_metadata":            appid.DataSourceIBMAppIDIDPSAMLMetadata(),
+			"ibm_appid_languages":                    appid.DataSourceIBMAppIDLanguages(),
+			"ibm_appid_mfa":                          appid.DataSourceIBMAppIDMFA(),
+			"ibm_appid_mfa_channel":                  appid.DataSourceIBMAppIDMFAChannel(),
+			"ibm_appid_password_regex":               appid.DataSourceIBMAppIDPasswordRegex(),
+			"ibm_appid_token_config":                 appid.DataSourceIBMAppIDTokenConfig(),
+			"ibm_appid_redirect_urls":                appid.DataSourceIBMAppIDRedirectURLs(),
+			"ibm_appid_role":                         appid.DataSourceIBMAppIDRole(),
+			"ibm_appid_roles":                        appid.DataSourceIBMAppIDRoles(),
+			"ibm_appid_theme_color":                  appid.DataSourceIBMAppIDThemeColor(),
+			"ibm_appid_theme_text":                   appid.DataSourceIBMAppIDThemeText(),
+			"ibm_appid_user_roles":                   appid.DataSourceIBMAppIDUserRoles(),
 
 			"ibm_function_action":                   functions.DataSourceIBMFunctionAction(),
 			"ibm_function_package":                  functions.DataSourceIBMFunctionPackage(),
@@ -328,6 +330,7 @@ func Provider() *schema.Provider {
 			"ibm_container_dedicated_host":          kubernetes.DataSourceIBMContainerDedicatedHost(),
 			"ibm_cr_namespaces":                     registry.DataIBMContainerRegistryNamespaces(),
 			"ibm_cloud_shell_account_settings":      cloudshell.DataSourceIBMCloudShellAccountSettings(),
+			"ibm_cloudinit_config":                  cloudinit.DataSourceIBMCloudInitConfig(),
 			"ibm_cos_bucket":                        cos.DataSourceIBMCosBucket(),
 			"ibm_cos_bucket_object":                 cos.DataSourceIBMCosBucketObject(),
 			"ibm_dns_domain_registration":           classicinfrastructure.DataSourceIBMDNSDomainRegistration(),
@@ -398,6 +401,7 @@ func Provider() *schema.Provider {
 			"ibm_is_floating_ips":                    vpc.DataSourceIBMIsFloatingIps(),
 			"ibm_is_flow_log":                        vpc.DataSourceIBMIsFlowLog(),
 			"ibm_is_flow_logs":                       vpc.DataSourceIBMISFlowLogs(),
+			"ibm_is_flow_log_analytics":              vpc.DataSourceIBMIsFlowLogAnalytics(),
 			"ibm_is_image":                           vpc.DataSourceIBMISImage(),
 			"ibm_is_images":                          vpc.DataSourceIBMISImages(),
 			"ibm_is_image_export_job":                vpc.DataSourceIBMIsImageExport(),
@@ -431,110 +435,112 @@ func Provider() *schema.Provider {
 			"ibm_is_instance_network_interface_reserved_ip":  vpc.DataSourceIBMISInstanceNICReservedIP(),
 			"ibm_is_instance_network_interface_reserved_ips": vpc.DataSourceIBMISInstanceNICReservedIPs(),
 
-			"ibm_is_instance_volume_attachment":      vpc.DataSourceIBMISInstanceVolumeAttachment(),
-			"ibm_is_instance_volume_attachments":     vpc.DataSourceIBMISInstanceVolumeAttachments(),
-			"ibm_is_ipsec_policy":                    vpc.DataSourceIBMIsIpsecPolicy(),
-			"ibm_is_ipsec_policies":                  vpc.DataSourceIBMIsIpsecPolicies(),
-			"ibm_is_ike_policies":                    vpc.DataSourceIBMIsIkePolicies(),
-			"ibm_is_ike_policy":                      vpc.DataSourceIBMIsIkePolicy(),
-			"ibm_is_lb":                              vpc.DataSourceIBMISLB(),
-			"ibm_is_lb_listener":                     vpc.DataSourceIBMISLBListener(),
-			"ibm_is_lb_listeners":                    vpc.DataSourceIBMISLBListeners(),
-			"ibm_is_lb_listener_policies":            vpc.DataSourceIBMISLBListenerPolicies(),
-			"ibm_is_lb_listener_policy":              vpc.DataSourceIBMISLBListenerPolicy(),
-			"ibm_is_lb_listener_policy_rule":         vpc.DataSourceIBMISLBListenerPolicyRule(),
-			"ibm_is_lb_listener_policy_rules":        vpc.DataSourceIBMISLBListenerPolicyRules(),
-			"ibm_is_lb_pool":                         vpc.DataSourceIBMISLBPool(),
-			"ibm_is_lb_pools":                        vpc.DataSourceIBMISLBPools(),
-			"ibm_is_lb_pool_member":                  vpc.DataSourceIBMIBLBPoolMember(),
-			"ibm_is_lb_pool_members":                 vpc.DataSourceIBMISLBPoolMembers(),
-			"ibm_is_lb_profile":                      vpc.DataSourceIBMISLbProfile(),
-			"ibm_is_lb_profiles":                     vpc.DataSourceIBMISLbProfiles(),
-			"ibm_is_lbs":                             vpc.DataSourceIBMISLBS(),
-			"ibm_is_public_gateway":                  vpc.DataSourceIBMISPublicGateway(),
-			"ibm_is_public_gateways":                 vpc.DataSourceIBMISPublicGateways(),
-			"ibm_is_region":                          vpc.DataSourceIBMISRegion(),
-			"ibm_is_regions":                         vpc.DataSourceIBMISRegions(),
-			"ibm_is_ssh_key":                         vpc.DataSourceIBMISSSHKey(),
-			"ibm_is_ssh_keys":                        vpc.DataSourceIBMIsSshKeys(),
-			"ibm_is_subnet":                          vpc.DataSourceIBMISSubnet(),
-			"ibm_is_subnets":                         vpc.DataSourceIBMISSubnets(),
-			"ibm_is_subnet_reserved_ip":              vpc.DataSourceIBMISReservedIP(),
-			"ibm_is_subnet_reserved_ips":             vpc.DataSourceIBMISReservedIPs(),
-			"ibm_is_security_group":                  vpc.DataSourceIBMISSecurityGroup(),
-			"ibm_is_security_groups":                 vpc.DataSourceIBMIsSecurityGroups(),
-			"ibm_is_security_group_rule":             vpc.DataSourceIBMIsSecurityGroupRule(),
-			"ibm_is_security_group_rules":            vpc.DataSourceIBMIsSecurityGroupRules(),
-			"ibm_is_security_group_target":           vpc.DataSourceIBMISSecurityGroupTarget(),
-			"ibm_is_security_group_targets":          vpc.DataSourceIBMISSecurityGroupTargets(),
-			"ibm_is_snapshot_clone":                  vpc.DataSourceSnapshotClone(),
-			"ibm_is_snapshot_clones":                 vpc.DataSourceSnapshotClones(),
-			"ibm_is_snapshot":                        vpc.DataSourceSnapshot(),
-			"ibm_is_snapshots":                       vpc.DataSourceSnapshots(),
-			"ibm_is_share":                           vpc.DataSourceIbmIsShare(),
-			"ibm_is_source_share":                    vpc.DataSourceIbmIsSourceShare(),
-			"ibm_is_shares":                          vpc.DataSourceIbmIsShares(),
-			"ibm_is_share_profile":                   vpc.DataSourceIbmIsShareProfile(),
-			"ibm_is_share_profiles":                  vpc.DataSourceIbmIsShareProfiles(),
-			"ibm_is_share_target":                    vpc.DataSourceIbmIsShareTarget(),
-			"ibm_is_share_targets":                   vpc.DataSourceIbmIsShareTargets(),
-			"ibm_is_share_mount_target":              vpc.DataSourceIBMIsShareTarget(),
-			"ibm_is_share_mount_targets":             vpc.DataSourceIBMIsShareTargets(),
-			"ibm_is_volume":                          vpc.DataSourceIBMISVolume(),
-			"ibm_is_volumes":                         vpc.DataSourceIBMIsVolumes(),
-			"ibm_is_volume_profile":                  vpc.DataSourceIBMISVolumeProfile(),
-			"ibm_is_volume_profiles":                 vpc.DataSourceIBMISVolumeProfiles(),
-			"ibm_is_vpc":                             vpc.DataSourceIBMISVPC(),
-			"ibm_is_vpcs":                            vpc.DataSourceIBMISVPCs(),
-			"ibm_is_vpn_gateway":                     vpc.DataSourceIBMISVPNGateway(),
-			"ibm_is_vpn_gateways":                    vpc.DataSourceIBMISVPNGateways(),
-			"ibm_is_vpc_address_prefixes":            vpc.DataSourceIbmIsVpcAddressPrefixes(),
-			"ibm_is_vpc_address_prefix":              vpc.DataSourceIBMIsVPCAddressPrefix(),
-			"ibm_is_vpn_gateway_connection":          vpc.DataSourceIBMISVPNGatewayConnection(),
-			"ibm_is_vpn_gateway_connections":         vpc.DataSourceIBMISVPNGatewayConnections(),
-			"ibm_is_vpc_default_routing_table":       vpc.DataSourceIBMISVPCDefaultRoutingTable(),
-			"ibm_is_vpc_routing_table":               vpc.DataSourceIBMIBMIsVPCRoutingTable(),
-			"ibm_is_vpc_routing_tables":              vpc.DataSourceIBMISVPCRoutingTables(),
-			"ibm_is_vpc_routing_table_route":         vpc.DataSourceIBMIBMIsVPCRoutingTableRoute(),
-			"ibm_is_vpc_routing_table_routes":        vpc.DataSourceIBMISVPCRoutingTableRoutes(),
-			"ibm_is_vpn_server":                      vpc.DataSourceIBMIsVPNServer(),
-			"ibm_is_vpn_servers":                     vpc.DataSourceIBMIsVPNServers(),
-			"ibm_is_vpn_server_client":               vpc.DataSourceIBMIsVPNServerClient(),
-			"ibm_is_vpn_server_client_configuration": vpc.DataSourceIBMIsVPNServerClientConfiguration(),
-			"ibm_is_vpn_server_clients":              vpc.DataSourceIBMIsVPNServerClients(),
-			"ibm_is_vpn_server_route":                vpc.DataSourceIBMIsVPNServerRoute(),
-			"ibm_is_vpn_server_routes":               vpc.DataSourceIBMIsVPNServerRoutes(),
-			"ibm_is_zone":                            vpc.DataSourceIBMISZone(),
-			"ibm_is_zones":                           vpc.DataSourceIBMISZones(),
-			"ibm_is_operating_system":                vpc.DataSourceIBMISOperatingSystem(),
-			"ibm_is_operating_systems":               vpc.DataSourceIBMISOperatingSystems(),
-			"ibm_is_network_acls":                    vpc.DataSourceIBMIsNetworkAcls(),
-			"ibm_is_network_acl":                     vpc.DataSourceIBMIsNetworkACL(),
-			"ibm_is_network_acl_rule":                vpc.DataSourceIBMISNetworkACLRule(),
-			"ibm_is_network_acl_rules":               vpc.DataSourceIBMISNetworkACLRules(),
-			"ibm_lbaas":                              classicinfrastructure.DataSourceIBMLbaas(),
-			"ibm_network_vlan":                       classicinfrastructure.DataSourceIBMNetworkVlan(),
-			"ibm_org":                                cloudfoundry.DataSourceIBMOrg(),
-			"ibm_org_quota":                          cloudfoundry.DataSourceIBMOrgQuota(),
-			"ibm_kms_instance_policies":              kms.DataSourceIBMKmsInstancePolicies(),
-			"ibm_kp_key":                             kms.DataSourceIBMkey(),
-			"ibm_kms_key_rings":                      kms.DataSourceIBMKMSkeyRings(),
-			"ibm_kms_key_policies":                   kms.DataSourceIBMKMSkeyPolicies(),
-			"ibm_kms_keys":                           kms.DataSourceIBMKMSkeys(),
-			"ibm_kms_key":                            kms.DataSourceIBMKMSkey(),
-			"ibm_pn_application_chrome":              pushnotification.DataSourceIBMPNApplicationChrome(),
-			"ibm_app_config_environment":             appconfiguration.DataSourceIBMAppConfigEnvironment(),
-			"ibm_app_config_environments":            appconfiguration.DataSourceIBMAppConfigEnvironments(),
-			"ibm_app_config_collection":              appconfiguration.DataSourceIBMAppConfigCollection(),
-			"ibm_app_config_collections":             appconfiguration.DataSourceIBMAppConfigCollections(),
-			"ibm_app_config_feature":                 appconfiguration.DataSourceIBMAppConfigFeature(),
-			"ibm_app_config_features":                appconfiguration.DataSourceIBMAppConfigFeatures(),
-			"ibm_app_config_property":                appconfiguration.DataSourceIBMAppConfigProperty(),
-			"ibm_app_config_properties":              appconfiguration.DataSourceIBMAppConfigProperties(),
-			"ibm_app_config_segment":                 appconfiguration.DataSourceIBMAppConfigSegment(),
-			"ibm_app_config_segments":                appconfiguration.DataSourceIBMAppConfigSegments(),
-			"ibm_app_config_snapshot":                appconfiguration.DataSourceIBMAppConfigSnapshot(),
-			"ibm_app_config_snapshots":               appconfiguration.DataSourceIBMAppConfigSnapshots(),
+			"ibm_is_instance_volume_attachment":         vpc.DataSourceIBMISInstanceVolumeAttachment(),
+			"ibm_is_instance_volume_attachments":        vpc.DataSourceIBMISInstanceVolumeAttachments(),
+			"ibm_is_ipsec_policy":                       vpc.DataSourceIBMIsIpsecPolicy(),
+			"ibm_is_ipsec_policies":                     vpc.DataSourceIBMIsIpsecPolicies(),
+			"ibm_is_ike_policies":                       vpc.DataSourceIBMIsIkePolicies(),
+			"ibm_is_ike_policy":                         vpc.DataSourceIBMIsIkePolicy(),
+			"ibm_is_lb":                                 vpc.DataSourceIBMISLB(),
+			"ibm_is_lb_listener":                        vpc.DataSourceIBMISLBListener(),
+			"ibm_is_lb_listeners":                       vpc.DataSourceIBMISLBListeners(),
+			"ibm_is_lb_listener_policies":               vpc.DataSourceIBMISLBListenerPolicies(),
+			"ibm_is_lb_listener_policy":                 vpc.DataSourceIBMISLBListenerPolicy(),
+			"ibm_is_lb_listener_policy_rule":            vpc.DataSourceIBMISLBListenerPolicyRule(),
+			"ibm_is_lb_listener_policy_rules":           vpc.DataSourceIBMISLBListenerPolicyRules(),
+			"ibm_is_lb_pool":                            vpc.DataSourceIBMISLBPool(),
+			"ibm_is_lb_pools":                           vpc.DataSourceIBMISLBPools(),
+			"ibm_is_lb_pool_member":                     vpc.DataSourceIBMIBLBPoolMember(),
+			"ibm_is_lb_pool_members":                    vpc.DataSourceIBMISLBPoolMembers(),
+			"ibm_is_lb_profile":                         vpc.DataSourceIBMISLbProfile(),
+			"ibm_is_lb_profiles":                        vpc.DataSourceIBMISLbProfiles(),
+			"ibm_is_lbs":                                vpc.DataSourceIBMISLBS(),
+			"ibm_is_public_gateway":                     vpc.DataSourceIBMISPublicGateway(),
+			"ibm_is_public_gateways":                    vpc.DataSourceIBMISPublicGateways(),
+			"ibm_is_region":                             vpc.DataSourceIBMISRegion(),
+			"ibm_is_regions":                            vpc.DataSourceIBMISRegions(),
+			"ibm_is_ssh_key":                            vpc.DataSourceIBMISSSHKey(),
+			"ibm_is_ssh_keys":                           vpc.DataSourceIBMIsSshKeys(),
+			"ibm_is_subnet":                             vpc.DataSourceIBMISSubnet(),
+			"ibm_is_subnets":                            vpc.DataSourceIBMISSubnets(),
+			"ibm_is_subnet_reserved_ip":                 vpc.DataSourceIBMISReservedIP(),
+			"ibm_is_subnet_reserved_ips":                vpc.DataSourceIBMISReservedIPs(),
+			"ibm_is_security_group":                     vpc.DataSourceIBMISSecurityGroup(),
+			"ibm_is_security_groups":                    vpc.DataSourceIBMIsSecurityGroups(),
+			"ibm_is_packet_path":                        vpc.DataSourceIBMISPacketPath(),
+			"ibm_is_security_group_rule":                vpc.DataSourceIBMIsSecurityGroupRule(),
+			"ibm_is_security_group_rules":               vpc.DataSourceIBMIsSecurityGroupRules(),
+			"ibm_is_security_group_target":              vpc.DataSourceIBMISSecurityGroupTarget(),
+			"ibm_is_security_group_targets":             vpc.DataSourceIBMISSecurityGroupTargets(),
+			"ibm_is_snapshot_clone":                     vpc.DataSourceSnapshotClone(),
+			"ibm_is_snapshot_clones":                    vpc.DataSourceSnapshotClones(),
+			"ibm_is_snapshot":                           vpc.DataSourceSnapshot(),
+			"ibm_is_snapshots":                          vpc.DataSourceSnapshots(),
+			"ibm_is_share":                              vpc.DataSourceIbmIsShare(),
+			"ibm_is_source_share":                       vpc.DataSourceIbmIsSourceShare(),
+			"ibm_is_shares":                             vpc.DataSourceIbmIsShares(),
+			"ibm_is_share_profile":                      vpc.DataSourceIbmIsShareProfile(),
+			"ibm_is_share_profiles":                     vpc.DataSourceIbmIsShareProfiles(),
+			"ibm_is_share_target":                       vpc.DataSourceIbmIsShareTarget(),
+			"ibm_is_share_targets":                      vpc.DataSourceIbmIsShareTargets(),
+			"ibm_is_share_mount_target":                 vpc.DataSourceIBMIsShareTarget(),
+			"ibm_is_share_mount_targets":                vpc.DataSourceIBMIsShareTargets(),
+			"ibm_is_volume":                             vpc.DataSourceIBMISVolume(),
+			"ibm_is_volumes":                            vpc.DataSourceIBMIsVolumes(),
+			"ibm_is_volume_profile":                     vpc.DataSourceIBMISVolumeProfile(),
+			"ibm_is_volume_profiles":                    vpc.DataSourceIBMISVolumeProfiles(),
+			"ibm_is_vpc":                                vpc.DataSourceIBMISVPC(),
+			"ibm_is_vpcs":                               vpc.DataSourceIBMISVPCs(),
+			"ibm_is_vpn_gateway":                        vpc.DataSourceIBMISVPNGateway(),
+			"ibm_is_vpn_gateways":                       vpc.DataSourceIBMISVPNGateways(),
+			"ibm_is_vpc_address_prefixes":               vpc.DataSourceIbmIsVpcAddressPrefixes(),
+			"ibm_is_vpc_address_prefix":                 vpc.DataSourceIBMIsVPCAddressPrefix(),
+			"ibm_is_vpn_gateway_connection":             vpc.DataSourceIBMISVPNGatewayConnection(),
+			"ibm_is_vpn_gateway_connections":            vpc.DataSourceIBMISVPNGatewayConnections(),
+			"ibm_is_vpn_gateway_connection_peer_config": vpc.DataSourceIBMISVPNGatewayConnectionPeerConfig(),
+			"ibm_is_vpc_default_routing_table":          vpc.DataSourceIBMISVPCDefaultRoutingTable(),
+			"ibm_is_vpc_routing_table":                  vpc.DataSourceIBMIBMIsVPCRoutingTable(),
+			"ibm_is_vpc_routing_tables":                 vpc.DataSourceIBMISVPCRoutingTables(),
+			"ibm_is_vpc_routing_table_route":            vpc.DataSourceIBMIBMIsVPCRoutingTableRoute(),
+			"ibm_is_vpc_routing_table_routes":           vpc.DataSourceIBMISVPCRoutingTableRoutes(),
+			"ibm_is_vpn_server":                         vpc.DataSourceIBMIsVPNServer(),
+			"ibm_is_vpn_servers":                        vpc.DataSourceIBMIsVPNServers(),
+			"ibm_is_vpn_server_client":                  vpc.DataSourceIBMIsVPNServerClient(),
+			"ibm_is_vpn_server_client_configuration":    vpc.DataSourceIBMIsVPNServerClientConfiguration(),
+			"ibm_is_vpn_server_clients":                 vpc.DataSourceIBMIsVPNServerClients(),
+			"ibm_is_vpn_server_route":                   vpc.DataSourceIBMIsVPNServerRoute(),
+			"ibm_is_vpn_server_routes":                  vpc.DataSourceIBMIsVPNServerRoutes(),
+			"ibm_is_zone":                               vpc.DataSourceIBMISZone(),
+			"ibm_is_zones":                              vpc.DataSourceIBMISZones(),
+			"ibm_is_operating_system":                   vpc.DataSourceIBMISOperatingSystem(),
+			"ibm_is_operating_systems":                  vpc.DataSourceIBMISOperatingSystems(),
+			"ibm_is_network_acls":                       vpc.DataSourceIBMIsNetworkAcls(),
+			"ibm_is_network_acl":                        vpc.DataSourceIBMIsNetworkACL(),
+			"ibm_is_network_acl_rule":                   vpc.DataSourceIBMISNetworkACLRule(),
+			"ibm_is_network_acl_rules":                  vpc.DataSourceIBMISNetworkACLRules(),
+			"ibm_lbaas":                                 classicinfrastructure.DataSourceIBMLbaas(),
+			"ibm_network_vlan":                          classicinfrastructure.DataSourceIBMNetworkVlan(),
+			"ibm_org":                                   cloudfoundry.DataSourceIBMOrg(),
+			"ibm_org_quota":                             cloudfoundry.DataSourceIBMOrgQuota(),
+			"ibm_kms_instance_policies":                 kms.DataSourceIBMKmsInstancePolicies(),
+			"ibm_kp_key":                                kms.DataSourceIBMkey(),
+			"ibm_kms_key_rings":                         kms.DataSourceIBMKMSkeyRings(),
+			"ibm_kms_key_policies":                      kms.DataSourceIBMKMSkeyPolicies(),
+			"ibm_kms_keys":                              kms.DataSourceIBMKMSkeys(),
+			"ibm_kms_key":                               kms.DataSourceIBMKMSkey(),
+			"ibm_pn_application_chrome":                 pushnotification.DataSourceIBMPNApplicationChrome(),
+			"ibm_app_config_environment":                appconfiguration.DataSourceIBMAppConfigEnvironment(),
+			"ibm_app_config_environments":               appconfiguration.DataSourceIBMAppConfigEnvironments(),
+			"ibm_app_config_collection":                 appconfiguration.DataSourceIBMAppConfigCollection(),
+			"ibm_app_config_collections":                appconfiguration.DataSourceIBMAppConfigCollections(),
+			"ibm_app_config_feature":                    appconfiguration.DataSourceIBMAppConfigFeature(),
+			"ibm_app_config_features":                   appconfiguration.DataSourceIBMAppConfigFeatures(),
+			"ibm_app_config_property":                   appconfiguration.DataSourceIBMAppConfigProperty(),
+			"ibm_app_config_properties":                 appconfiguration.DataSourceIBMAppConfigProperties(),
+			"ibm_app_config_segment":                    appconfiguration.DataSourceIBMAppConfigSegment(),
+			"ibm_app_config_segments":                   appconfiguration.DataSourceIBMAppConfigSegments(),
+			"ibm_app_config_snapshot":                   appconfiguration.DataSourceIBMAppConfigSnapshot(),
+			"ibm_app_config_snapshots":                  appconfiguration.DataSourceIBMAppConfigSnapshots(),
 
 			"ibm_resource_quota":    resourcecontroller.DataSourceIBMResourceQuota(),
 			"ibm_resource_group":    resourcemanager.DataSourceIBMResourceGroup(),
@@ -644,6 +650,7 @@ func Provider() *schema.Provider {
 			"ibm_tg_location":                  transitgateway.DataSourceIBMTransitGatewaysLocation(),
 			"ibm_tg_route_report":              transitgateway.DataSourceIBMTransitGatewayRouteReport(),
 			"ibm_tg_route_reports":             transitgateway.DataSourceIBMTransitGatewayRouteReports(),
+			"ibm_tg_network_analysis":          transitgateway.DataSourceIBMTransitGatewayNetworkAnalysis(),
 
 			// //Added for BSS Enterprise
 			"ibm_enterprises":               enterprise.DataSourceIBMEnterprises(),
@@ -718,6 +725,7 @@ func Provider() *schema.Provider {
 			"ibm_scc_account_locations":             scc.DataSourceIBMSccAccountLocations(),
 			"ibm_scc_account_location_settings":     scc.DataSourceIBMSccAccountLocationSettings(),
 			"ibm_scc_account_notification_settings": scc.DataSourceIBMSccNotificationSettings(),
+			"ibm_scc_rule_evaluation":               scc.DataSourceIBMSccRuleEvaluation(),
 
 			// Compliance Posture Management
 			"ibm_scc_posture_scopes":            scc.DataSourceIBMSccPostureScopes(),
@@ -734,8 +742,9 @@ func Provider() *schema.Provider {
 			"ibm_scc_posture_credentials":       scc.DataSourceIBMSccPostureCredentials(),
 			"ibm_scc_posture_collectors":        scc.DataSourceIBMSccPostureCollectors(),
 			// // Added for Context Based Restrictions
-			"ibm_cbr_zone": contextbasedrestrictions.DataSourceIBMCbrZone(),
-			"ibm_cbr_rule": contextbasedrestrictions.DataSourceIBMCbrRule(),
+			"ibm_cbr_zone":            contextbasedrestrictions.DataSourceIBMCbrZone(),
+			"ibm_cbr_rule":            contextbasedrestrictions.DataSourceIBMCbrRule(),
+			"ibm_cbr_rule_evaluation": contextbasedrestrictions.DataSourceIBMCbrRuleEvaluation(),
 
 			// // Added for Event Notifications
 			"ibm_en_source":                 eventnotification.DataSourceIBMEnSource(),
@@ -805,6 +814,7 @@ func Provider() *schema.Provider {
 			"ibm_cd_tekton_pipeline_property":         cdtektonpipeline.DataSourceIBMCdTektonPipelineProperty(),
 			"ibm_cd_tekton_pipeline_trigger":          cdtektonpipeline.DataSourceIBMCdTektonPipelineTrigger(),
 			"ibm_cd_tekton_pipeline":                  cdtektonpipeline.DataSourceIBMCdTektonPipeline(),
+			"ibm_cd_tekton_pipeline_runs":             cdtektonpipeline.DataSourceIBMCdTektonPipelineRuns(),
 
 			// Added for Code Engine
 			"ibm_code_engine_app":        codeengine.DataSourceIbmCodeEngineApp(),
@@ -828,29 +838,30 @@ func Provider() *schema.Provider {
 			"ibm_app_route":                         cloudfoundry.ResourceIBMAppRoute(),
 
 			// // AppID
-			"ibm_appid_action_url":               appid.ResourceIBMAppIDActionURL(),
-			"ibm_appid_apm":                      appid.ResourceIBMAppIDAPM(),
-			"ibm_appid_application":              appid.ResourceIBMAppIDApplication(),
-			"ibm_appid_application_scopes":       appid.ResourceIBMAppIDApplicationScopes(),
-			"ibm_appid_application_roles":        appid.ResourceIBMAppIDApplicationRoles(),
-			"ibm_appid_audit_status":             appid.ResourceIBMAppIDAuditStatus(),
-			"ibm_appid_cloud_directory_template": appid.ResourceIBMAppIDCloudDirectoryTemplate(),
-			"ibm_appid_cloud_directory_user":     appid.ResourceIBMAppIDCloudDirectoryUser(),
-			"ibm_appid_idp_cloud_directory":      appid.ResourceIBMAppIDIDPCloudDirectory(),
-			"ibm_appid_idp_custom":               appid.ResourceIBMAppIDIDPCustom(),
-			"ibm_appid_idp_facebook":             appid.ResourceIBMAppIDIDPFacebook(),
-			"ibm_appid_idp_google":               appid.ResourceIBMAppIDIDPGoogle(),
-			"ibm_appid_idp_saml":                 appid.ResourceIBMAppIDIDPSAML(),
-			"ibm_appid_languages":                appid.ResourceIBMAppIDLanguages(),
-			"ibm_appid_mfa":                      appid.ResourceIBMAppIDMFA(),
-			"ibm_appid_mfa_channel":              appid.ResourceIBMAppIDMFAChannel(),
-			"ibm_appid_password_regex":           appid.ResourceIBMAppIDPasswordRegex(),
-			"ibm_appid_token_config":             appid.ResourceIBMAppIDTokenConfig(),
-			"ibm_appid_redirect_urls":            appid.ResourceIBMAppIDRedirectURLs(),
-			"ibm_appid_role":                     appid.ResourceIBMAppIDRole(),
-			"ibm_appid_theme_color":              appid.ResourceIBMAppIDThemeColor(),
-			"ibm_appid_theme_text":               appid.ResourceIBMAppIDThemeText(),
-			"ibm_appid_user_roles":               appid.ResourceIBMAppIDUserRoles(),
+			"ibm_appid_action_url":                   appid.ResourceIBMAppIDActionURL(),
+			"ibm_appid_apm":                          appid.ResourceIBMAppIDAPM(),
+			"ibm_appid_application":                  appid.ResourceIBMAppIDApplication(),
+			"ibm_appid_application_scopes":           appid.ResourceIBMAppIDApplicationScopes(),
+			"ibm_appid_application_roles":            appid.ResourceIBMAppIDApplicationRoles(),
+			"ibm_appid_audit_status":                 appid.ResourceIBMAppIDAuditStatus(),
+			"ibm_appid_cloud_directory_template":     appid.ResourceIBMAppIDCloudDirectoryTemplate(),
+			"ibm_appid_cloud_directory_user":         appid.ResourceIBMAppIDCloudDirectoryUser(),
+			"ibm_appid_cloud_directory_users_import": appid.ResourceIBMAppIDCloudDirectoryUsersImport(),
+			"ibm_appid_idp_cloud_directory":          appid.ResourceIBMAppIDIDPCloudDirectory(),
+			"ibm_appid_idp_custom":                   appid.ResourceIBMAppIDIDPCustom(),
+			"ibm_appid_idp_facebook":                 appid.ResourceIBMAppIDIDPFacebook(),
+			"ibm_appid_idp_google":                   appid.ResourceIBMAppIDIDPGoogle(),
+			"ibm_appid_idp_saml":                     appid.ResourceIBMAppIDIDPSAML(),
+			"ibm_appid_languages":                    appid.ResourceIBMAppIDLanguages(),
+			"ibm_appid_mfa":                          appid.ResourceIBMAppIDMFA(),
+			"ibm_appid_mfa_channel":                  appid.ResourceIBMAppIDMFAChannel(),
+			"ibm_appid_password_regex":               appid.ResourceIBMAppIDPasswordRegex(),
+			"ibm_appid_token_config":                 appid.ResourceIBMAppIDTokenConfig(),
+			"ibm_appid_redirect_urls":                appid.ResourceIBMAppIDRedirectURLs(),
+			"ibm_appid_role":                         appid.ResourceIBMAppIDRole(),
+			"ibm_appid_theme_color":                  appid.ResourceIBMAppIDThemeColor(),
+			"ibm_appid_theme_text":                   appid.ResourceIBMAppIDThemeText(),
+			"ibm_appid_user_roles":                   appid.ResourceIBMAppIDUserRoles(),
 
 			"ibm_function_action":                       functions.ResourceIBMFunctionAction(),
 			"ibm_function_package":                      functions.ResourceIBMFunctionPackage(),
@@ -859,6 +870,8 @@ func Provider() *schema.Provider {
 			"ibm_function_namespace":                    functions.ResourceIBMFunctionNamespace(),
 			"ibm_cis":                                   cis.ResourceIBMCISInstance(),
 			"ibm_database":                              database.ResourceIBMDatabaseInstance(),
+			"ibm_database_user":                         database.ResourceIBMDatabaseUser(),
+			"ibm_database_sql_migration":                database.ResourceIBMDatabaseSQLMigration(),
 			"ibm_cis_domain":                            cis.ResourceIBMCISDomain(),
 			"ibm_cis_domain_settings":                   cis.ResourceIBMCISSettings(),
 			"ibm_cis_firewall":                          cis.ResourceIBMCISFirewallRecord(),
@@ -906,6 +919,7 @@ func Provider() *schema.Provider {
 			"ibm_compute_user":                          classicinfrastructure.ResourceIBMComputeUser(),
 			"ibm_compute_vm_instance":                   classicinfrastructure.ResourceIBMComputeVmInstance(),
 			"ibm_container_addons":                      kubernetes.ResourceIBMContainerAddOns(),
+			"ibm_container_cluster_autoscaler":          kubernetes.ResourceIBMContainerClusterAutoscaler(),
 			"ibm_container_alb":                         kubernetes.ResourceIBMContainerALB(),
 			"ibm_container_alb_create":                  kubernetes.ResourceIBMContainerAlbCreate(),
 			"ibm_container_api_key_reset":               kubernetes.ResourceIBMContainerAPIKeyReset(),
@@ -941,6 +955,9 @@ func Provider() *schema.Provider {
 			"ibm_dns_record":                            classicinfrastructure.ResourceIBMDNSRecord(),
 			"ibm_event_streams_topic":                   eventstreams.ResourceIBMEventStreamsTopic(),
 			"ibm_event_streams_schema":                  eventstreams.ResourceIBMEventStreamsSchema(),
+			"ibm_event_streams_acl":                     eventstreams.ResourceIBMEventStreamsACL(),
+			"ibm_event_streams_quota":                   eventstreams.ResourceIBMEventStreamsQuota(),
+			"ibm_event_streams_mirroring_config":        eventstreams.ResourceIBMEventStreamsMirroringConfig(),
 			"ibm_firewall":                              classicinfrastructure.ResourceIBMFirewall(),
 			"ibm_firewall_policy":                       classicinfrastructure.ResourceIBMFirewallPolicy(),
 			"ibm_hpcs":                                  hpcs.ResourceIBMHPCS(),
@@ -1068,6 +1085,7 @@ func Provider() *schema.Provider {
 			"ibm_kms_key_with_policy_overrides":             kms.ResourceIBMKmsKeyWithPolicyOverrides(),
 			"ibm_kms_key_alias":                             kms.ResourceIBMKmskeyAlias(),
 			"ibm_kms_key_rings":                             kms.ResourceIBMKmskeyRings(),
+			"ibm_kms_import_token":                          kms.ResourceIBMKmsImportToken(),
 			"ibm_kms_key_policies":                          kms.ResourceIBMKmskeyPolicies(),
 			"ibm_kp_key":                                    kms.ResourceIBMkey(),
 			"ibm_kms_instance_policies":                     kms.ResourceIBMKmsInstancePolicy(),
@@ -1146,12 +1164,13 @@ func Provider() *schema.Provider {
 			"ibm_tg_route_report":             transitgateway.ResourceIBMTransitGatewayRouteReport(),
 
 			// //Catalog related resources
-			"ibm_cm_offering_instance": catalogmanagement.ResourceIBMCmOfferingInstance(),
-			"ibm_cm_catalog":           catalogmanagement.ResourceIBMCmCatalog(),
-			"ibm_cm_offering":          catalogmanagement.ResourceIBMCmOffering(),
-			"ibm_cm_version":           catalogmanagement.ResourceIBMCmVersion(),
-			"ibm_cm_validation":        catalogmanagement.ResourceIBMCmValidation(),
-			"ibm_cm_object":            catalogmanagement.ResourceIBMCmObject(),
+			"ibm_cm_offering_instance":  catalogmanagement.ResourceIBMCmOfferingInstance(),
+			"ibm_cm_catalog":            catalogmanagement.ResourceIBMCmCatalog(),
+			"ibm_cm_offering":           catalogmanagement.ResourceIBMCmOffering(),
+			"ibm_cm_version":            catalogmanagement.ResourceIBMCmVersion(),
+			"ibm_cm_validation":         catalogmanagement.ResourceIBMCmValidation(),
+			"ibm_cm_version_onboarding": catalogmanagement.ResourceIBMCmVersionOnboarding(),
+			"ibm_cm_object":             catalogmanagement.ResourceIBMCmObject(),
 
 			// //Added for enterprise
 			"ibm_enterprise":               enterprise.ResourceIBMEnterprise(),
@@ -1223,8 +1242,9 @@ func Provider() *schema.Provider {
 			"ibm_scc_posture_scan_initiate_validation": scc.ResourceIBMSccPostureScanInitiateValidation(),
 
 			// // Added for Context Based Restrictions
-			"ibm_cbr_zone": contextbasedrestrictions.ResourceIBMCbrZone(),
-			"ibm_cbr_rule": contextbasedrestrictions.ResourceIBMCbrRule(),
+			"ibm_cbr_zone":           contextbasedrestrictions.ResourceIBMCbrZone(),
+			"ibm_cbr_rule":           contextbasedrestrictions.ResourceIBMCbrRule(),
+			"ibm_cbr_zone_addresses": contextbasedrestrictions.ResourceIBMCbrZoneAddresses(),
 
 			// // Added for Event Notifications
 			"ibm_en_source":                 eventnotification.ResourceIBMEnSource(),
@@ -1258,6 +1278,7 @@ func Provider() *schema.Provider {
 			"ibm_en_subscription_ce":        eventnotification.ResourceIBMEnFCMSubscription(),
 			"ibm_en_destination_cos":        eventnotification.ResourceIBMEnCOSDestination(),
 			"ibm_en_subscription_cos":       eventnotification.ResourceIBMEnFCMSubscription(),
+			"ibm_en_notification":           eventnotification.ResourceIBMEnNotification(),
 
 			// // Added for Toolchain
 			"ibm_cd_toolchain":                         cdtoolchain.ResourceIBMCdToolchain(),
@@ -1290,15 +1311,19 @@ func Provider() *schema.Provider {
 			"ibm_cd_tekton_pipeline_property":         cdtektonpipeline.ResourceIBMCdTektonPipelineProperty(),
 			"ibm_cd_tekton_pipeline_trigger":          cdtektonpipeline.ResourceIBMCdTektonPipelineTrigger(),
 			"ibm_cd_tekton_pipeline":                  cdtektonpipeline.ResourceIBMCdTektonPipeline(),
+			"ibm_cd_tekton_pipeline_run":              cdtektonpipeline.ResourceIBMCdTektonPipelineRun(),
 
 			// // Added for Code Engine
-			"ibm_code_engine_app":        codeengine.ResourceIbmCodeEngineApp(),
-			"ibm_code_engine_binding":    codeengine.ResourceIbmCodeEngineBinding(),
-			"ibm_code_engine_build":      codeengine.ResourceIbmCodeEngineBuild(),
-			"ibm_code_engine_config_map": codeengine.ResourceIbmCodeEngineConfigMap(),
-			"ibm_code_engine_job":        codeengine.ResourceIbmCodeEngineJob(),
-			"ibm_code_engine_project":    codeengine.ResourceIbmCodeEngineProject(),
-			"ibm_code_engine_secret":     codeengine.ResourceIbmCodeEngineSecret(),
+			"ibm_code_engine_app":            codeengine.ResourceIbmCodeEngineApp(),
+			"ibm_code_engine_binding":        codeengine.ResourceIbmCodeEngineBinding(),
+			"ibm_code_engine_build":          codeengine.ResourceIbmCodeEngineBuild(),
+			"ibm_code_engine_build_run":      codeengine.ResourceIbmCodeEngineBuildRun(),
+			"ibm_code_engine_config_map":     codeengine.ResourceIbmCodeEngineConfigMap(),
+			"ibm_code_engine_domain_mapping": codeengine.ResourceIbmCodeEngineDomainMapping(),
+			"ibm_code_engine_job":            codeengine.ResourceIbmCodeEngineJob(),
+			"ibm_code_engine_job_run":        codeengine.ResourceIbmCodeEngineJobRun(),
+			"ibm_code_engine_project":        codeengine.ResourceIbmCodeEngineProject(),
+			"ibm_code_engine_secret":         codeengine.ResourceIbmCodeEngineSecret(),
 
 			// Added for Project
 			"ibm_project_instance": project.ResourceIbmProjectInstance(),
@@ -1441,6 +1466,7 @@ func Validator() validate.ValidatorDict {
 				"ibm_is_vpn_server":                        vpc.ResourceIBMIsVPNServerValidator(),
 				"ibm_is_vpn_server_route":                  vpc.ResourceIBMIsVPNServerRouteValidator(),
 				"ibm_kms_key_rings":                        kms.ResourceIBMKeyRingValidator(),
+				"ibm_kms_import_token":                     kms.ResourceIBMKmsImportTokenValidator(),
 				"ibm_dns_glb_monitor":                      dnsservices.ResourceIBMPrivateDNSGLBMonitorValidator(),
 				"ibm_dns_custom_resolver_forwarding_rule":  dnsservices.ResourceIBMPrivateDNSForwardingRuleValidator(),
 				"ibm_schematics_action":                    schematics.ResourceIBMSchematicsActionValidator(),
@@ -1505,8 +1531,10 @@ func Validator() validate.ValidatorDict {
 				"ibm_cd_tekton_pipeline_trigger_property": cdtektonpipeline.ResourceIBMCdTektonPipelineTriggerPropertyValidator(),
 				"ibm_cd_tekton_pipeline_property":         cdtektonpipeline.ResourceIBMCdTektonPipelinePropertyValidator(),
 				"ibm_cd_tekton_pipeline_trigger":          cdtektonpipeline.ResourceIBMCdTektonPipelineTriggerValidator(),
+				"ibm_cd_tekton_pipeline_run":              cdtektonpipeline.ResourceIBMCdTektonPipelineRunValidator(),
 
 				"ibm_container_addons":                      kubernetes.ResourceIBMContainerAddOnsValidator(),
+				"ibm_container_cluster_autoscaler":          kubernetes.ResourceIBMContainerClusterAutoscalerValidator(),
 				"ibm_container_alb_create":                  kubernetes.ResourceIBMContainerAlbCreateValidator(),
 				"ibm_container_nlb_dns":                     kubernetes.ResourceIBMContainerNlbDnsValidator(),
 				"ibm_container_vpc_alb_create":              kubernetes.ResourceIBMContainerVpcAlbCreateNewValidator(),
@@ -1538,13 +1566,16 @@ func Validator() validate.ValidatorDict {
 				"ibm_sm_public_certificate_configuration_dns_classic_infrastructure": secretsmanager.ResourceIbmSmPublicCertificateConfigurationDNSClassicInfrastructureValidator(),
 
 				// // Added for Code Engine
-				"ibm_code_engine_app":        codeengine.ResourceIbmCodeEngineAppValidator(),
-				"ibm_code_engine_binding":    codeengine.ResourceIbmCodeEngineBindingValidator(),
-				"ibm_code_engine_build":      codeengine.ResourceIbmCodeEngineBuildValidator(),
-				"ibm_code_engine_config_map": codeengine.ResourceIbmCodeEngineConfigMapValidator(),
-				"ibm_code_engine_job":        codeengine.ResourceIbmCodeEngineJobValidator(),
-				"ibm_code_engine_project":    codeengine.ResourceIbmCodeEngineProjectValidator(),
-				"ibm_code_engine_secret":     codeengine.ResourceIbmCodeEngineSecretValidator(),
+				"ibm_code_engine_app":            codeengine.ResourceIbmCodeEngineAppValidator(),
+				"ibm_code_engine_binding":        codeengine.ResourceIbmCodeEngineBindingValidator(),
+				"ibm_code_engine_build":          codeengine.ResourceIbmCodeEngineBuildValidator(),
+				"ibm_code_engine_build_run":      codeengine.ResourceIbmCodeEngineBuildRunValidator(),
+				"ibm_code_engine_config_map":     codeengine.ResourceIbmCodeEngineConfigMapValidator(),
+				"ibm_code_engine_domain_mapping": codeengine.ResourceIbmCodeEngineDomainMappingValidator(),
+				"ibm_code_engine_job":            codeengine.ResourceIbmCodeEngineJobValidator(),
+				"ibm_code_engine_job_run":        codeengine.ResourceIbmCodeEngineJobRunValidator(),
+				"ibm_code_engine_project":        codeengine.ResourceIbmCodeEngineProjectValidator(),
+				"ibm_code_engine_secret":         codeengine.ResourceIbmCodeEngineSecretValidator(),
 
 				// Added for Project
 				"ibm_project_instance": project.ResourceIbmProjectInstanceValidator(),
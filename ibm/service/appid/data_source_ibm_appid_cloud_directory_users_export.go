@@ -0,0 +1,112 @@
+package appid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	appid "github.com/IBM/appid-management-go-sdk/appidmanagementv4"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// appIDCloudDirectoryExportPageSize is the number of users requested per
+// export call.
+const appIDCloudDirectoryExportPageSize = 50
+
+func DataSourceIBMAppIDCloudDirectoryUsersExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMAppIDCloudDirectoryUsersExportRead,
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The AppID instance GUID",
+			},
+			"encryption_secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "A secret that encrypts the exported password hashes. The same secret is required to import the users",
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "json",
+				ValidateFunc: validation.StringInSlice([]string{"csv", "json"}, false),
+				Description:  "Format of `content`: `csv` or `json`",
+			},
+			"user_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of exported users",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The exported users, in the format read by `ibm_appid_cloud_directory_users_import`",
+			},
+		},
+	}
+}
+
+func dataSourceIBMAppIDCloudDirectoryUsersExportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	appIDClient, err := meta.(conns.ClientSession).AppIDAPI()
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tenantID := d.Get("tenant_id").(string)
+	secret := d.Get("encryption_secret").(string)
+	format := d.Get("format").(string)
+
+	users := []appid.ExportUserUsersItem{}
+
+	for {
+		export, resp, err := appIDClient.CloudDirectoryExportWithContext(ctx, &appid.CloudDirectoryExportOptions{
+			TenantID:         &tenantID,
+			EncryptionSecret: &secret,
+			StartIndex:       core.Int64Ptr(int64(len(users))),
+			Count:            core.Int64Ptr(appIDCloudDirectoryExportPageSize),
+		})
+
+		if err != nil {
+			return diag.Errorf("Error exporting AppID Cloud Directory users: %s\n%s", err, resp)
+		}
+
+		users = append(users, export.Users...)
+
+		if len(export.Users) < appIDCloudDirectoryExportPageSize {
+			break
+		}
+	}
+
+	var content string
+
+	if format == "csv" {
+		content, err = encodeAppIDCloudDirectoryUsersCSV(users)
+
+		if err != nil {
+			return diag.Errorf("Error encoding AppID Cloud Directory users: %s", err)
+		}
+	} else {
+		encoded, err := json.MarshalIndent(appid.ExportUser{Users: users}, "", "  ")
+
+		if err != nil {
+			return diag.Errorf("Error encoding AppID Cloud Directory users: %s", err)
+		}
+
+		content = string(encoded)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", tenantID, format))
+	d.Set("user_count", len(users))
+	d.Set("content", content)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package appid_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMAppIDCloudDirectoryUsersExportDataSource_basic(t *testing.T) {
+	userName := fmt.Sprintf("tf_testacc_export_%d", acctest.RandIntRange(10, 100))
+	email := fmt.Sprintf("%s@mail.com", userName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMAppIDCloudDirectoryUsersExportDataSourceConfig(acc.AppIDTenantID, userName, email),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ibm_appid_cloud_directory_users_export.users", "user_count"),
+					resource.TestMatchResourceAttr("data.ibm_appid_cloud_directory_users_export.users", "content", regexp.MustCompile("^id,user_name,email,")),
+					resource.TestMatchResourceAttr("data.ibm_appid_cloud_directory_users_export.users", "content", regexp.MustCompile(email)),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMAppIDCloudDirectoryUsersExportDataSourceConfig(tenantID, userName, email string) string {
+	return fmt.Sprintf(`
+		resource "ibm_appid_cloud_directory_user" "user" {
+			tenant_id = "%s"
+			user_name = "%s"
+
+			email {
+				value = "%s"
+				primary = true
+			}
+
+			password = "P@ssw0rd"
+			display_name = "Test TF User"
+		}
+
+		data "ibm_appid_cloud_directory_users_export" "users" {
+			tenant_id         = ibm_appid_cloud_directory_user.user.tenant_id
+			encryption_secret = "tf_testacc_secret"
+			format            = "csv"
+
+			depends_on = [ibm_appid_cloud_directory_user.user]
+		}
+	`, tenantID, userName, email)
+}
@@ -0,0 +1,757 @@
+package appid
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	appid "github.com/IBM/appid-management-go-sdk/appidmanagementv4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// appIDCloudDirectoryUsersCSVHeader lists the columns of the CSV format. The
+// JSON format is the format of the App ID Cloud Directory export API.
+var appIDCloudDirectoryUsersCSVHeader = []string{
+	"id",
+	"user_name",
+	"email",
+	"display_name",
+	"given_name",
+	"family_name",
+	"active",
+	"status",
+	"password_hash",
+	"password_hash_alg",
+	"roles",
+	"attributes",
+}
+
+// appIDCloudDirectoryUserRow is a user read from an import file. Row is the
+// 1-based row of the user in the file, not counting the CSV header.
+type appIDCloudDirectoryUserRow struct {
+	Row  int
+	User appid.ExportUserUsersItem
+	Err  string
+}
+
+func ResourceIBMAppIDCloudDirectoryUsersImport() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Bulk import of AppID Cloud Directory users",
+		CreateContext: resourceIBMAppIDCloudDirectoryUsersImportCreate,
+		ReadContext:   resourceIBMAppIDCloudDirectoryUsersImportRead,
+		DeleteContext: resourceIBMAppIDCloudDirectoryUsersImportDelete,
+		CustomizeDiff: resourceIBMAppIDCloudDirectoryUsersImportDiff,
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description: "The AppID instance GUID",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"source_file": {
+				Description: "Path of the CSV or JSON file with the users to import",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"format": {
+				Description:  "Format of `source_file`: `csv` or `json`. Defaults to the file extension",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"csv", "json"}, false),
+			},
+			"encryption_secret": {
+				Description: "The secret that the password hashes were encrypted with when they were exported",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+			},
+			"batch_size": {
+				Description:  "Number of users sent in one import request",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      50,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"parallelism": {
+				Description:  "Number of import requests that run at the same time",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      4,
+				ValidateFunc: validation.IntBetween(1, 10),
+			},
+			"fail_on_error": {
+				Description: "Fail the apply when a user can not be imported. The failures are always listed in `failures`",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"source_sha": {
+				Description: "SHA256 of `source_file`. A change imports the file again",
+				Type:        schema.TypeString,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"total": {
+				Description: "Number of users in `source_file`",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"added": {
+				Description: "Number of imported users",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"failed": {
+				Description: "Number of users that could not be imported",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"failures": {
+				Description: "The users that could not be imported",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"row": {
+							Description: "Row of the user in `source_file`, not counting the CSV header",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"original_id": {
+							Description: "User ID in `source_file`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"email": {
+							Description: "User email",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"user_name": {
+							Description: "User name",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"error": {
+							Description: "Reason of the failure",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceIBMAppIDCloudDirectoryUsersImportDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("source_file") {
+		return nil
+	}
+
+	content, err := os.ReadFile(d.Get("source_file").(string))
+	if err != nil {
+		return fmt.Errorf("Error reading AppID Cloud Directory users file: %s", err)
+	}
+
+	sum := sha256.Sum256(content)
+	sourceSha := hex.EncodeToString(sum[:])
+
+	if d.Get("source_sha").(string) != sourceSha {
+		if err := d.SetNew("source_sha", sourceSha); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceIBMAppIDCloudDirectoryUsersImportCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	appIDClient, err := meta.(conns.ClientSession).AppIDAPI()
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tenantID := d.Get("tenant_id").(string)
+	sourceFile := d.Get("source_file").(string)
+	secret := d.Get("encryption_secret").(string)
+	batchSize := d.Get("batch_size").(int)
+	parallelism := d.Get("parallelism").(int)
+
+	format, err := appIDCloudDirectoryUsersFormat(sourceFile, d.Get("format").(string))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	content, err := os.ReadFile(sourceFile)
+
+	if err != nil {
+		return diag.Errorf("Error reading AppID Cloud Directory users file: %s", err)
+	}
+
+	var rows []appIDCloudDirectoryUserRow
+
+	if format == "csv" {
+		rows, err = parseAppIDCloudDirectoryUsersCSV(content)
+	} else {
+		rows, err = parseAppIDCloudDirectoryUsersJSON(content)
+	}
+
+	if err != nil {
+		return diag.Errorf("Error parsing AppID Cloud Directory users file %s: %s", sourceFile, err)
+	}
+
+	valid := make([]appIDCloudDirectoryUserRow, 0, len(rows))
+	failures := []appIDCloudDirectoryUserRow{}
+
+	for _, row := range rows {
+		if row.Err != "" {
+			failures = append(failures, row)
+		} else {
+			valid = append(valid, row)
+		}
+	}
+
+	batches := [][]appIDCloudDirectoryUserRow{}
+
+	for start := 0; start < len(valid); start += batchSize {
+		end := start + batchSize
+		if end > len(valid) {
+			end = len(valid)
+		}
+		batches = append(batches, valid[start:end])
+	}
+
+	added := make([]int64, len(batches))
+	batchFailures := make([][]appIDCloudDirectoryUserRow, len(batches))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				added[index], batchFailures[index] = importAppIDCloudDirectoryUsers(ctx, appIDClient, tenantID, secret, batches[index])
+			}
+		}()
+	}
+
+	for index := range batches {
+		jobs <- index
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	var addedTotal int64
+
+	for index := range batches {
+		addedTotal += added[index]
+		failures = append(failures, batchFailures[index]...)
+	}
+
+	sort.SliceStable(failures, func(i, j int) bool {
+		return failures[i].Row < failures[j].Row
+	})
+
+	sum := sha256.Sum256(content)
+
+	d.SetId(fmt.Sprintf("%s/%s", tenantID, hex.EncodeToString(sum[:])))
+	d.Set("format", format)
+	d.Set("source_sha", hex.EncodeToString(sum[:]))
+	d.Set("total", len(rows))
+	d.Set("added", int(addedTotal))
+	d.Set("failed", len(failures))
+
+	if err := d.Set("failures", flattenAppIDCloudDirectoryUsersImportFailures(failures)); err != nil {
+		return diag.Errorf("Error setting AppID Cloud Directory users import failures: %s", err)
+	}
+
+	if len(failures) != 0 {
+		log.Printf("[WARN] %d of %d AppID Cloud Directory users could not be imported from %s", len(failures), len(rows), sourceFile)
+
+		if d.Get("fail_on_error").(bool) {
+			return diag.Errorf("Error importing AppID Cloud Directory users: %d of %d users could not be imported\n%s", len(failures), len(rows), appIDCloudDirectoryUsersImportFailuresSummary(failures))
+		}
+	}
+
+	return resourceIBMAppIDCloudDirectoryUsersImportRead(ctx, d, meta)
+}
+
+func resourceIBMAppIDCloudDirectoryUsersImportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The import is a one time operation, the imported users are managed
+	// in AppID afterwards
+	return nil
+}
+
+func resourceIBMAppIDCloudDirectoryUsersImportDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The imported users are kept, only the import is removed from state
+	d.SetId("")
+	return nil
+}
+
+// importAppIDCloudDirectoryUsers imports one batch of users and returns the
+// number of added users and the rows that failed. When the request fails, all
+// rows of the batch fail with the request error.
+func importAppIDCloudDirectoryUsers(ctx context.Context, appIDClient *appid.AppIDManagementV4, tenantID, secret string, rows []appIDCloudDirectoryUserRow) (int64, []appIDCloudDirectoryUserRow) {
+	users := make([]appid.ExportUserUsersItem, len(rows))
+
+	for i, row := range rows {
+		users[i] = row.User
+	}
+
+	result, resp, err := appIDClient.CloudDirectoryImportWithContext(ctx, &appid.CloudDirectoryImportOptions{
+		TenantID:         &tenantID,
+		EncryptionSecret: &secret,
+		Users:            users,
+	})
+
+	if err != nil {
+		log.Printf("[DEBUG] Error importing AppID Cloud Directory users: %s\n%s", err, resp)
+
+		failures := make([]appIDCloudDirectoryUserRow, len(rows))
+
+		for i, row := range rows {
+			row.Err = err.Error()
+			failures[i] = row
+		}
+
+		return 0, failures
+	}
+
+	var added int64
+
+	if result.Added != nil {
+		added = *result.Added
+	}
+
+	failures := make([]appIDCloudDirectoryUserRow, 0, len(result.FailReasons))
+
+	for _, reason := range result.FailReasons {
+		failure := matchAppIDCloudDirectoryUserRow(rows, reason)
+
+		if reason.Error != nil {
+			if message, ok := reason.Error.(string); ok {
+				failure.Err = message
+			} else if message, err := json.Marshal(reason.Error); err == nil {
+				failure.Err = string(message)
+			}
+		}
+
+		if failure.Err == "" {
+			failure.Err = "unknown error"
+		}
+
+		failures = append(failures, failure)
+	}
+
+	return added, failures
+}
+
+// matchAppIDCloudDirectoryUserRow returns the row of a failed user, matched by
+// the original ID, the email or the user name.
+func matchAppIDCloudDirectoryUserRow(rows []appIDCloudDirectoryUserRow, reason appid.ImportResponseFailReasonsItem) appIDCloudDirectoryUserRow {
+	for _, row := range rows {
+		id, email, userName := appIDCloudDirectoryUserKeys(row.User)
+
+		if (reason.OriginalID != nil && *reason.OriginalID != "" && *reason.OriginalID == id) ||
+			(reason.Email != nil && *reason.Email != "" && strings.EqualFold(*reason.Email, email)) ||
+			(reason.UserName != nil && *reason.UserName != "" && *reason.UserName == userName) {
+			return row
+		}
+	}
+
+	row := appIDCloudDirectoryUserRow{User: appid.ExportUserUsersItem{ScimUser: map[string]interface{}{}}}
+	scimUser := row.User.ScimUser.(map[string]interface{})
+
+	if reason.OriginalID != nil {
+		scimUser["id"] = *reason.OriginalID
+	}
+
+	if reason.Email != nil {
+		scimUser["emails"] = []interface{}{map[string]interface{}{"value": *reason.Email}}
+	}
+
+	if reason.UserName != nil {
+		scimUser["userName"] = *reason.UserName
+	}
+
+	return row
+}
+
+// appIDCloudDirectoryUserKeys returns the ID, the primary email and the user
+// name of an exported user.
+func appIDCloudDirectoryUserKeys(user appid.ExportUserUsersItem) (string, string, string) {
+	scimUser, _ := user.ScimUser.(map[string]interface{})
+	id, _ := scimUser["id"].(string)
+	userName, _ := scimUser["userName"].(string)
+	email := ""
+
+	emails, _ := scimUser["emails"].([]interface{})
+
+	for _, item := range emails {
+		emailMap, _ := item.(map[string]interface{})
+		value, _ := emailMap["value"].(string)
+
+		if email == "" {
+			email = value
+		}
+
+		if primary, _ := emailMap["primary"].(bool); primary {
+			email = value
+			break
+		}
+	}
+
+	return id, email, userName
+}
+
+func appIDCloudDirectoryUsersFormat(path, format string) (string, error) {
+	if format != "" {
+		return format, nil
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return "csv", nil
+	case ".json":
+		return "json", nil
+	}
+
+	return "", fmt.Errorf("Unable to determine the format of AppID Cloud Directory users file %s: set format to csv or json", path)
+}
+
+// parseAppIDCloudDirectoryUsersJSON reads users in the format of the App ID
+// Cloud Directory export API, either as an object with a users list or as a
+// plain list.
+func parseAppIDCloudDirectoryUsersJSON(content []byte) ([]appIDCloudDirectoryUserRow, error) {
+	var users []appid.ExportUserUsersItem
+
+	if trimmed := bytes.TrimSpace(content); len(trimmed) != 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &users); err != nil {
+			return nil, err
+		}
+	} else {
+		export := appid.ExportUser{}
+
+		if err := json.Unmarshal(trimmed, &export); err != nil {
+			return nil, err
+		}
+
+		users = export.Users
+	}
+
+	rows := make([]appIDCloudDirectoryUserRow, len(users))
+
+	for i, user := range users {
+		rows[i] = appIDCloudDirectoryUserRow{Row: i + 1, User: user}
+
+		if _, ok := user.ScimUser.(map[string]interface{}); !ok {
+			rows[i].Err = "scimUser is required"
+			continue
+		}
+
+		if _, email, _ := appIDCloudDirectoryUserKeys(user); email == "" {
+			rows[i].Err = "at least one email is required"
+			continue
+		}
+
+		if (user.PasswordHash == nil) != (user.PasswordHashAlg == nil) {
+			rows[i].Err = "passwordHash and passwordHashAlg must be set together"
+			continue
+		}
+
+		if user.Profile == nil {
+			rows[i].User.Profile = &appid.ExportUserUsersItemProfile{Attributes: map[string]interface{}{}}
+		}
+
+		if user.Roles == nil {
+			rows[i].User.Roles = []string{}
+		}
+	}
+
+	return rows, nil
+}
+
+// parseAppIDCloudDirectoryUsersCSV reads users from a CSV file with a header
+// row. The columns are matched by name, see appIDCloudDirectoryUsersCSVHeader.
+// Roles are separated by semicolons and attributes is a JSON object.
+func parseAppIDCloudDirectoryUsersCSV(content []byte) ([]appIDCloudDirectoryUserRow, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %s", err)
+	}
+
+	columns := map[string]int{}
+
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+
+		known := false
+
+		for _, column := range appIDCloudDirectoryUsersCSVHeader {
+			if column == name {
+				known = true
+			}
+		}
+
+		if !known {
+			return nil, fmt.Errorf("unknown column %q, supported columns are %s", name, strings.Join(appIDCloudDirectoryUsersCSVHeader, ", "))
+		}
+
+		columns[name] = i
+	}
+
+	if _, ok := columns["email"]; !ok {
+		return nil, fmt.Errorf("column email is required")
+	}
+
+	rows := []appIDCloudDirectoryUserRow{}
+
+	for {
+		record, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := appIDCloudDirectoryUserRow{Row: len(rows) + 1}
+		row.User, row.Err = expandAppIDCloudDirectoryUserCSV(value)
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func expandAppIDCloudDirectoryUserCSV(value func(string) string) (appid.ExportUserUsersItem, string) {
+	scimUser := map[string]interface{}{}
+	user := appid.ExportUserUsersItem{
+		ScimUser: scimUser,
+		Profile:  &appid.ExportUserUsersItemProfile{Attributes: map[string]interface{}{}},
+		Roles:    []string{},
+	}
+
+	if id := value("id"); id != "" {
+		scimUser["id"] = id
+	}
+
+	if userName := value("user_name"); userName != "" {
+		scimUser["userName"] = userName
+	}
+
+	email := value("email")
+
+	if email == "" {
+		return user, "email is required"
+	}
+
+	scimUser["emails"] = []interface{}{map[string]interface{}{"value": email, "primary": true}}
+
+	if displayName := value("display_name"); displayName != "" {
+		scimUser["displayName"] = displayName
+	}
+
+	name := map[string]interface{}{}
+
+	if givenName := value("given_name"); givenName != "" {
+		name["givenName"] = givenName
+	}
+
+	if familyName := value("family_name"); familyName != "" {
+		name["familyName"] = familyName
+	}
+
+	if len(name) != 0 {
+		scimUser["name"] = name
+	}
+
+	if active := value("active"); active != "" {
+		activeValue, err := strconv.ParseBool(active)
+
+		if err != nil {
+			return user, fmt.Sprintf("invalid active value %q", active)
+		}
+
+		scimUser["active"] = activeValue
+	}
+
+	if status := value("status"); status != "" {
+		if status != "PENDING" && status != "CONFIRMED" {
+			return user, fmt.Sprintf("invalid status %q, expected PENDING or CONFIRMED", status)
+		}
+
+		scimUser["status"] = status
+	}
+
+	passwordHash := value("password_hash")
+	passwordHashAlg := value("password_hash_alg")
+
+	if (passwordHash == "") != (passwordHashAlg == "") {
+		return user, "password_hash and password_hash_alg must be set together"
+	}
+
+	if passwordHash != "" {
+		user.PasswordHash = &passwordHash
+		user.PasswordHashAlg = &passwordHashAlg
+	}
+
+	if roles := value("roles"); roles != "" {
+		for _, role := range strings.Split(roles, ";") {
+			if role = strings.TrimSpace(role); role != "" {
+				user.Roles = append(user.Roles, role)
+			}
+		}
+	}
+
+	if attributes := value("attributes"); attributes != "" {
+		attributesMap := map[string]interface{}{}
+
+		if err := json.Unmarshal([]byte(attributes), &attributesMap); err != nil {
+			return user, fmt.Sprintf("attributes must be a JSON object: %s", err)
+		}
+
+		user.Profile.Attributes = attributesMap
+	}
+
+	return user, ""
+}
+
+// encodeAppIDCloudDirectoryUsersCSV writes users in the CSV format read by
+// parseAppIDCloudDirectoryUsersCSV.
+func encodeAppIDCloudDirectoryUsersCSV(users []appid.ExportUserUsersItem) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write(appIDCloudDirectoryUsersCSVHeader); err != nil {
+		return "", err
+	}
+
+	for _, user := range users {
+		scimUser, _ := user.ScimUser.(map[string]interface{})
+		id, email, userName := appIDCloudDirectoryUserKeys(user)
+		displayName, _ := scimUser["displayName"].(string)
+		name, _ := scimUser["name"].(map[string]interface{})
+		givenName, _ := name["givenName"].(string)
+		familyName, _ := name["familyName"].(string)
+		status, _ := scimUser["status"].(string)
+
+		active := ""
+
+		if activeValue, ok := scimUser["active"].(bool); ok {
+			active = strconv.FormatBool(activeValue)
+		}
+
+		passwordHash, passwordHashAlg := "", ""
+
+		if user.PasswordHash != nil && user.PasswordHashAlg != nil {
+			passwordHash, passwordHashAlg = *user.PasswordHash, *user.PasswordHashAlg
+		}
+
+		attributes := ""
+
+		if user.Profile != nil && user.Profile.Attributes != nil {
+			if attributesMap, ok := user.Profile.Attributes.(map[string]interface{}); !ok || len(attributesMap) != 0 {
+				content, err := json.Marshal(user.Profile.Attributes)
+
+				if err != nil {
+					return "", err
+				}
+
+				attributes = string(content)
+			}
+		}
+
+		record := []string{id, userName, email, displayName, givenName, familyName, active, status, passwordHash, passwordHashAlg, strings.Join(user.Roles, ";"), attributes}
+
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func flattenAppIDCloudDirectoryUsersImportFailures(failures []appIDCloudDirectoryUserRow) []interface{} {
+	result := make([]interface{}, len(failures))
+
+	for i, failure := range failures {
+		id, email, userName := appIDCloudDirectoryUserKeys(failure.User)
+
+		result[i] = map[string]interface{}{
+			"row":         failure.Row,
+			"original_id": id,
+			"email":       email,
+			"user_name":   userName,
+			"error":       failure.Err,
+		}
+	}
+
+	return result
+}
+
+// appIDCloudDirectoryUsersImportFailuresSummary lists the first failed rows
+// for the error of a failed import, the full list is kept in state.
+func appIDCloudDirectoryUsersImportFailuresSummary(failures []appIDCloudDirectoryUserRow) string {
+	const limit = 20
+
+	lines := []string{}
+
+	for i, failure := range failures {
+		if i == limit {
+			lines = append(lines, fmt.Sprintf("... and %d more", len(failures)-limit))
+			break
+		}
+
+		_, email, _ := appIDCloudDirectoryUserKeys(failure.User)
+		lines = append(lines, fmt.Sprintf("row %d (%s): %s", failure.Row, email, failure.Err))
+	}
+
+	return strings.Join(lines, "\n")
+}
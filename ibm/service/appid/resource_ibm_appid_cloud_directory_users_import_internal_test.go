@@ -0,0 +1,222 @@
+package appid
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	appid "github.com/IBM/appid-management-go-sdk/appidmanagementv4"
+)
+
+func TestParseAppIDCloudDirectoryUsersCSV(t *testing.T) {
+	content := "\ufeffEmail,user_name,given_name,family_name,active,status,roles,attributes\n" +
+		"jane@example.com,jane,Jane,Doe,true,CONFIRMED,admin; viewer ;,\"{\"\"team\"\":\"\"blue\"\"}\"\n" +
+		"john@example.com\n"
+
+	rows, err := parseAppIDCloudDirectoryUsersCSV([]byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+
+	jane := rows[0]
+	if jane.Row != 1 || jane.Err != "" {
+		t.Fatalf("got row %d with error %q, want row 1 without error", jane.Row, jane.Err)
+	}
+	wantScimUser := map[string]interface{}{
+		"userName": "jane",
+		"emails":   []interface{}{map[string]interface{}{"value": "jane@example.com", "primary": true}},
+		"name":     map[string]interface{}{"givenName": "Jane", "familyName": "Doe"},
+		"active":   true,
+		"status":   "CONFIRMED",
+	}
+	if !reflect.DeepEqual(jane.User.ScimUser, wantScimUser) {
+		t.Fatalf("got scimUser %#v, want %#v", jane.User.ScimUser, wantScimUser)
+	}
+	if !reflect.DeepEqual(jane.User.Roles, []string{"admin", "viewer"}) {
+		t.Fatalf("got roles %q, want [admin viewer]", jane.User.Roles)
+	}
+	if !reflect.DeepEqual(jane.User.Profile.Attributes, map[string]interface{}{"team": "blue"}) {
+		t.Fatalf("got attributes %#v", jane.User.Profile.Attributes)
+	}
+
+	// Missing trailing columns are empty.
+	john := rows[1]
+	if john.Row != 2 || john.Err != "" {
+		t.Fatalf("got row %d with error %q, want row 2 without error", john.Row, john.Err)
+	}
+	if _, email, _ := appIDCloudDirectoryUserKeys(john.User); email != "john@example.com" {
+		t.Fatalf("got email %q, want john@example.com", email)
+	}
+	if len(john.User.Roles) != 0 || john.User.PasswordHash != nil {
+		t.Fatalf("got roles %q and password hash %v, want none", john.User.Roles, john.User.PasswordHash)
+	}
+}
+
+func TestParseAppIDCloudDirectoryUsersCSVHeaderErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"empty file", "", "reading header"},
+		{"unknown column", "email,nickname\n", `unknown column "nickname"`},
+		{"missing email column", "user_name,display_name\n", "column email is required"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseAppIDCloudDirectoryUsersCSV([]byte(tc.content))
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("got error %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestExpandAppIDCloudDirectoryUserCSVValidation(t *testing.T) {
+	testCases := []struct {
+		name   string
+		values map[string]string
+		err    string
+	}{
+		{"valid", map[string]string{"email": "jane@example.com", "active": "false", "status": "PENDING"}, ""},
+		{"missing email", map[string]string{"user_name": "jane"}, "email is required"},
+		{"invalid active", map[string]string{"email": "jane@example.com", "active": "yes please"}, `invalid active value "yes please"`},
+		{"invalid status", map[string]string{"email": "jane@example.com", "status": "ACTIVE"}, `invalid status "ACTIVE", expected PENDING or CONFIRMED`},
+		{"hash without algorithm", map[string]string{"email": "jane@example.com", "password_hash": "abc"}, "password_hash and password_hash_alg must be set together"},
+		{"algorithm without hash", map[string]string{"email": "jane@example.com", "password_hash_alg": "bcrypt"}, "password_hash and password_hash_alg must be set together"},
+		{"hash with algorithm", map[string]string{"email": "jane@example.com", "password_hash": "abc", "password_hash_alg": "bcrypt"}, ""},
+		{"attributes not an object", map[string]string{"email": "jane@example.com", "attributes": "[1, 2]"}, "attributes must be a JSON object"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := expandAppIDCloudDirectoryUserCSV(func(column string) string { return tc.values[column] })
+			if (tc.err == "" && err != "") || !strings.HasPrefix(err, tc.err) {
+				t.Fatalf("got error %q, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestParseAppIDCloudDirectoryUsersCSVRowErrors(t *testing.T) {
+	content := "email,status,password_hash\n" +
+		"jane@example.com,CONFIRMED,\n" +
+		",CONFIRMED,\n" +
+		"john@example.com,ACTIVE,\n" +
+		"joe@example.com,,abc\n"
+
+	rows, err := parseAppIDCloudDirectoryUsersCSV([]byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []string{
+		"",
+		"email is required",
+		`invalid status "ACTIVE", expected PENDING or CONFIRMED`,
+		"password_hash and password_hash_alg must be set together",
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+	for i, row := range rows {
+		if row.Row != i+1 || row.Err != want[i] {
+			t.Fatalf("got row %d with error %q, want row %d with error %q", row.Row, row.Err, i+1, want[i])
+		}
+	}
+}
+
+func TestParseAppIDCloudDirectoryUsersJSON(t *testing.T) {
+	object := `{"itemsPerPage": 2, "totalResults": 2, "users": [
+		{"scimUser": {"emails": [{"value": "other@example.com"}, {"value": "jane@example.com", "primary": true}]}, "passwordHash": "abc", "passwordHashAlg": "bcrypt", "roles": ["admin"], "profile": {"attributes": {"team": "blue"}}},
+		{"scimUser": {"emails": [{"value": "john@example.com"}]}}
+	]}`
+	list := `[
+		{"scimUser": {"emails": [{"value": "jane@example.com"}]}},
+		{"passwordHash": "abc"},
+		{"scimUser": {"userName": "noemail"}},
+		{"scimUser": {"emails": [{"value": "joe@example.com"}]}, "passwordHash": "abc"}
+	]`
+
+	rows, err := parseAppIDCloudDirectoryUsersJSON([]byte(object))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(rows) != 2 || rows[0].Err != "" || rows[1].Err != "" {
+		t.Fatalf("got rows %#v, want two valid rows", rows)
+	}
+	if _, email, _ := appIDCloudDirectoryUserKeys(rows[0].User); email != "jane@example.com" {
+		t.Fatalf("got email %q, want the primary email jane@example.com", email)
+	}
+	if !reflect.DeepEqual(rows[0].User.Roles, []string{"admin"}) {
+		t.Fatalf("got roles %q, want [admin]", rows[0].User.Roles)
+	}
+	// Users without profile and roles get empty ones, as the import API
+	// requires them.
+	if rows[1].User.Profile == nil || rows[1].User.Roles == nil {
+		t.Fatalf("got profile %v and roles %v, want empty ones", rows[1].User.Profile, rows[1].User.Roles)
+	}
+
+	rows, err = parseAppIDCloudDirectoryUsersJSON([]byte(list))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []string{
+		"",
+		"scimUser is required",
+		"at least one email is required",
+		"passwordHash and passwordHashAlg must be set together",
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+	for i, row := range rows {
+		if row.Row != i+1 || row.Err != want[i] {
+			t.Fatalf("got row %d with error %q, want row %d with error %q", row.Row, row.Err, i+1, want[i])
+		}
+	}
+
+	if _, err := parseAppIDCloudDirectoryUsersJSON([]byte(`{"users": {}}`)); err == nil {
+		t.Fatalf("expected an error for users that are not a list")
+	}
+}
+
+func TestEncodeAppIDCloudDirectoryUsersCSVRoundTrip(t *testing.T) {
+	passwordHash, passwordHashAlg := "abc", "bcrypt"
+	users := []appid.ExportUserUsersItem{
+		{
+			ScimUser: map[string]interface{}{
+				"id":          "1234",
+				"userName":    "jane",
+				"displayName": "Jane Doe",
+				"emails":      []interface{}{map[string]interface{}{"value": "jane@example.com", "primary": true}},
+				"name":        map[string]interface{}{"givenName": "Jane", "familyName": "Doe"},
+				"active":      true,
+				"status":      "CONFIRMED",
+			},
+			PasswordHash:    &passwordHash,
+			PasswordHashAlg: &passwordHashAlg,
+			Roles:           []string{"admin", "viewer"},
+			Profile:         &appid.ExportUserUsersItemProfile{Attributes: map[string]interface{}{"team": "blue"}},
+		},
+	}
+
+	content, err := encodeAppIDCloudDirectoryUsersCSV(users)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	rows, err := parseAppIDCloudDirectoryUsersCSV([]byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(rows) != 1 || rows[0].Err != "" {
+		t.Fatalf("got rows %#v, want one valid row", rows)
+	}
+	if !reflect.DeepEqual(rows[0].User, users[0]) {
+		t.Fatalf("got user %#v, want %#v", rows[0].User, users[0])
+	}
+}
@@ -0,0 +1,106 @@
+package appid_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+
+	appid "github.com/IBM/appid-management-go-sdk/appidmanagementv4"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccIBMAppIDCloudDirectoryUsersImport_basic(t *testing.T) {
+	userName := fmt.Sprintf("tf_testacc_import_%d", acctest.RandIntRange(10, 100))
+	email := fmt.Sprintf("%s@mail.com", userName)
+	sourceFile := filepath.Join(t.TempDir(), "users.csv")
+
+	content := fmt.Sprintf("user_name,email,display_name,active,status\n%s,%s,Test TF User,true,CONFIRMED\n%s_invalid,,Test TF User,true,CONFIRMED\n", userName, email, userName)
+
+	if err := os.WriteFile(sourceFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMAppIDCloudDirectoryUsersImportDestroy(email),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMAppIDCloudDirectoryUsersImportConfig(acc.AppIDTenantID, sourceFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_appid_cloud_directory_users_import.users", "format", "csv"),
+					resource.TestCheckResourceAttr("ibm_appid_cloud_directory_users_import.users", "total", "2"),
+					resource.TestCheckResourceAttr("ibm_appid_cloud_directory_users_import.users", "added", "1"),
+					resource.TestCheckResourceAttr("ibm_appid_cloud_directory_users_import.users", "failed", "1"),
+					resource.TestCheckResourceAttr("ibm_appid_cloud_directory_users_import.users", "failures.0.row", "2"),
+					resource.TestCheckResourceAttr("ibm_appid_cloud_directory_users_import.users", "failures.0.error", "email is required"),
+					resource.TestCheckResourceAttrSet("ibm_appid_cloud_directory_users_import.users", "source_sha"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMAppIDCloudDirectoryUsersImportConfig(tenantID, sourceFile string) string {
+	return fmt.Sprintf(`
+		resource "ibm_appid_cloud_directory_users_import" "users" {
+			tenant_id         = "%s"
+			source_file       = "%s"
+			encryption_secret = "tf_testacc_secret"
+		}
+	`, tenantID, sourceFile)
+}
+
+// testAccCheckIBMAppIDCloudDirectoryUsersImportDestroy removes the imported
+// user, destroying the import keeps the users in AppID.
+func testAccCheckIBMAppIDCloudDirectoryUsersImportDestroy(email string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		appIDClient, err := acc.TestAccProvider.Meta().(conns.ClientSession).AppIDAPI()
+
+		if err != nil {
+			return err
+		}
+
+		tenantID := acc.AppIDTenantID
+
+		users, _, err := appIDClient.ListCloudDirectoryUsers(&appid.ListCloudDirectoryUsersOptions{
+			TenantID: &tenantID,
+			Query:    &email,
+		})
+
+		if err != nil {
+			return fmt.Errorf("Error listing AppID Cloud Directory users: %s", err)
+		}
+
+		for _, item := range users.Resources {
+			user, ok := item.(map[string]interface{})
+
+			if !ok {
+				continue
+			}
+
+			userID, ok := user["id"].(string)
+
+			if !ok {
+				continue
+			}
+
+			_, err := appIDClient.DeleteCloudDirectoryUser(&appid.DeleteCloudDirectoryUserOptions{
+				TenantID: &tenantID,
+				UserID:   &userID,
+			})
+
+			if err != nil {
+				return fmt.Errorf("Error deleting imported AppID Cloud Directory user %s: %s", userID, err)
+			}
+		}
+
+		return nil
+	}
+}
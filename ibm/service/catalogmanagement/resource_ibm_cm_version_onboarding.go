@@ -0,0 +1,803 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package catalogmanagement
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/platform-services-go-sdk/catalogmanagementv1"
+)
+
+func ResourceIBMCmVersionOnboarding() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMCmVersionOnboardingCreate,
+		ReadContext:   resourceIBMCmVersionOnboardingRead,
+		UpdateContext: resourceIBMCmVersionOnboardingUpdate,
+		DeleteContext: resourceIBMCmVersionOnboardingDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.All(
+			resourceIBMCmVersionOnboardingDiff,
+		),
+
+		Schema: map[string]*schema.Schema{
+			"catalog_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Catalog identifier.",
+			},
+			"offering_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Offering identification.",
+			},
+			"source_dir": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Local directory of the Terraform module that is packaged into a tgz archive and imported as the version.",
+			},
+			"target_version": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The semver value for the new version.",
+			},
+			"label": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Display name of version.",
+			},
+			"tags": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Tags array.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"install_kind": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "terraform",
+				Description: "Install type. Example: instance, operator, helm, terraform.",
+			},
+			"target_kinds": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Deployment target of the content being onboarded. Current valid values are iks, roks, vcenter, power-iaas, and terraform.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"format_kind": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "terraform",
+				Description: "Format of content being onboarded. Example: vsi-image, helm, operator-bundle, terraform.",
+			},
+			"working_directory": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Working directory of the Terraform template within the archive.",
+			},
+			"validation": &schema.Schema{
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Validate the version in a Schematics workspace after it is imported.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Validation region.",
+						},
+						"override_values": &schema.Schema{
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Override values during validation.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"environment_variables": &schema.Schema{
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Environment variables to include in the schematics workspace.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": &schema.Schema{
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Name of the environment variable.",
+									},
+									"value": &schema.Schema{
+										Type:        schema.TypeString,
+										Optional:    true,
+										Sensitive:   true,
+										Description: "Value of the environment variable.",
+									},
+									"secure": &schema.Schema{
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Description: "If the environment variable should be secure.",
+									},
+								},
+							},
+						},
+						"schematics": &schema.Schema{
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Optional:    true,
+							Description: "The schematics workspace that validates the version.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": &schema.Schema{
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Name for the schematics workspace.",
+									},
+									"description": &schema.Schema{
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Description for the schematics workspace.",
+									},
+									"resource_group_id": &schema.Schema{
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The resource group ID.",
+									},
+									"terraform_version": &schema.Schema{
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Version of terraform to use in schematics.",
+									},
+									"region": &schema.Schema{
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Region to use for the schematics installation.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"mark_version_consumable": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "If the version should be marked as consumable or \"ready to share\" after it is validated.",
+			},
+			"publish": &schema.Schema{
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Description: "Share the offering of the version. Publishing marks the version as consumable.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_ids": &schema.Schema{
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The accounts to share the offering with.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"enterprise_ids": &schema.Schema{
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The enterprises to share the offering with.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"ibm": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Share the offering with IBM.",
+						},
+						"public": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Share the offering with all accounts. Requires approval for public publishing.",
+						},
+					},
+				},
+			},
+			"content_sha": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA256 of the packaged source directory. A change imports a new version.",
+			},
+			"version_locator": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A dotted value of `catalogID`.`versionID`.",
+			},
+			"version_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique ID of the version.",
+			},
+			"kind_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Kind ID.",
+			},
+			"version": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of content type.",
+			},
+			"state": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The current state of the version, for example new or consumable.",
+			},
+			"validation_state": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current validation state - <empty>, in_progress, valid, invalid, expired.",
+			},
+			"validated": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Data and time of last successful validation.",
+			},
+			"validation_message": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Any message needing to be conveyed as part of the validation job.",
+			},
+		},
+	}
+}
+
+func resourceIBMCmVersionOnboardingDiff(context context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("source_dir") {
+		return nil
+	}
+	content, err := packageCmVersionOnboardingSourceDir(diff.Get("source_dir").(string))
+	if err != nil {
+		return err
+	}
+	contentSha := cmVersionOnboardingContentSha(content)
+	if diff.Get("content_sha").(string) == contentSha {
+		return nil
+	}
+	if err = diff.SetNew("content_sha", contentSha); err != nil {
+		return err
+	}
+	if diff.Id() != "" {
+		return diff.ForceNew("content_sha")
+	}
+	return nil
+}
+
+func resourceIBMCmVersionOnboardingCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	catalogManagementClient, err := meta.(conns.ClientSession).CatalogManagementV1()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	content, err := packageCmVersionOnboardingSourceDir(d.Get("source_dir").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	catalogID := d.Get("catalog_id").(string)
+	offeringID := d.Get("offering_id").(string)
+
+	importOfferingVersionOptions := &catalogmanagementv1.ImportOfferingVersionOptions{}
+	importOfferingVersionOptions.SetCatalogIdentifier(catalogID)
+	importOfferingVersionOptions.SetOfferingID(offeringID)
+	importOfferingVersionOptions.SetContent(content)
+	importOfferingVersionOptions.SetTargetVersion(d.Get("target_version").(string))
+	importOfferingVersionOptions.SetVersion(d.Get("target_version").(string))
+	importOfferingVersionOptions.SetInstallKind(d.Get("install_kind").(string))
+	importOfferingVersionOptions.SetFormatKind(d.Get("format_kind").(string))
+	if _, ok := d.GetOk("target_kinds"); ok {
+		importOfferingVersionOptions.SetTargetKinds(SIToSS(d.Get("target_kinds").([]interface{})))
+	} else {
+		importOfferingVersionOptions.SetTargetKinds([]string{"terraform"})
+	}
+	if _, ok := d.GetOk("label"); ok {
+		importOfferingVersionOptions.SetLabel(d.Get("label").(string))
+	}
+	if _, ok := d.GetOk("tags"); ok {
+		importOfferingVersionOptions.SetTags(SIToSS(d.Get("tags").([]interface{})))
+	}
+	if _, ok := d.GetOk("working_directory"); ok {
+		importOfferingVersionOptions.SetWorkingDirectory(d.Get("working_directory").(string))
+	}
+
+	mk := fmt.Sprintf("%s.%s", catalogID, offeringID)
+	conns.IbmMutexKV.Lock(mk)
+	defer conns.IbmMutexKV.Unlock(mk)
+
+	getOfferingOptions := &catalogmanagementv1.GetOfferingOptions{}
+	getOfferingOptions.SetCatalogIdentifier(catalogID)
+	getOfferingOptions.SetOfferingID(offeringID)
+	oldOffering, response, err := catalogManagementClient.GetOfferingWithContext(context, getOfferingOptions)
+	if err != nil {
+		log.Printf("[DEBUG] GetOfferingWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("GetOfferingWithContext failed %s\n%s", err, response))
+	}
+
+	offering, response, err := catalogManagementClient.ImportOfferingVersionWithContext(context, importOfferingVersionOptions)
+	if err != nil {
+		log.Printf("[DEBUG] ImportOfferingVersionWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("ImportOfferingVersionWithContext failed %s\n%s", err, response))
+	}
+
+	version, err := getVersionFromOffering(oldOffering, offering)
+	if err != nil {
+		log.Printf("[DEBUG] getVersionFromOffering failed %s\n", err)
+		return diag.FromErr(fmt.Errorf("getVersionFromOffering failed %s\n", err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", *offering.CatalogID, *version.ID))
+	d.Set("content_sha", cmVersionOnboardingContentSha(content))
+
+	// The version is kept in state from here on, so that a failed validation
+	// taints it and the next apply imports it again.
+	if _, ok := d.GetOk("validation"); ok {
+		err = validateCmVersionOnboarding(context, d, meta, *version.VersionLocator)
+		if err != nil {
+			resourceIBMCmVersionOnboardingRead(context, d, meta)
+			return diag.FromErr(err)
+		}
+	}
+
+	_, publish := d.GetOk("publish")
+	if d.Get("mark_version_consumable").(bool) || publish {
+		err = markVersionAsConsumable(*version, context, meta)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("Error marking version %s as consumable: %s", *version.VersionLocator, err))
+		}
+	}
+
+	if publish {
+		err = publishCmVersionOnboarding(context, d, meta, nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceIBMCmVersionOnboardingRead(context, d, meta)
+}
+
+func resourceIBMCmVersionOnboardingRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	catalogManagementClient, err := meta.(conns.ClientSession).CatalogManagementV1()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	getVersionOptions := &catalogmanagementv1.GetVersionOptions{}
+	getVersionOptions.SetVersionLocID(strings.Replace(d.Id(), "/", ".", 1))
+
+	offering, response, err := catalogManagementClient.GetVersionWithContext(context, getVersionOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] GetVersionWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("GetVersionWithContext failed %s\n%s", err, response))
+	}
+
+	version := offering.Kinds[0].Versions[0]
+
+	if err = d.Set("catalog_id", version.CatalogID); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting catalog_id: %s", err))
+	}
+	if err = d.Set("offering_id", version.OfferingID); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting offering_id: %s", err))
+	}
+	if err = d.Set("version_locator", version.VersionLocator); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting version_locator: %s", err))
+	}
+	if err = d.Set("version_id", version.ID); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting version_id: %s", err))
+	}
+	if err = d.Set("kind_id", version.KindID); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting kind_id: %s", err))
+	}
+	if err = d.Set("version", version.Version); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting version: %s", err))
+	}
+	if version.State != nil {
+		if err = d.Set("state", version.State.Current); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting state: %s", err))
+		}
+	}
+	if version.Validation != nil {
+		if err = d.Set("validation_state", version.Validation.State); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting validation_state: %s", err))
+		}
+		if version.Validation.Validated != nil {
+			if err = d.Set("validated", flex.DateTimeToString(version.Validation.Validated)); err != nil {
+				return diag.FromErr(fmt.Errorf("Error setting validated: %s", err))
+			}
+		}
+		if err = d.Set("validation_message", version.Validation.Message); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting validation_message: %s", err))
+		}
+	}
+
+	return nil
+}
+
+func resourceIBMCmVersionOnboardingUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange("publish") {
+		mk := fmt.Sprintf("%s.%s", d.Get("catalog_id").(string), d.Get("offering_id").(string))
+		conns.IbmMutexKV.Lock(mk)
+		defer conns.IbmMutexKV.Unlock(mk)
+
+		oldPublish, _ := d.GetChange("publish")
+		var previous map[string]interface{}
+		if list := oldPublish.([]interface{}); len(list) != 0 && list[0] != nil {
+			previous = list[0].(map[string]interface{})
+		}
+
+		if _, ok := d.GetOk("publish"); ok && d.Get("state").(string) != "consumable" {
+			catalogManagementClient, err := meta.(conns.ClientSession).CatalogManagementV1()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			consumableVersionOptions := &catalogmanagementv1.ConsumableVersionOptions{}
+			consumableVersionOptions.SetVersionLocID(d.Get("version_locator").(string))
+			response, err := catalogManagementClient.ConsumableVersionWithContext(context, consumableVersionOptions)
+			if err != nil {
+				log.Printf("[DEBUG] ConsumableVersionWithContext failed %s\n%s", err, response)
+				return diag.FromErr(fmt.Errorf("ConsumableVersionWithContext failed %s\n%s", err, response))
+			}
+		}
+
+		err := publishCmVersionOnboarding(context, d, meta, previous)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceIBMCmVersionOnboardingRead(context, d, meta)
+}
+
+func resourceIBMCmVersionOnboardingDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	catalogManagementClient, err := meta.(conns.ClientSession).CatalogManagementV1()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	mk := fmt.Sprintf("%s.%s", d.Get("catalog_id").(string), d.Get("offering_id").(string))
+	conns.IbmMutexKV.Lock(mk)
+	defer conns.IbmMutexKV.Unlock(mk)
+
+	deleteVersionOptions := &catalogmanagementv1.DeleteVersionOptions{}
+	deleteVersionOptions.SetVersionLocID(strings.Replace(d.Id(), "/", ".", 1))
+
+	response, err := catalogManagementClient.DeleteVersionWithContext(context, deleteVersionOptions)
+	if err != nil && (response == nil || response.StatusCode != 404) {
+		log.Printf("[DEBUG] DeleteVersionWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("DeleteVersionWithContext failed %s\n%s", err, response))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// packageCmVersionOnboardingSourceDir packages a module directory into a tgz
+// archive. Entries are sorted and their timestamps are cleared, so the same
+// files always give the same archive. Terraform state and working directories
+// are left out.
+func packageCmVersionOnboardingSourceDir(dir string) ([]byte, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading source_dir %s: %s", dir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("source_dir %s is not a directory", dir)
+	}
+
+	paths := []string{}
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			if name == ".git" || name == ".terraform" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || strings.HasSuffix(name, ".tfstate") || strings.HasSuffix(name, ".tfstate.backup") {
+			return nil
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error reading source_dir %s: %s", dir, err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("source_dir %s contains no files", dir)
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading %s: %s", path, err)
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil, err
+		}
+		mode := int64(0644)
+		if info, err := os.Stat(path); err == nil && info.Mode()&0111 != 0 {
+			mode = 0755
+		}
+		header := &tar.Header{
+			Name:     filepath.ToSlash(rel),
+			Mode:     mode,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+			ModTime:  time.Unix(0, 0),
+			Format:   tar.FormatPAX,
+		}
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err = tarWriter.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err = tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func cmVersionOnboardingContentSha(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// validateCmVersionOnboarding validates the version in a Schematics workspace
+// and waits for the result. A failed validation returns the validation message.
+func validateCmVersionOnboarding(context context.Context, d *schema.ResourceData, meta interface{}, versionLocator string) error {
+	catalogManagementClient, err := meta.(conns.ClientSession).CatalogManagementV1()
+	if err != nil {
+		return err
+	}
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return err
+	}
+
+	validation := map[string]interface{}{}
+	if list := d.Get("validation").([]interface{}); len(list) != 0 && list[0] != nil {
+		validation = list[0].(map[string]interface{})
+	}
+
+	validateInstallOptions := &catalogmanagementv1.ValidateInstallOptions{}
+	validateInstallOptions.SetVersionLocID(versionLocator)
+	validateInstallOptions.SetVersionLocatorID(versionLocator)
+	validateInstallOptions.SetXAuthRefreshToken(bxSession.Config.IAMRefreshToken)
+	if region, ok := validation["region"].(string); ok && region != "" {
+		validateInstallOptions.SetRegion(region)
+	}
+	if overrides, ok := validation["override_values"].(map[string]interface{}); ok && len(overrides) != 0 {
+		overridesModel, err := configureOverrides(overrides)
+		if err != nil {
+			return err
+		}
+		validateInstallOptions.SetOverrideValues(&overridesModel)
+	}
+	if envVariables, ok := validation["environment_variables"].([]interface{}); ok && len(envVariables) != 0 {
+		envVariableMaps := make([]map[string]interface{}, 0, len(envVariables))
+		for _, envVariable := range envVariables {
+			envVariableMaps = append(envVariableMaps, envVariable.(map[string]interface{}))
+		}
+		envsModel, err := envVariablesToDeployRequestBodyEnvVariables(envVariableMaps)
+		if err != nil {
+			return err
+		}
+		validateInstallOptions.SetEnvironmentVariables(envsModel)
+	}
+	if schematics, ok := validation["schematics"].([]interface{}); ok && len(schematics) != 0 && schematics[0] != nil {
+		schematicsModel, err := schematicsMapToDeployRequestBodySchematics(schematics[0].(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+		validateInstallOptions.SetSchematics(&schematicsModel)
+	}
+
+	response, err := catalogManagementClient.ValidateInstallWithContext(context, validateInstallOptions)
+	if err != nil {
+		log.Printf("[DEBUG] ValidateInstallWithContext failed %s\n%s", err, response)
+		return fmt.Errorf("ValidateInstallWithContext failed %s\n%s", err, response)
+	}
+
+	validationStatusOptions := &catalogmanagementv1.GetValidationStatusOptions{}
+	validationStatusOptions.SetVersionLocID(versionLocator)
+	validationStatusOptions.SetXAuthRefreshToken(bxSession.Config.IAMRefreshToken)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"in_progress"},
+		Target:  []string{"valid", "invalid", "expired"},
+		Refresh: func() (interface{}, string, error) {
+			result, response, err := catalogManagementClient.GetValidationStatusWithContext(context, validationStatusOptions)
+			if err != nil {
+				log.Printf("[DEBUG] GetValidationStatusWithContext failed %s\n%s", err, response)
+				return nil, "", fmt.Errorf("GetValidationStatusWithContext failed %s\n%s", err, response)
+			}
+			state := ""
+			if result.State != nil {
+				state = *result.State
+			}
+			log.Printf("[DEBUG] Validation status of version %s is %s\n", versionLocator, state)
+			if state != "valid" && state != "invalid" && state != "expired" {
+				state = "in_progress"
+			}
+			return result, state, nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+	result, err := stateConf.WaitForStateContext(context)
+	if err != nil {
+		return fmt.Errorf("Error waiting for the validation of version %s: %s", versionLocator, err)
+	}
+
+	status := result.(*catalogmanagementv1.Validation)
+	if status.State != nil && *status.State == "valid" {
+		return nil
+	}
+	return cmVersionOnboardingValidationError(versionLocator, status)
+}
+
+func cmVersionOnboardingValidationError(versionLocator string, status *catalogmanagementv1.Validation) error {
+	var details strings.Builder
+	fmt.Fprintf(&details, "validation of version %s is %s", versionLocator, *status.State)
+	if status.LastOperation != nil && *status.LastOperation != "" {
+		fmt.Fprintf(&details, "\nlast operation: %s", *status.LastOperation)
+	}
+	if len(status.Target) != 0 {
+		keys := make([]string, 0, len(status.Target))
+		for key := range status.Target {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(&details, "\n%s: %v", key, status.Target[key])
+		}
+	}
+	if status.Message != nil && *status.Message != "" {
+		fmt.Fprintf(&details, "\nvalidation log:\n%s", *status.Message)
+	}
+	return fmt.Errorf("%s", details.String())
+}
+
+// publishCmVersionOnboarding applies the publish block to the offering. The
+// accounts and enterprises of the previous publish block that are no longer
+// listed are removed from the access list of the offering.
+func publishCmVersionOnboarding(context context.Context, d *schema.ResourceData, meta interface{}, previous map[string]interface{}) error {
+	catalogManagementClient, err := meta.(conns.ClientSession).CatalogManagementV1()
+	if err != nil {
+		return err
+	}
+
+	catalogID := d.Get("catalog_id").(string)
+	offeringID := d.Get("offering_id").(string)
+
+	publish := map[string]interface{}{}
+	if list := d.Get("publish").([]interface{}); len(list) != 0 && list[0] != nil {
+		publish = list[0].(map[string]interface{})
+	}
+	accesses := cmVersionOnboardingAccesses(publish)
+	previousAccesses := cmVersionOnboardingAccesses(previous)
+
+	removed := []string{}
+	for _, access := range previousAccesses {
+		if !flex.StringContains(accesses, access) {
+			removed = append(removed, access)
+		}
+	}
+	if len(removed) != 0 {
+		deleteOfferingAccessListOptions := &catalogmanagementv1.DeleteOfferingAccessListOptions{}
+		deleteOfferingAccessListOptions.SetCatalogIdentifier(catalogID)
+		deleteOfferingAccessListOptions.SetOfferingID(offeringID)
+		deleteOfferingAccessListOptions.SetAccesses(removed)
+		result, response, err := catalogManagementClient.DeleteOfferingAccessListWithContext(context, deleteOfferingAccessListOptions)
+		if err != nil {
+			log.Printf("[DEBUG] DeleteOfferingAccessListWithContext failed %s\n%s", err, response)
+			return fmt.Errorf("DeleteOfferingAccessListWithContext failed %s\n%s", err, response)
+		}
+		if result != nil && len(result.Errors) != 0 {
+			return fmt.Errorf("Error removing accesses from offering %s: %v", offeringID, result.Errors)
+		}
+	}
+
+	shareOfferingOptions := &catalogmanagementv1.ShareOfferingOptions{}
+	shareOfferingOptions.SetCatalogIdentifier(catalogID)
+	shareOfferingOptions.SetOfferingID(offeringID)
+	shareOfferingOptions.SetEnabled(len(publish) != 0)
+	shareOfferingOptions.SetIBM(publish["ibm"] != nil && publish["ibm"].(bool))
+	shareOfferingOptions.SetPublic(publish["public"] != nil && publish["public"].(bool))
+	_, response, err := catalogManagementClient.ShareOfferingWithContext(context, shareOfferingOptions)
+	if err != nil {
+		log.Printf("[DEBUG] ShareOfferingWithContext failed %s\n%s", err, response)
+		return fmt.Errorf("ShareOfferingWithContext failed %s\n%s", err, response)
+	}
+
+	if len(accesses) != 0 {
+		addOfferingAccessListOptions := &catalogmanagementv1.AddOfferingAccessListOptions{}
+		addOfferingAccessListOptions.SetCatalogIdentifier(catalogID)
+		addOfferingAccessListOptions.SetOfferingID(offeringID)
+		addOfferingAccessListOptions.SetAccesses(accesses)
+		_, response, err := catalogManagementClient.AddOfferingAccessListWithContext(context, addOfferingAccessListOptions)
+		if err != nil {
+			log.Printf("[DEBUG] AddOfferingAccessListWithContext failed %s\n%s", err, response)
+			return fmt.Errorf("AddOfferingAccessListWithContext failed %s\n%s", err, response)
+		}
+	}
+
+	return nil
+}
+
+// cmVersionOnboardingAccesses returns the access list entries of a publish
+// block, -acct-<id> for accounts and -ent-<id> for enterprises.
+func cmVersionOnboardingAccesses(publish map[string]interface{}) []string {
+	accesses := []string{}
+	if accounts, ok := publish["account_ids"].(*schema.Set); ok {
+		for _, account := range flex.ExpandStringList(accounts.List()) {
+			accesses = append(accesses, "-acct-"+account)
+		}
+	}
+	if enterprises, ok := publish["enterprise_ids"].(*schema.Set); ok {
+		for _, enterprise := range flex.ExpandStringList(enterprises.List()) {
+			accesses = append(accesses, "-ent-"+enterprise)
+		}
+	}
+	sort.Strings(accesses)
+	return accesses
+}
@@ -0,0 +1,121 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package catalogmanagement_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM/platform-services-go-sdk/catalogmanagementv1"
+)
+
+func TestAccIBMCmVersionOnboardingBasic(t *testing.T) {
+	var conf catalogmanagementv1.Version
+	sourceDir := t.TempDir()
+	targetVersion := "1.0.0"
+
+	err := os.WriteFile(filepath.Join(sourceDir, "main.tf"), []byte(testAccCheckIBMCmVersionOnboardingModule("one")), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMCmVersionOnboardingDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMCmVersionOnboardingConfig(sourceDir, targetVersion),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMCmVersionExists("ibm_cm_version_onboarding.cm_version_onboarding", conf),
+					resource.TestCheckResourceAttr("ibm_cm_version_onboarding.cm_version_onboarding", "target_version", targetVersion),
+					resource.TestCheckResourceAttr("ibm_cm_version_onboarding.cm_version_onboarding", "validation_state", "valid"),
+					resource.TestCheckResourceAttr("ibm_cm_version_onboarding.cm_version_onboarding", "state", "consumable"),
+					resource.TestCheckResourceAttrSet("ibm_cm_version_onboarding.cm_version_onboarding", "content_sha"),
+					resource.TestCheckResourceAttrSet("ibm_cm_version_onboarding.cm_version_onboarding", "version_locator"),
+				),
+			},
+			resource.TestStep{
+				PreConfig: func() {
+					err := os.WriteFile(filepath.Join(sourceDir, "main.tf"), []byte(testAccCheckIBMCmVersionOnboardingModule("two")), 0644)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccCheckIBMCmVersionOnboardingConfig(sourceDir, targetVersion),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCmVersionOnboardingModule(value string) string {
+	return fmt.Sprintf(`
+		output "value" {
+			value = "%s"
+		}
+	`, value)
+}
+
+func testAccCheckIBMCmVersionOnboardingConfig(sourceDir string, targetVersion string) string {
+	return fmt.Sprintf(`
+		resource "ibm_cm_catalog" "cm_catalog" {
+			label = "test_tf_catalog_label_onboarding"
+			kind = "offering"
+		}
+
+		resource "ibm_cm_offering" "cm_offering" {
+			catalog_id = ibm_cm_catalog.cm_catalog.id
+			label = "test_tf_offering_label_onboarding"
+			name = "test_tf_offering_name_onboarding"
+			offering_icon_url = "test.url.onboarding"
+			tags = ["dev_ops"]
+		}
+
+		resource "ibm_cm_version_onboarding" "cm_version_onboarding" {
+			catalog_id = ibm_cm_catalog.cm_catalog.id
+			offering_id = ibm_cm_offering.cm_offering.id
+			source_dir = "%s"
+			target_version = "%s"
+			validation {
+				region = "us-south"
+			}
+			mark_version_consumable = true
+		}
+	`, sourceDir, targetVersion)
+}
+
+func testAccCheckIBMCmVersionOnboardingDestroy(s *terraform.State) error {
+	catalogManagementClient, err := acc.TestAccProvider.Meta().(conns.ClientSession).CatalogManagementV1()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_cm_version_onboarding" {
+			continue
+		}
+
+		getVersionOptions := &catalogmanagementv1.GetVersionOptions{}
+		getVersionOptions.SetVersionLocID(strings.Replace(rs.Primary.ID, "/", ".", 1))
+
+		_, response, err := catalogManagementClient.GetVersion(getVersionOptions)
+
+		if err == nil {
+			return fmt.Errorf("cm_version_onboarding still exists: %s", rs.Primary.ID)
+		} else if response.StatusCode != 404 {
+			return fmt.Errorf("Error checking for cm_version_onboarding (%s) has been destroyed: %s", rs.Primary.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package cdtektonpipeline
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/continuous-delivery-go-sdk/cdtektonpipelinev2"
+)
+
+func DataSourceIBMCdTektonPipelineRuns() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMCdTektonPipelineRunsRead,
+
+		Schema: map[string]*schema.Schema{
+			"pipeline_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The Tekton pipeline ID.",
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the runs with this status.",
+			},
+			"trigger_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the runs started by this trigger.",
+			},
+			"limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(1, 50),
+				Description:  "The number of most recent runs to list.",
+			},
+			"pipeline_runs": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The most recent pipeline runs, newest first.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the pipeline run.",
+						},
+						"status": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the pipeline run.",
+						},
+						"trigger_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the trigger that started the run.",
+						},
+						"definition_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the definition that the run used.",
+						},
+						"listener_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the event listener that the run used.",
+						},
+						"run_url": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL of the pipeline run in the console.",
+						},
+						"href": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "API URL for interacting with the pipeline run.",
+						},
+						"created_at": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the pipeline run was created.",
+						},
+						"updated_at": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the pipeline run was last updated.",
+						},
+						"duration": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the pipeline run took to finish. Empty while the run is in progress.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIBMCdTektonPipelineRunsRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cdTektonPipelineClient, err := meta.(conns.ClientSession).CdTektonPipelineV2()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	listTektonPipelineRunsOptions := &cdtektonpipelinev2.ListTektonPipelineRunsOptions{}
+
+	listTektonPipelineRunsOptions.SetPipelineID(d.Get("pipeline_id").(string))
+	listTektonPipelineRunsOptions.SetLimit(int64(d.Get("limit").(int)))
+	if _, ok := d.GetOk("status"); ok {
+		listTektonPipelineRunsOptions.SetStatus(d.Get("status").(string))
+	}
+	if _, ok := d.GetOk("trigger_name"); ok {
+		listTektonPipelineRunsOptions.SetTriggerName(d.Get("trigger_name").(string))
+	}
+
+	pipelineRunsCollection, response, err := cdTektonPipelineClient.ListTektonPipelineRunsWithContext(context, listTektonPipelineRunsOptions)
+	if err != nil {
+		log.Printf("[DEBUG] ListTektonPipelineRunsWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("ListTektonPipelineRunsWithContext failed %s\n%s", err, response))
+	}
+
+	d.SetId(*listTektonPipelineRunsOptions.PipelineID)
+
+	pipelineRuns := []map[string]interface{}{}
+	for _, pipelineRun := range pipelineRunsCollection.PipelineRuns {
+		pipelineRunMap := map[string]interface{}{
+			"id":            pipelineRun.ID,
+			"status":        pipelineRun.Status,
+			"definition_id": pipelineRun.DefinitionID,
+			"listener_name": pipelineRun.ListenerName,
+			"run_url":       pipelineRun.RunURL,
+			"href":          pipelineRun.Href,
+			"created_at":    flex.DateTimeToString(pipelineRun.CreatedAt),
+			"updated_at":    flex.DateTimeToString(pipelineRun.UpdatedAt),
+			"duration":      cdTektonPipelineRunDuration(&pipelineRun),
+		}
+		if trigger, ok := pipelineRun.Trigger.(*cdtektonpipelinev2.Trigger); ok && trigger.Name != nil {
+			pipelineRunMap["trigger_name"] = trigger.Name
+		}
+		pipelineRuns = append(pipelineRuns, pipelineRunMap)
+	}
+	if err = d.Set("pipeline_runs", pipelineRuns); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting pipeline_runs: %s", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package cdtektonpipeline_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+)
+
+func TestAccIBMCdTektonPipelineRunsDataSourceBasic(t *testing.T) {
+	triggerName := fmt.Sprintf("tf_name_%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMCdTektonPipelineRunConfigBasic(triggerName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ibm_cd_tekton_pipeline_runs.cd_tekton_pipeline_runs", "id"),
+					resource.TestCheckResourceAttr("data.ibm_cd_tekton_pipeline_runs.cd_tekton_pipeline_runs", "pipeline_runs.#", "1"),
+					resource.TestCheckResourceAttrPair("data.ibm_cd_tekton_pipeline_runs.cd_tekton_pipeline_runs", "pipeline_runs.0.id", "ibm_cd_tekton_pipeline_run.cd_tekton_pipeline_run", "run_id"),
+					resource.TestCheckResourceAttr("data.ibm_cd_tekton_pipeline_runs.cd_tekton_pipeline_runs", "pipeline_runs.0.trigger_name", triggerName),
+					resource.TestCheckResourceAttr("data.ibm_cd_tekton_pipeline_runs.cd_tekton_pipeline_runs", "pipeline_runs.0.status", "succeeded"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,514 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package cdtektonpipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/continuous-delivery-go-sdk/cdtektonpipelinev2"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+func ResourceIBMCdTektonPipelineRun() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMCdTektonPipelineRunCreate,
+		ReadContext:   resourceIBMCdTektonPipelineRunRead,
+		UpdateContext: resourceIBMCdTektonPipelineRunUpdate,
+		DeleteContext: resourceIBMCdTektonPipelineRunDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"pipeline_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.InvokeValidator("ibm_cd_tekton_pipeline_run", "pipeline_id"),
+				Description:  "The Tekton pipeline ID.",
+			},
+			"trigger_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.InvokeValidator("ibm_cd_tekton_pipeline_run", "trigger_name"),
+				Description:  "The name of the manual trigger that starts the run.",
+			},
+			"trigger_properties": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Additional `text` properties, or overrides of existing pipeline and trigger properties, to use for the run.",
+			},
+			"secure_trigger_properties": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Additional `secure` properties, or overrides of existing `secure` pipeline and trigger properties, to use for the run.",
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that start a new run when they change.",
+			},
+			"wait_for_completion": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to wait for the run to finish. A run that does not succeed fails the apply.",
+			},
+			"delete_on_destroy": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether destroying the resource deletes the run from the pipeline history. By default the run is kept, and only cancelled when it is still in progress.",
+			},
+			"log_tail_lines": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validate.InvokeValidator("ibm_cd_tekton_pipeline_run", "log_tail_lines"),
+				Description:  "The number of lines at the end of each step log to capture when the run finishes. Set to 0 to not capture logs.",
+			},
+			"run_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the pipeline run.",
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the pipeline run.",
+			},
+			"definition_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the definition that the run used.",
+			},
+			"listener_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the event listener that the run used.",
+			},
+			"run_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the pipeline run in the console.",
+			},
+			"href": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "API URL for interacting with the pipeline run.",
+			},
+			"created_at": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the pipeline run was created.",
+			},
+			"updated_at": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the pipeline run was last updated.",
+			},
+			"duration": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the pipeline run took to finish, for example `4m32s`.",
+			},
+			"logs": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The end of the step logs of the finished run.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the step log.",
+						},
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the step log, in the form `<task>/<step>`.",
+						},
+						"data": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The last `log_tail_lines` lines of the step log.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func ResourceIBMCdTektonPipelineRunValidator() *validate.ResourceValidator {
+	validateSchema := make([]validate.ValidateSchema, 0)
+	validateSchema = append(validateSchema,
+		validate.ValidateSchema{
+			Identifier:                 "pipeline_id",
+			ValidateFunctionIdentifier: validate.ValidateRegexpLen,
+			Type:                       validate.TypeString,
+			Required:                   true,
+			Regexp:                     `^[-0-9a-z]+$`,
+			MinValueLength:             36,
+			MaxValueLength:             36,
+		},
+		validate.ValidateSchema{
+			Identifier:                 "trigger_name",
+			ValidateFunctionIdentifier: validate.ValidateRegexpLen,
+			Type:                       validate.TypeString,
+			Required:                   true,
+			Regexp:                     `^([a-zA-Z0-9]{1,2}|[a-zA-Z0-9][0-9a-zA-Z-_.: \/\(\)\[\]]{1,251}[a-zA-Z0-9])$`,
+			MinValueLength:             1,
+			MaxValueLength:             253,
+		},
+		validate.ValidateSchema{
+			Identifier:                 "log_tail_lines",
+			ValidateFunctionIdentifier: validate.IntBetween,
+			Type:                       validate.TypeInt,
+			Optional:                   true,
+			MinValue:                   "0",
+			MaxValue:                   "1000",
+		},
+	)
+
+	resourceValidator := validate.ResourceValidator{ResourceName: "ibm_cd_tekton_pipeline_run", Schema: validateSchema}
+	return &resourceValidator
+}
+
+func resourceIBMCdTektonPipelineRunCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cdTektonPipelineClient, err := meta.(conns.ClientSession).CdTektonPipelineV2()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pipelineID := d.Get("pipeline_id").(string)
+	trigger := map[string]interface{}{
+		"name": d.Get("trigger_name").(string),
+	}
+	if v, ok := d.GetOk("trigger_properties"); ok {
+		trigger["properties"] = v.(map[string]interface{})
+	}
+	if v, ok := d.GetOk("secure_trigger_properties"); ok {
+		trigger["secure_properties"] = v.(map[string]interface{})
+	}
+
+	pipelineRun, response, err := createCdTektonPipelineRun(context, cdTektonPipelineClient, pipelineID, trigger)
+	if err != nil {
+		log.Printf("[DEBUG] CreateTektonPipelineRunWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("CreateTektonPipelineRunWithContext failed %s\n%s", err, response))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", pipelineID, *pipelineRun.ID))
+
+	if d.Get("wait_for_completion").(bool) {
+		finished, err := waitForCdTektonPipelineRunCompletion(context, d, meta)
+		if finished != nil {
+			if logErr := setCdTektonPipelineRunLogs(context, d, meta, finished.(*cdtektonpipelinev2.PipelineRun)); logErr != nil {
+				log.Printf("[WARN] Error capturing the logs of pipeline run %s: %s", d.Id(), logErr)
+			}
+		}
+		if err != nil {
+			resourceIBMCdTektonPipelineRunRead(context, d, meta)
+			return diag.FromErr(fmt.Errorf("Error waiting for pipeline run (%s) to finish: %s", d.Id(), err))
+		}
+	}
+
+	return resourceIBMCdTektonPipelineRunRead(context, d, meta)
+}
+
+// The trigger properties of a run are objects of string values, so the
+// request is built here rather than with CreateTektonPipelineRunOptions,
+// whose property fields are lists.
+func createCdTektonPipelineRun(context context.Context, cdTektonPipelineClient *cdtektonpipelinev2.CdTektonPipelineV2, pipelineID string, trigger map[string]interface{}) (*cdtektonpipelinev2.PipelineRun, *core.DetailedResponse, error) {
+	builder := core.NewRequestBuilder(core.POST)
+	builder = builder.WithContext(context)
+	builder.EnableGzipCompression = cdTektonPipelineClient.GetEnableGzipCompression()
+	_, err := builder.ResolveRequestURL(cdTektonPipelineClient.Service.Options.URL, `/tekton_pipelines/{pipeline_id}/pipeline_runs`, map[string]string{"pipeline_id": pipelineID})
+	if err != nil {
+		return nil, nil, err
+	}
+	builder.AddHeader("Accept", "application/json")
+	builder.AddHeader("Content-Type", "application/json")
+	_, err = builder.SetBodyContentJSON(map[string]interface{}{"trigger": trigger})
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := builder.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rawResponse map[string]json.RawMessage
+	response, err := cdTektonPipelineClient.Service.Request(request, &rawResponse)
+	if err != nil {
+		return nil, response, err
+	}
+	var pipelineRun *cdtektonpipelinev2.PipelineRun
+	err = core.UnmarshalModel(rawResponse, "", &pipelineRun, cdtektonpipelinev2.UnmarshalPipelineRun)
+	if err != nil {
+		return nil, response, err
+	}
+	return pipelineRun, response, nil
+}
+
+func waitForCdTektonPipelineRunCompletion(context context.Context, d *schema.ResourceData, meta interface{}) (interface{}, error) {
+	cdTektonPipelineClient, err := meta.(conns.ClientSession).CdTektonPipelineV2()
+	if err != nil {
+		return nil, err
+	}
+
+	getTektonPipelineRunOptions := &cdtektonpipelinev2.GetTektonPipelineRunOptions{}
+
+	parts, err := flex.SepIdParts(d.Id(), "/")
+	if err != nil {
+		return nil, err
+	}
+
+	getTektonPipelineRunOptions.SetPipelineID(parts[0])
+	getTektonPipelineRunOptions.SetID(parts[1])
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			cdtektonpipelinev2.PipelineRunStatusPendingConst,
+			cdtektonpipelinev2.PipelineRunStatusWaitingConst,
+			cdtektonpipelinev2.PipelineRunStatusQueuedConst,
+			cdtektonpipelinev2.PipelineRunStatusRunningConst,
+			cdtektonpipelinev2.PipelineRunStatusCancellingConst,
+		},
+		Target: []string{cdtektonpipelinev2.PipelineRunStatusSucceededConst},
+		Refresh: func() (interface{}, string, error) {
+			pipelineRun, response, err := cdTektonPipelineClient.GetTektonPipelineRunWithContext(context, getTektonPipelineRunOptions)
+			if err != nil {
+				return nil, "", fmt.Errorf("GetTektonPipelineRunWithContext failed %s\n%s", err, response)
+			}
+			status := core.StringNilMapper(pipelineRun.Status)
+			switch status {
+			case cdtektonpipelinev2.PipelineRunStatusFailedConst, cdtektonpipelinev2.PipelineRunStatusErrorConst, cdtektonpipelinev2.PipelineRunStatusCancelledConst:
+				return pipelineRun, status, fmt.Errorf("The pipeline run finished with status %s, see %s", status, core.StringNilMapper(pipelineRun.RunURL))
+			}
+			return pipelineRun, status, nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForStateContext(context)
+}
+
+// setCdTektonPipelineRunLogs captures the end of every step log of a run. The
+// logs are only read once, when the run finishes.
+func setCdTektonPipelineRunLogs(context context.Context, d *schema.ResourceData, meta interface{}, pipelineRun *cdtektonpipelinev2.PipelineRun) error {
+	tailLines := d.Get("log_tail_lines").(int)
+	if tailLines == 0 {
+		return nil
+	}
+
+	cdTektonPipelineClient, err := meta.(conns.ClientSession).CdTektonPipelineV2()
+	if err != nil {
+		return err
+	}
+
+	getTektonPipelineRunLogsOptions := &cdtektonpipelinev2.GetTektonPipelineRunLogsOptions{}
+	getTektonPipelineRunLogsOptions.SetPipelineID(*pipelineRun.PipelineID)
+	getTektonPipelineRunLogsOptions.SetID(*pipelineRun.ID)
+
+	logsCollection, response, err := cdTektonPipelineClient.GetTektonPipelineRunLogsWithContext(context, getTektonPipelineRunLogsOptions)
+	if err != nil {
+		return fmt.Errorf("GetTektonPipelineRunLogsWithContext failed %s\n%s", err, response)
+	}
+
+	logs := []map[string]interface{}{}
+	for _, stepLog := range logsCollection.Logs {
+		getTektonPipelineRunLogContentOptions := &cdtektonpipelinev2.GetTektonPipelineRunLogContentOptions{}
+		getTektonPipelineRunLogContentOptions.SetPipelineID(*pipelineRun.PipelineID)
+		getTektonPipelineRunLogContentOptions.SetPipelineRunID(*pipelineRun.ID)
+		getTektonPipelineRunLogContentOptions.SetID(*stepLog.ID)
+
+		content, response, err := cdTektonPipelineClient.GetTektonPipelineRunLogContentWithContext(context, getTektonPipelineRunLogContentOptions)
+		if err != nil {
+			return fmt.Errorf("GetTektonPipelineRunLogContentWithContext failed %s\n%s", err, response)
+		}
+		logs = append(logs, map[string]interface{}{
+			"id":   stepLog.ID,
+			"name": stepLog.Name,
+			"data": cdTektonPipelineRunLogTail(core.StringNilMapper(content.Data), tailLines),
+		})
+	}
+
+	return d.Set("logs", logs)
+}
+
+func cdTektonPipelineRunLogTail(data string, lines int) string {
+	data = strings.TrimRight(data, "\n")
+	all := strings.Split(data, "\n")
+	if len(all) <= lines {
+		return data
+	}
+	return strings.Join(all[len(all)-lines:], "\n")
+}
+
+func resourceIBMCdTektonPipelineRunRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cdTektonPipelineClient, err := meta.(conns.ClientSession).CdTektonPipelineV2()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	getTektonPipelineRunOptions := &cdtektonpipelinev2.GetTektonPipelineRunOptions{}
+
+	parts, err := flex.SepIdParts(d.Id(), "/")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	getTektonPipelineRunOptions.SetPipelineID(parts[0])
+	getTektonPipelineRunOptions.SetID(parts[1])
+
+	pipelineRun, response, err := cdTektonPipelineClient.GetTektonPipelineRunWithContext(context, getTektonPipelineRunOptions)
+	if err != nil {
+		// Runs are pruned from the pipeline history over time. The run keeps
+		// its last known state, so that it is not started again.
+		if response != nil && response.StatusCode == 404 {
+			log.Printf("[WARN] Pipeline run %s no longer exists, keeping its last known state", d.Id())
+			return nil
+		}
+		log.Printf("[DEBUG] GetTektonPipelineRunWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("GetTektonPipelineRunWithContext failed %s\n%s", err, response))
+	}
+
+	if err = d.Set("pipeline_id", pipelineRun.PipelineID); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting pipeline_id: %s", err))
+	}
+	if trigger, ok := pipelineRun.Trigger.(*cdtektonpipelinev2.Trigger); ok && trigger.Name != nil {
+		if err = d.Set("trigger_name", trigger.Name); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting trigger_name: %s", err))
+		}
+	}
+	if err = d.Set("run_id", pipelineRun.ID); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting run_id: %s", err))
+	}
+	if err = d.Set("status", pipelineRun.Status); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting status: %s", err))
+	}
+	if err = d.Set("definition_id", pipelineRun.DefinitionID); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting definition_id: %s", err))
+	}
+	if err = d.Set("listener_name", pipelineRun.ListenerName); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting listener_name: %s", err))
+	}
+	if err = d.Set("run_url", pipelineRun.RunURL); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting run_url: %s", err))
+	}
+	if err = d.Set("href", pipelineRun.Href); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting href: %s", err))
+	}
+	if err = d.Set("created_at", flex.DateTimeToString(pipelineRun.CreatedAt)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting created_at: %s", err))
+	}
+	if err = d.Set("updated_at", flex.DateTimeToString(pipelineRun.UpdatedAt)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting updated_at: %s", err))
+	}
+	if err = d.Set("duration", cdTektonPipelineRunDuration(pipelineRun)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting duration: %s", err))
+	}
+
+	return nil
+}
+
+// cdTektonPipelineRunDuration is the time between the creation and the last
+// update of a finished run, and empty while the run is in progress.
+func cdTektonPipelineRunDuration(pipelineRun *cdtektonpipelinev2.PipelineRun) string {
+	if pipelineRun.CreatedAt == nil || pipelineRun.UpdatedAt == nil {
+		return ""
+	}
+	switch core.StringNilMapper(pipelineRun.Status) {
+	case cdtektonpipelinev2.PipelineRunStatusSucceededConst, cdtektonpipelinev2.PipelineRunStatusFailedConst,
+		cdtektonpipelinev2.PipelineRunStatusErrorConst, cdtektonpipelinev2.PipelineRunStatusCancelledConst:
+		return time.Time(*pipelineRun.UpdatedAt).Sub(time.Time(*pipelineRun.CreatedAt)).Round(time.Second).String()
+	}
+	return ""
+}
+
+// Only arguments that affect how the run is started force a new run; the
+// others only take effect for the next run.
+func resourceIBMCdTektonPipelineRunUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceIBMCdTektonPipelineRunRead(context, d, meta)
+}
+
+func resourceIBMCdTektonPipelineRunDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cdTektonPipelineClient, err := meta.(conns.ClientSession).CdTektonPipelineV2()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	parts, err := flex.SepIdParts(d.Id(), "/")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	switch d.Get("status").(string) {
+	case cdtektonpipelinev2.PipelineRunStatusSucceededConst, cdtektonpipelinev2.PipelineRunStatusFailedConst,
+		cdtektonpipelinev2.PipelineRunStatusErrorConst, cdtektonpipelinev2.PipelineRunStatusCancelledConst:
+	default:
+		cancelTektonPipelineRunOptions := &cdtektonpipelinev2.CancelTektonPipelineRunOptions{}
+		cancelTektonPipelineRunOptions.SetPipelineID(parts[0])
+		cancelTektonPipelineRunOptions.SetID(parts[1])
+		cancelTektonPipelineRunOptions.SetForce(true)
+
+		_, response, err := cdTektonPipelineClient.CancelTektonPipelineRunWithContext(context, cancelTektonPipelineRunOptions)
+		if err != nil && (response == nil || response.StatusCode != 404) {
+			log.Printf("[DEBUG] CancelTektonPipelineRunWithContext failed %s\n%s", err, response)
+			return diag.FromErr(fmt.Errorf("CancelTektonPipelineRunWithContext failed %s\n%s", err, response))
+		}
+	}
+
+	// The run stays in the pipeline history unless its deletion is asked for.
+	if d.Get("delete_on_destroy").(bool) {
+		deleteTektonPipelineRunOptions := &cdtektonpipelinev2.DeleteTektonPipelineRunOptions{}
+		deleteTektonPipelineRunOptions.SetPipelineID(parts[0])
+		deleteTektonPipelineRunOptions.SetID(parts[1])
+
+		err = resource.RetryContext(context, d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+			response, err := cdTektonPipelineClient.DeleteTektonPipelineRunWithContext(context, deleteTektonPipelineRunOptions)
+			if err != nil && (response == nil || response.StatusCode != 404) {
+				// A run that is still being cancelled cannot be deleted yet.
+				if response != nil && response.StatusCode == 409 {
+					return resource.RetryableError(fmt.Errorf("DeleteTektonPipelineRunWithContext failed %s\n%s", err, response))
+				}
+				log.Printf("[DEBUG] DeleteTektonPipelineRunWithContext failed %s\n%s", err, response)
+				return resource.NonRetryableError(fmt.Errorf("DeleteTektonPipelineRunWithContext failed %s\n%s", err, response))
+			}
+			return nil
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package cloudinit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	yaml "gopkg.in/yaml.v3"
+)
+
+const (
+	cloudInitConfigPlatform     = "platform"
+	cloudInitConfigGzip         = "gzip"
+	cloudInitConfigBase64Encode = "base64_encode"
+	cloudInitConfigBoundary     = "boundary"
+	cloudInitConfigPart         = "part"
+	cloudInitConfigRendered     = "rendered"
+	cloudInitConfigSize         = "size"
+	cloudInitConfigSizeLimit    = "size_limit"
+
+	cloudInitConfigPartContentType = "content_type"
+	cloudInitConfigPartContent     = "content"
+	cloudInitConfigPartFilename    = "filename"
+	cloudInitConfigPartMergeType   = "merge_type"
+
+	cloudInitConfigPlatformVPC   = "vpc"
+	cloudInitConfigPlatformPower = "power"
+
+	cloudInitConfigCloudConfig = "text/cloud-config"
+)
+
+// cloudInitConfigSizeLimits are the user data limits in bytes of the
+// platforms, the PowerVS limit applies to the base64 encoded user data.
+var cloudInitConfigSizeLimits = map[string]int{
+	cloudInitConfigPlatformVPC:   64 * 1024,
+	cloudInitConfigPlatformPower: 63 * 1024,
+}
+
+func DataSourceIBMCloudInitConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMCloudInitConfigRead,
+
+		Schema: map[string]*schema.Schema{
+			cloudInitConfigPlatform: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      cloudInitConfigPlatformVPC,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{cloudInitConfigPlatformVPC, cloudInitConfigPlatformPower}),
+				Description:  "The platform the user data is for, vpc for ibm_is_instance and ibm_is_instance_template or power for ibm_pi_instance",
+			},
+			cloudInitConfigGzip: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Compress the user data with gzip, requires base64_encode",
+			},
+			cloudInitConfigBase64Encode: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Encode the user data with base64, defaults to false for vpc and true for power",
+			},
+			cloudInitConfigBoundary: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "MIMEBOUNDARY",
+				Description: "The boundary of the MIME multipart document",
+			},
+			cloudInitConfigPart: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The parts of the MIME multipart document",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						cloudInitConfigPartContentType: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The MIME type of the part, such as text/cloud-config or text/x-shellscript",
+						},
+						cloudInitConfigPartContent: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The content of the part",
+						},
+						cloudInitConfigPartFilename: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The filename of the part",
+						},
+						cloudInitConfigPartMergeType: {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The cloud-init merge type of the part, such as list(append)+dict(no_replace,recurse_list)+str()",
+						},
+					},
+				},
+			},
+			cloudInitConfigRendered: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user data to pass to user_data or pi_user_data",
+			},
+			cloudInitConfigSize: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the rendered user data in bytes",
+			},
+			cloudInitConfigSizeLimit: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The user data limit of the platform in bytes",
+			},
+		},
+	}
+}
+
+func dataSourceIBMCloudInitConfigRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	platform := d.Get(cloudInitConfigPlatform).(string)
+	gzipEnabled := d.Get(cloudInitConfigGzip).(bool)
+	base64Encode := platform == cloudInitConfigPlatformPower
+	if v, ok := d.GetOkExists(cloudInitConfigBase64Encode); ok {
+		base64Encode = v.(bool)
+	}
+	if platform == cloudInitConfigPlatformPower && !base64Encode {
+		return diag.FromErr(fmt.Errorf("[ERROR] base64_encode must be true for platform power, pi_user_data must be base64 encoded"))
+	}
+	if gzipEnabled && !base64Encode {
+		return diag.FromErr(fmt.Errorf("[ERROR] base64_encode must be true when gzip is enabled"))
+	}
+
+	rendered, err := renderCloudInitConfig(d.Get(cloudInitConfigBoundary).(string), d.Get(cloudInitConfigPart).([]interface{}), gzipEnabled, base64Encode)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sizeLimit, err := validateCloudInitConfigSize(platform, rendered)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	checksum := sha256.Sum256([]byte(rendered))
+	d.SetId(hex.EncodeToString(checksum[:]))
+	d.Set(cloudInitConfigBase64Encode, base64Encode)
+	d.Set(cloudInitConfigRendered, rendered)
+	d.Set(cloudInitConfigSize, len(rendered))
+	d.Set(cloudInitConfigSizeLimit, sizeLimit)
+	return nil
+}
+
+// renderCloudInitConfig assembles the parts into a MIME multipart document,
+// the cloud-config parts are validated as YAML documents.
+func renderCloudInitConfig(boundary string, parts []interface{}, gzipEnabled, base64Encode bool) (string, error) {
+	var buffer bytes.Buffer
+	writer := multipart.NewWriter(&buffer)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return "", fmt.Errorf("[ERROR] Invalid boundary %q: %s", boundary, err)
+	}
+	fmt.Fprintf(&buffer, "Content-Type: multipart/mixed; boundary=\"%s\"\r\nMIME-Version: 1.0\r\n\r\n", boundary)
+
+	for i, p := range parts {
+		part := p.(map[string]interface{})
+		contentType := part[cloudInitConfigPartContentType].(string)
+		content := part[cloudInitConfigPartContent].(string)
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return "", fmt.Errorf("[ERROR] Invalid content_type %q of part %d: %s", contentType, i, err)
+		}
+		if mediaType == cloudInitConfigCloudConfig {
+			if err := validateCloudInitCloudConfig(content); err != nil {
+				return "", fmt.Errorf("[ERROR] Invalid cloud-config in part %d: %s", i, err)
+			}
+		}
+
+		header := textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"MIME-Version":              {"1.0"},
+			"Content-Transfer-Encoding": {"7bit"},
+		}
+		if filename := part[cloudInitConfigPartFilename].(string); filename != "" {
+			header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		}
+		if mergeType := part[cloudInitConfigPartMergeType].(string); mergeType != "" {
+			header.Set("X-Merge-Type", mergeType)
+		}
+		partWriter, err := writer.CreatePart(header)
+		if err != nil {
+			return "", err
+		}
+		if _, err := partWriter.Write([]byte(content)); err != nil {
+			return "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	output := buffer.Bytes()
+	if gzipEnabled {
+		var gzipBuffer bytes.Buffer
+		gzipWriter := gzip.NewWriter(&gzipBuffer)
+		if _, err := gzipWriter.Write(output); err != nil {
+			return "", err
+		}
+		if err := gzipWriter.Close(); err != nil {
+			return "", err
+		}
+		output = gzipBuffer.Bytes()
+	}
+	if base64Encode {
+		return base64.StdEncoding.EncodeToString(output), nil
+	}
+	return string(output), nil
+}
+
+// validateCloudInitConfigSize checks the rendered user data against the user
+// data limit of the platform, and returns the limit.
+func validateCloudInitConfigSize(platform, rendered string) (int, error) {
+	sizeLimit := cloudInitConfigSizeLimits[platform]
+	if len(rendered) > sizeLimit {
+		return sizeLimit, fmt.Errorf("[ERROR] The rendered user data is %d bytes, which exceeds the %s user data limit of %d bytes", len(rendered), platform, sizeLimit)
+	}
+	return sizeLimit, nil
+}
+
+// validateCloudInitCloudConfig checks that the content is a YAML mapping, as
+// cloud-init ignores a cloud-config that it can not parse.
+func validateCloudInitCloudConfig(content string) error {
+	var cloudConfig interface{}
+	if err := yaml.Unmarshal([]byte(content), &cloudConfig); err != nil {
+		return err
+	}
+	if _, ok := cloudConfig.(map[string]interface{}); !ok {
+		return fmt.Errorf("the cloud-config is not a YAML mapping")
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package cloudinit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+func testCloudInitConfigParts() []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			cloudInitConfigPartContentType: "text/cloud-config",
+			cloudInitConfigPartContent:     "#cloud-config\npackages:\n  - nginx\n",
+			cloudInitConfigPartFilename:    "",
+			cloudInitConfigPartMergeType:   "list(append)+dict(no_replace,recurse_list)+str()",
+		},
+		map[string]interface{}{
+			cloudInitConfigPartContentType: "text/x-shellscript",
+			cloudInitConfigPartContent:     "#!/bin/sh\nsystemctl start nginx\n",
+			cloudInitConfigPartFilename:    "start.sh",
+			cloudInitConfigPartMergeType:   "",
+		},
+	}
+}
+
+type testCloudInitConfigPart struct {
+	header   textproto.MIMEHeader
+	filename string
+	content  string
+}
+
+// readCloudInitConfig parses the rendered user data as a MIME multipart
+// document and returns its parts.
+func readCloudInitConfig(t *testing.T, rendered string) []testCloudInitConfigPart {
+	message, err := mail.ReadMessage(strings.NewReader(rendered))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" || params["boundary"] != "MIMEBOUNDARY" {
+		t.Fatalf("got content type %q, want multipart/mixed with boundary MIMEBOUNDARY", message.Header.Get("Content-Type"))
+	}
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	parts := []testCloudInitConfigPart{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, testCloudInitConfigPart{header: part.Header, filename: part.FileName(), content: string(content)})
+	}
+	return parts
+}
+
+func TestRenderCloudInitConfig(t *testing.T) {
+	rendered, err := renderCloudInitConfig("MIMEBOUNDARY", testCloudInitConfigParts(), false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	parts := readCloudInitConfig(t, rendered)
+	if len(parts) != 2 {
+		t.Fatalf("got %d parts, want 2", len(parts))
+	}
+	if contentType := parts[0].header.Get("Content-Type"); contentType != "text/cloud-config" {
+		t.Fatalf("got content type %q of the first part, want text/cloud-config", contentType)
+	}
+	if mergeType := parts[0].header.Get("X-Merge-Type"); mergeType != "list(append)+dict(no_replace,recurse_list)+str()" {
+		t.Fatalf("got merge type %q of the first part", mergeType)
+	}
+	if parts[0].content != "#cloud-config\npackages:\n  - nginx\n" {
+		t.Fatalf("got content %q of the first part", parts[0].content)
+	}
+	if parts[1].filename != "start.sh" || parts[1].header.Get("X-Merge-Type") != "" {
+		t.Fatalf("got filename %q and merge type %q of the second part, want start.sh without merge type", parts[1].filename, parts[1].header.Get("X-Merge-Type"))
+	}
+	if parts[1].content != "#!/bin/sh\nsystemctl start nginx\n" {
+		t.Fatalf("got content %q of the second part", parts[1].content)
+	}
+}
+
+func TestRenderCloudInitConfigEncoding(t *testing.T) {
+	plain, err := renderCloudInitConfig("MIMEBOUNDARY", testCloudInitConfigParts(), false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	encoded, err := renderCloudInitConfig("MIMEBOUNDARY", testCloudInitConfigParts(), false, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || string(decoded) != plain {
+		t.Fatalf("got %q (%v), want the base64 encoded document", encoded, err)
+	}
+
+	compressed, err := renderCloudInitConfig("MIMEBOUNDARY", testCloudInitConfigParts(), true, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	decoded, err = base64.StdEncoding.DecodeString(compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gzipReader, err := gzip.NewReader(bytes.NewReader(decoded))
+	if err != nil {
+		t.Fatal(err)
+	}
+	uncompressed, err := io.ReadAll(gzipReader)
+	if err != nil || string(uncompressed) != plain {
+		t.Fatalf("got %q (%v), want the gzip compressed document", uncompressed, err)
+	}
+}
+
+func TestRenderCloudInitConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		boundary string
+		part     map[string]interface{}
+		err      string
+	}{
+		{
+			name:     "invalid cloud-config",
+			boundary: "MIMEBOUNDARY",
+			part:     map[string]interface{}{cloudInitConfigPartContentType: "text/cloud-config", cloudInitConfigPartContent: "packages: [nginx\n"},
+			err:      "Invalid cloud-config in part 0",
+		},
+		{
+			name:     "invalid content type",
+			boundary: "MIMEBOUNDARY",
+			part:     map[string]interface{}{cloudInitConfigPartContentType: "text/", cloudInitConfigPartContent: "#!/bin/sh\n"},
+			err:      "Invalid content_type",
+		},
+		{
+			name:     "invalid boundary",
+			boundary: "MIME BOUNDARY ",
+			part:     map[string]interface{}{cloudInitConfigPartContentType: "text/x-shellscript", cloudInitConfigPartContent: "#!/bin/sh\n"},
+			err:      "Invalid boundary",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.part[cloudInitConfigPartFilename] = ""
+			tc.part[cloudInitConfigPartMergeType] = ""
+			_, err := renderCloudInitConfig(tc.boundary, []interface{}{tc.part}, false, false)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("got error %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestValidateCloudInitCloudConfig(t *testing.T) {
+	testCases := []struct {
+		content string
+		valid   bool
+	}{
+		{content: "#cloud-config\npackages:\n  - nginx\n", valid: true},
+		{content: "#cloud-config\n{}\n", valid: true},
+		{content: "packages: [nginx\n"},
+		{content: "- nginx\n"},
+		{content: "nginx"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.content, func(t *testing.T) {
+			if err := validateCloudInitCloudConfig(tc.content); (err == nil) != tc.valid {
+				t.Fatalf("got error %v, want valid %t", err, tc.valid)
+			}
+		})
+	}
+}
+
+func TestValidateCloudInitConfigSize(t *testing.T) {
+	testCases := []struct {
+		platform  string
+		size      int
+		sizeLimit int
+		err       bool
+	}{
+		{platform: cloudInitConfigPlatformVPC, size: 64 * 1024, sizeLimit: 64 * 1024},
+		{platform: cloudInitConfigPlatformVPC, size: 64*1024 + 1, sizeLimit: 64 * 1024, err: true},
+		{platform: cloudInitConfigPlatformPower, size: 63 * 1024, sizeLimit: 63 * 1024},
+		{platform: cloudInitConfigPlatformPower, size: 63*1024 + 1, sizeLimit: 63 * 1024, err: true},
+	}
+
+	for _, tc := range testCases {
+		sizeLimit, err := validateCloudInitConfigSize(tc.platform, strings.Repeat("a", tc.size))
+		if (err != nil) != tc.err {
+			t.Fatalf("got error %v for %d bytes on %s, want error %t", err, tc.size, tc.platform, tc.err)
+		}
+		if sizeLimit != tc.sizeLimit {
+			t.Fatalf("got size limit %d on %s, want %d", sizeLimit, tc.platform, tc.sizeLimit)
+		}
+		if err != nil && !strings.Contains(err.Error(), tc.platform+" user data limit") {
+			t.Fatalf("got error %v, want the limit of %s", err, tc.platform)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package cloudinit_test
+
+import (
+	"regexp"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMCloudInitConfigDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCloudInitConfigDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ibm_cloudinit_config.vpc", "base64_encode", "false"),
+					resource.TestCheckResourceAttr("data.ibm_cloudinit_config.vpc", "size_limit", "65536"),
+					resource.TestMatchResourceAttr("data.ibm_cloudinit_config.vpc", "rendered", regexp.MustCompile(`Content-Type: text/x-shellscript`)),
+					resource.TestCheckResourceAttr("data.ibm_cloudinit_config.power", "base64_encode", "true"),
+					resource.TestCheckResourceAttr("data.ibm_cloudinit_config.power", "size_limit", "64512"),
+					resource.TestCheckResourceAttrSet("data.ibm_cloudinit_config.power", "rendered"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIBMCloudInitConfigDataSource_invalidCloudConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckIBMCloudInitConfigDataSourceInvalidConfig(),
+				ExpectError: regexp.MustCompile("Invalid cloud-config in part 0"),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCloudInitConfigDataSourceConfig() string {
+	return `
+	data "ibm_cloudinit_config" "vpc" {
+		part {
+			content_type = "text/cloud-config"
+			content      = "#cloud-config\npackages:\n  - nginx\n"
+		}
+		part {
+			content_type = "text/x-shellscript"
+			content      = "#!/bin/sh\nsystemctl start nginx\n"
+			filename     = "start.sh"
+		}
+	}
+
+	data "ibm_cloudinit_config" "power" {
+		platform = "power"
+		gzip     = true
+		part {
+			content_type = "text/cloud-config"
+			content      = "#cloud-config\npackages:\n  - nginx\n"
+		}
+	}`
+}
+
+func testAccCheckIBMCloudInitConfigDataSourceInvalidConfig() string {
+	return `
+	data "ibm_cloudinit_config" "invalid" {
+		part {
+			content_type = "text/cloud-config"
+			content      = "packages: [nginx\n"
+		}
+	}`
+}
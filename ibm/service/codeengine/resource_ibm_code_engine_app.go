@@ -12,8 +12,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
-	"github.com/IBM-Cloud/bluemix-go/bmxerror"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
@@ -290,6 +290,19 @@ func ResourceIbmCodeEngineApp() *schema.Resource {
 					},
 				},
 			},
+			"ready_instances": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The number of running instances that the latest revision of the app must report before the apply completes. With the default of `0`, the apply waits until the latest revision is ready.",
+			},
+			"rollback_on_failure": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to restore the configuration of the previously ready revision when an update does not become ready. The apply still fails so that the configuration can be corrected.",
+			},
 			"etag": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -488,7 +501,7 @@ func resourceIbmCodeEngineAppCreate(context context.Context, d *schema.ResourceD
 
 	d.SetId(fmt.Sprintf("%s/%s", *createAppOptions.ProjectID, *app.Name))
 
-	_, err = waitForIbmCodeEngineAppCreate(d, meta)
+	_, err = waitForIbmCodeEngineAppReady(context, d, meta, "", d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		return diag.FromErr(fmt.Errorf(
 			"Error waiting for resource IbmCodeEngineApp (%s) to be created: %s", d.Id(), err))
@@ -497,46 +510,6 @@ func resourceIbmCodeEngineAppCreate(context context.Context, d *schema.ResourceD
 	return resourceIbmCodeEngineAppRead(context, d, meta)
 }
 
-func waitForIbmCodeEngineAppCreate(d *schema.ResourceData, meta interface{}) (interface{}, error) {
-	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
-	if err != nil {
-		return false, err
-	}
-	getAppOptions := &codeenginev2.GetAppOptions{}
-
-	parts, err := flex.SepIdParts(d.Id(), "/")
-	if err != nil {
-		return false, err
-	}
-
-	getAppOptions.SetProjectID(parts[0])
-	getAppOptions.SetName(parts[1])
-
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"deploying"},
-		Target:  []string{"ready", "failed", "warning"},
-		Refresh: func() (interface{}, string, error) {
-			stateObj, response, err := codeEngineClient.GetApp(getAppOptions)
-			if err != nil {
-				if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
-					return nil, "", fmt.Errorf("The instance %s does not exist anymore: %s\n%s", "getAppOptions", err, response)
-				}
-				return nil, "", err
-			}
-			failStates := map[string]bool{"failure": true, "failed": true}
-			if failStates[*stateObj.Status] {
-				return stateObj, *stateObj.Status, fmt.Errorf("The instance %s failed: %s\n%s", "getAppOptions", err, response)
-			}
-			return stateObj, *stateObj.Status, nil
-		},
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      60 * time.Second,
-		MinTimeout: 60 * time.Second,
-	}
-
-	return stateConf.WaitForState()
-}
-
 func resourceIbmCodeEngineAppRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
 	if err != nil {
@@ -873,6 +846,13 @@ func resourceIbmCodeEngineAppUpdate(context context.Context, d *schema.ResourceD
 	}
 	updateAppOptions.SetIfMatch(d.Get("etag").(string))
 
+	// The revisions known before the update: a changed configuration only
+	// counts as rolled out once a newer revision has been created and is
+	// ready, and the previously ready revision is the rollback target.
+	previousCreatedRevision := d.Get("status_details.0.latest_created_revision").(string)
+	previousReadyRevision := d.Get("status_details.0.latest_ready_revision").(string)
+	expectedRevision := ""
+
 	if hasChange {
 		updateAppOptions.App, _ = patchVals.AsPatch()
 		_, response, err := codeEngineClient.UpdateAppWithContext(context, updateAppOptions)
@@ -880,10 +860,27 @@ func resourceIbmCodeEngineAppUpdate(context context.Context, d *schema.ResourceD
 			log.Printf("[DEBUG] UpdateAppWithContext failed %s\n%s", err, response)
 			return diag.FromErr(fmt.Errorf("UpdateAppWithContext failed %s\n%s", err, response))
 		}
+		// Only changes to the configuration of the revision create a new
+		// one, managed domain mappings are set on the app itself.
+		if d.HasChanges(codeEngineAppRevisionFields...) {
+			expectedRevision = previousCreatedRevision
+		}
 	}
 
-	_, err = waitForIbmCodeEngineAppUpdate(d, meta)
+	_, err = waitForIbmCodeEngineAppReady(context, d, meta, expectedRevision, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
+		if hasChange && d.Get("rollback_on_failure").(bool) && previousReadyRevision != "" {
+			rollbackErr := resourceIbmCodeEngineAppRollback(context, d, meta, previousReadyRevision)
+			if diags := resourceIbmCodeEngineAppRead(context, d, meta); diags.HasError() {
+				return diags
+			}
+			if rollbackErr != nil {
+				return diag.FromErr(fmt.Errorf(
+					"Error waiting for resource IbmCodeEngineApp (%s) to be updated: %s\nError rolling back to revision %s: %s", d.Id(), err, previousReadyRevision, rollbackErr))
+			}
+			return diag.FromErr(fmt.Errorf(
+				"Error waiting for resource IbmCodeEngineApp (%s) to be updated: %s\nThe app was rolled back to the configuration of revision %s", d.Id(), err, previousReadyRevision))
+		}
 		return diag.FromErr(fmt.Errorf(
 			"Error waiting for resource IbmCodeEngineApp (%s) to be updated: %s", d.Id(), err))
 	}
@@ -891,7 +888,34 @@ func resourceIbmCodeEngineAppUpdate(context context.Context, d *schema.ResourceD
 	return resourceIbmCodeEngineAppRead(context, d, meta)
 }
 
-func waitForIbmCodeEngineAppUpdate(d *schema.ResourceData, meta interface{}) (interface{}, error) {
+// codeEngineAppRevisionFields are the arguments that are part of the
+// configuration of an app revision, so that a change creates a new revision.
+var codeEngineAppRevisionFields = []string{
+	"image_reference",
+	"image_port",
+	"image_secret",
+	"run_arguments",
+	"run_as_user",
+	"run_commands",
+	"run_env_variables",
+	"run_service_account",
+	"run_volume_mounts",
+	"scale_concurrency",
+	"scale_concurrency_target",
+	"scale_cpu_limit",
+	"scale_ephemeral_storage_limit",
+	"scale_initial_instances",
+	"scale_max_instances",
+	"scale_memory_limit",
+	"scale_min_instances",
+	"scale_request_timeout",
+}
+
+// waitForIbmCodeEngineAppReady waits until the latest created revision of the
+// app is ready and runs at least ready_instances instances. When
+// previousCreatedRevision is set, that revision and older ones are not
+// accepted, since the update has not been picked up yet.
+func waitForIbmCodeEngineAppReady(context context.Context, d *schema.ResourceData, meta interface{}, previousCreatedRevision string, timeout time.Duration) (interface{}, error) {
 	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
 	if err != nil {
 		return false, err
@@ -906,29 +930,170 @@ func waitForIbmCodeEngineAppUpdate(d *schema.ResourceData, meta interface{}) (in
 	getAppOptions.SetProjectID(parts[0])
 	getAppOptions.SetName(parts[1])
 
+	readyInstances := int64(d.Get("ready_instances").(int))
+	lastReason := ""
+
 	stateConf := &resource.StateChangeConf{
-		Pending: []string{"deploying"},
-		Target:  []string{"ready"},
+		Pending: []string{codeenginev2.App_Status_Deploying},
+		Target:  []string{codeenginev2.App_Status_Ready},
 		Refresh: func() (interface{}, string, error) {
-			stateObj, response, err := codeEngineClient.GetApp(getAppOptions)
+			app, response, err := codeEngineClient.GetAppWithContext(context, getAppOptions)
+			if err != nil {
+				return nil, "", fmt.Errorf("GetAppWithContext failed %s\n%s", err, response)
+			}
+			status := core.StringNilMapper(app.Status)
+			createdRevision, readyRevision := "", ""
+			if app.StatusDetails != nil {
+				createdRevision = core.StringNilMapper(app.StatusDetails.LatestCreatedRevision)
+				readyRevision = core.StringNilMapper(app.StatusDetails.LatestReadyRevision)
+				lastReason = core.StringNilMapper(app.StatusDetails.Reason)
+			}
+			if createdRevision == "" || createdRevision == previousCreatedRevision {
+				return app, codeenginev2.App_Status_Deploying, nil
+			}
+
+			getAppRevisionOptions := &codeenginev2.GetAppRevisionOptions{}
+			getAppRevisionOptions.SetProjectID(parts[0])
+			getAppRevisionOptions.SetAppName(parts[1])
+			getAppRevisionOptions.SetName(createdRevision)
+			revision, response, err := codeEngineClient.GetAppRevisionWithContext(context, getAppRevisionOptions)
 			if err != nil {
-				if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
-					return nil, "", fmt.Errorf("The instance %s does not exist anymore: %s\n%s", "getAppOptions", err, response)
+				return nil, "", fmt.Errorf("GetAppRevisionWithContext failed %s\n%s", err, response)
+			}
+			actualInstances := int64(0)
+			if revision.StatusDetails != nil {
+				if reason := core.StringNilMapper(revision.StatusDetails.Reason); reason != "" {
+					lastReason = reason
 				}
-				return nil, "", err
+				if revision.StatusDetails.ActualInstances != nil {
+					actualInstances = *revision.StatusDetails.ActualInstances
+				}
+			}
+
+			if status == codeenginev2.App_Status_Failed || core.StringNilMapper(revision.Status) == codeenginev2.AppRevision_Status_Failed {
+				return app, codeenginev2.App_Status_Failed, fmt.Errorf("The revision %s of the app failed: %s", createdRevision, lastReason)
+			}
+			// A warning needs the attention of the user, such as an image that
+			// cannot be pulled, and is not resolved by waiting.
+			if status == codeenginev2.App_Status_Warning || core.StringNilMapper(revision.Status) == codeenginev2.AppRevision_Status_Warning {
+				return app, codeenginev2.App_Status_Warning, fmt.Errorf("The revision %s of the app reports a warning: %s", createdRevision, lastReason)
 			}
-			failStates := map[string]bool{"failed": true, "warning": true}
-			if failStates[*stateObj.Status] {
-				return stateObj, *stateObj.Status, fmt.Errorf("The instance %s failed: %s\n%s", "getAppOptions", err, response)
+			if status == codeenginev2.App_Status_Ready && readyRevision == createdRevision && actualInstances >= readyInstances {
+				return app, codeenginev2.App_Status_Ready, nil
 			}
-			return stateObj, *stateObj.Status, nil
+			return app, codeenginev2.App_Status_Deploying, nil
 		},
-		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Timeout:    timeout,
 		Delay:      20 * time.Second,
 		MinTimeout: 20 * time.Second,
 	}
 
-	return stateConf.WaitForState()
+	app, err := stateConf.WaitForStateContext(context)
+	if _, ok := err.(*resource.TimeoutError); ok && lastReason != "" {
+		return app, fmt.Errorf("%s: the latest revision of the app is not ready: %s", err, lastReason)
+	}
+	return app, err
+}
+
+// resourceIbmCodeEngineAppRollback restores the configuration of the given
+// revision on the app and waits until the resulting revision is ready.
+func resourceIbmCodeEngineAppRollback(context context.Context, d *schema.ResourceData, meta interface{}, revisionName string) error {
+	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return err
+	}
+
+	parts, err := flex.SepIdParts(d.Id(), "/")
+	if err != nil {
+		return err
+	}
+
+	getAppRevisionOptions := &codeenginev2.GetAppRevisionOptions{}
+	getAppRevisionOptions.SetProjectID(parts[0])
+	getAppRevisionOptions.SetAppName(parts[1])
+	getAppRevisionOptions.SetName(revisionName)
+	revision, response, err := codeEngineClient.GetAppRevisionWithContext(context, getAppRevisionOptions)
+	if err != nil {
+		return fmt.Errorf("GetAppRevisionWithContext failed %s\n%s", err, response)
+	}
+
+	getAppOptions := &codeenginev2.GetAppOptions{}
+	getAppOptions.SetProjectID(parts[0])
+	getAppOptions.SetName(parts[1])
+	app, response, err := codeEngineClient.GetAppWithContext(context, getAppOptions)
+	if err != nil {
+		return fmt.Errorf("GetAppWithContext failed %s\n%s", err, response)
+	}
+	createdRevision := ""
+	if app.StatusDetails != nil {
+		createdRevision = core.StringNilMapper(app.StatusDetails.LatestCreatedRevision)
+	}
+
+	log.Printf("[INFO] Rolling back app %s to the configuration of revision %s", d.Id(), revisionName)
+
+	updateAppOptions := &codeenginev2.UpdateAppOptions{}
+	updateAppOptions.SetProjectID(parts[0])
+	updateAppOptions.SetName(parts[1])
+	updateAppOptions.SetIfMatch(response.Headers.Get("Etag"))
+	updateAppOptions.SetApp(resourceIbmCodeEngineAppRevisionToPatch(revision))
+	_, response, err = codeEngineClient.UpdateAppWithContext(context, updateAppOptions)
+	if err != nil {
+		return fmt.Errorf("UpdateAppWithContext failed %s\n%s", err, response)
+	}
+
+	_, err = waitForIbmCodeEngineAppReady(context, d, meta, createdRevision, d.Timeout(schema.TimeoutUpdate))
+	return err
+}
+
+// resourceIbmCodeEngineAppRevisionToPatch builds an app patch that restores
+// the configuration of a revision. List properties are always sent, so that
+// values added by the failed update are removed again.
+func resourceIbmCodeEngineAppRevisionToPatch(revision *codeenginev2.AppRevision) map[string]interface{} {
+	patchVals := &codeenginev2.AppPatch{
+		ImagePort:                  revision.ImagePort,
+		ImageReference:             revision.ImageReference,
+		ImageSecret:                revision.ImageSecret,
+		RunAsUser:                  revision.RunAsUser,
+		RunServiceAccount:          revision.RunServiceAccount,
+		ScaleConcurrency:           revision.ScaleConcurrency,
+		ScaleConcurrencyTarget:     revision.ScaleConcurrencyTarget,
+		ScaleCpuLimit:              revision.ScaleCpuLimit,
+		ScaleDownDelay:             revision.ScaleDownDelay,
+		ScaleEphemeralStorageLimit: revision.ScaleEphemeralStorageLimit,
+		ScaleInitialInstances:      revision.ScaleInitialInstances,
+		ScaleMaxInstances:          revision.ScaleMaxInstances,
+		ScaleMemoryLimit:           revision.ScaleMemoryLimit,
+		ScaleMinInstances:          revision.ScaleMinInstances,
+		ScaleRequestTimeout:        revision.ScaleRequestTimeout,
+	}
+	patch, _ := patchVals.AsPatch()
+
+	runEnvVariables := []codeenginev2.EnvVarPrototype{}
+	for _, envVar := range revision.RunEnvVariables {
+		runEnvVariables = append(runEnvVariables, codeenginev2.EnvVarPrototype{
+			Key:       envVar.Key,
+			Name:      envVar.Name,
+			Prefix:    envVar.Prefix,
+			Reference: envVar.Reference,
+			Type:      envVar.Type,
+			Value:     envVar.Value,
+		})
+	}
+	runVolumeMounts := []codeenginev2.VolumeMountPrototype{}
+	for _, volumeMount := range revision.RunVolumeMounts {
+		runVolumeMounts = append(runVolumeMounts, codeenginev2.VolumeMountPrototype{
+			MountPath: volumeMount.MountPath,
+			Name:      volumeMount.Name,
+			Reference: volumeMount.Reference,
+			Type:      volumeMount.Type,
+		})
+	}
+	patch["run_arguments"] = append([]string{}, revision.RunArguments...)
+	patch["run_commands"] = append([]string{}, revision.RunCommands...)
+	patch["run_env_variables"] = runEnvVariables
+	patch["run_volume_mounts"] = runVolumeMounts
+
+	return patch
 }
 
 func resourceIbmCodeEngineAppDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -5,6 +5,7 @@ package codeengine_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -81,6 +82,40 @@ func TestAccIbmCodeEngineAppBasic(t *testing.T) {
 	})
 }
 
+func TestAccIbmCodeEngineAppRollback(t *testing.T) {
+	var conf codeenginev2.App
+	name := fmt.Sprintf("tf-app-rollback-%d", acctest.RandIntRange(10, 1000))
+	imageReference := "icr.io/codeengine/helloworld"
+	imageReferenceBroken := "icr.io/codeengine/helloworld:does-not-exist"
+
+	projectID := acc.CeProjectId
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIbmCodeEngineAppDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIbmCodeEngineAppConfigRollback(projectID, imageReference, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIbmCodeEngineAppExists("ibm_code_engine_app.code_engine_app_instance", conf),
+					resource.TestCheckResourceAttr("ibm_code_engine_app.code_engine_app_instance", "status", "ready"),
+					resource.TestCheckResourceAttr("ibm_code_engine_app.code_engine_app_instance", "ready_instances", "1"),
+					resource.TestCheckResourceAttr("ibm_code_engine_app.code_engine_app_instance", "rollback_on_failure", "true"),
+				),
+			},
+			resource.TestStep{
+				Config:      testAccCheckIbmCodeEngineAppConfigRollback(projectID, imageReferenceBroken, name),
+				ExpectError: regexp.MustCompile("rolled back to the configuration of revision"),
+			},
+			resource.TestStep{
+				Config:   testAccCheckIbmCodeEngineAppConfigRollback(projectID, imageReference, name),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccIbmCodeEngineAppExtended(t *testing.T) {
 	var conf codeenginev2.App
 	name := fmt.Sprintf("tf-app-extended-%d", acctest.RandIntRange(10, 1000))
@@ -196,6 +231,33 @@ func testAccCheckIbmCodeEngineAppConfigBasic(projectID string, imageReference st
 	`, projectID, imageReference, name)
 }
 
+func testAccCheckIbmCodeEngineAppConfigRollback(projectID string, imageReference string, name string) string {
+	return fmt.Sprintf(`
+		data "ibm_code_engine_project" "code_engine_project_instance" {
+			project_id = "%s"
+		}
+
+		resource "ibm_code_engine_app" "code_engine_app_instance" {
+			project_id = data.ibm_code_engine_project.code_engine_project_instance.project_id
+			image_reference = "%s"
+			name = "%s"
+			scale_min_instances = 1
+			ready_instances = 1
+			rollback_on_failure = true
+
+			timeouts {
+				update = "5m"
+			}
+
+			lifecycle {
+				ignore_changes = [
+					run_env_variables
+				]
+			}
+		}
+	`, projectID, imageReference, name)
+}
+
 func testAccCheckIbmCodeEngineAppConfig(projectID string, configMapName string, configMapData string, imageReference string, name string, imagePort string, managedDomainMappings string, runAsUser string, runServiceAccount string, scaleConcurrency string, scaleConcurrencyTarget string, scaleCpuLimit string, scaleEphemeralStorageLimit string, scaleInitialInstances string, scaleMaxInstances string, scaleMemoryLimit string, scaleMinInstances string, scaleRequestTimeout string) string {
 	return fmt.Sprintf(`
 		data "ibm_code_engine_project" "code_engine_project_instance" {
@@ -0,0 +1,384 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package codeengine
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/code-engine-go-sdk/codeenginev2"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+func ResourceIbmCodeEngineBuildRun() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIbmCodeEngineBuildRunCreate,
+		ReadContext:   resourceIbmCodeEngineBuildRunRead,
+		UpdateContext: resourceIbmCodeEngineBuildRunUpdate,
+		DeleteContext: resourceIbmCodeEngineBuildRunDelete,
+		Importer:      &schema.ResourceImporter{},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.InvokeValidator("ibm_code_engine_build_run", "project_id"),
+				Description:  "The ID of the project.",
+			},
+			"build_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.InvokeValidator("ibm_code_engine_build_run", "build_name"),
+				Description:  "The name of the build that is run.",
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.InvokeValidator("ibm_code_engine_build_run", "name"),
+				Description:  "The name of the build run. If omitted, a name is generated by Code Engine.",
+			},
+			"source_revision": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Commit, tag, or branch in the source repository to pull, overriding the revision of the build. A change triggers a new build run.",
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that trigger a new build run when they change, for example the commit of the source code.",
+			},
+			"timeout": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The maximum amount of time, in seconds, that can pass before the build run must succeed or fail.",
+			},
+			"wait_for_completion": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to wait until the build run succeeds. A failed build run is reported as an error.",
+			},
+			"build_run_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The identifier of the resource.",
+			},
+			"output_image": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the image that is built.",
+			},
+			"output_digest": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The digest of the image that is built, to reference the image immutably as `output_image@output_digest`.",
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The current status of the build run.",
+			},
+			"status_details": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Current status condition of a build run.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"completion_time": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time the build run completed.",
+						},
+						"reason": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Optional information to provide more context in case of a 'failed' or 'warning' status.",
+						},
+						"start_time": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time the build run started.",
+						},
+					},
+				},
+			},
+			"created_at": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The timestamp when the resource was created.",
+			},
+			"href": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When you provision a new resource, a URL is created identifying the location of the instance.",
+			},
+		},
+	}
+}
+
+func ResourceIbmCodeEngineBuildRunValidator() *validate.ResourceValidator {
+	validateSchema := make([]validate.ValidateSchema, 0)
+	validateSchema = append(validateSchema,
+		validate.ValidateSchema{
+			Identifier:                 "project_id",
+			ValidateFunctionIdentifier: validate.ValidateRegexpLen,
+			Type:                       validate.TypeString,
+			Required:                   true,
+			Regexp:                     `^[0-9a-z]{8}-[0-9a-z]{4}-[0-9a-z]{4}-[0-9a-z]{4}-[0-9a-z]{12}$`,
+			MinValueLength:             36,
+			MaxValueLength:             36,
+		},
+		validate.ValidateSchema{
+			Identifier:                 "build_name",
+			ValidateFunctionIdentifier: validate.ValidateRegexpLen,
+			Type:                       validate.TypeString,
+			Required:                   true,
+			Regexp:                     `^[a-z0-9]([\-a-z0-9]*[a-z0-9])?$`,
+			MinValueLength:             1,
+			MaxValueLength:             63,
+		},
+		validate.ValidateSchema{
+			Identifier:                 "name",
+			ValidateFunctionIdentifier: validate.ValidateRegexpLen,
+			Type:                       validate.TypeString,
+			Optional:                   true,
+			Regexp:                     `^[a-z0-9]([\-a-z0-9]*[a-z0-9])?$`,
+			MinValueLength:             1,
+			MaxValueLength:             63,
+		},
+	)
+
+	resourceValidator := validate.ResourceValidator{ResourceName: "ibm_code_engine_build_run", Schema: validateSchema}
+	return &resourceValidator
+}
+
+func resourceIbmCodeEngineBuildRunCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	createBuildRunOptions := &codeenginev2.CreateBuildRunOptions{}
+
+	createBuildRunOptions.SetProjectID(d.Get("project_id").(string))
+	createBuildRunOptions.SetBuildName(d.Get("build_name").(string))
+	if _, ok := d.GetOk("name"); ok {
+		createBuildRunOptions.SetName(d.Get("name").(string))
+	}
+	if _, ok := d.GetOk("source_revision"); ok {
+		createBuildRunOptions.SetSourceRevision(d.Get("source_revision").(string))
+	}
+	if _, ok := d.GetOk("timeout"); ok {
+		createBuildRunOptions.SetTimeout(int64(d.Get("timeout").(int)))
+	}
+
+	buildRun, response, err := codeEngineClient.CreateBuildRunWithContext(context, createBuildRunOptions)
+	if err != nil {
+		log.Printf("[DEBUG] CreateBuildRunWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("CreateBuildRunWithContext failed %s\n%s", err, response))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", *createBuildRunOptions.ProjectID, *buildRun.Name))
+
+	if d.Get("wait_for_completion").(bool) {
+		_, err = waitForIbmCodeEngineBuildRunCompletion(d, meta)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf(
+				"Error waiting for resource IbmCodeEngineBuildRun (%s) to succeed: %s", d.Id(), err))
+		}
+	}
+
+	return resourceIbmCodeEngineBuildRunRead(context, d, meta)
+}
+
+func waitForIbmCodeEngineBuildRunCompletion(d *schema.ResourceData, meta interface{}) (interface{}, error) {
+	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return false, err
+	}
+	getBuildRunOptions := &codeenginev2.GetBuildRunOptions{}
+
+	parts, err := flex.SepIdParts(d.Id(), "/")
+	if err != nil {
+		return false, err
+	}
+
+	getBuildRunOptions.SetProjectID(parts[0])
+	getBuildRunOptions.SetName(parts[1])
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{codeenginev2.BuildRun_Status_Pending, codeenginev2.BuildRun_Status_Running},
+		Target:  []string{codeenginev2.BuildRun_Status_Succeeded},
+		Refresh: func() (interface{}, string, error) {
+			stateObj, response, err := codeEngineClient.GetBuildRun(getBuildRunOptions)
+			if err != nil {
+				return nil, "", fmt.Errorf("GetBuildRun failed %s\n%s", err, response)
+			}
+			if *stateObj.Status == codeenginev2.BuildRun_Status_Failed {
+				reason := ""
+				if stateObj.StatusDetails != nil && stateObj.StatusDetails.Reason != nil {
+					reason = *stateObj.StatusDetails.Reason
+				}
+				return stateObj, *stateObj.Status, fmt.Errorf("The build run %s failed: %s", *stateObj.Name, reason)
+			}
+			return stateObj, *stateObj.Status, nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
+
+func resourceIbmCodeEngineBuildRunRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	getBuildRunOptions := &codeenginev2.GetBuildRunOptions{}
+
+	parts, err := flex.SepIdParts(d.Id(), "/")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	getBuildRunOptions.SetProjectID(parts[0])
+	getBuildRunOptions.SetName(parts[1])
+
+	buildRun, response, err := codeEngineClient.GetBuildRunWithContext(context, getBuildRunOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			// Code Engine prunes old build runs. The run still happened and its
+			// image still exists, so keep the last known state instead of
+			// triggering a new build on the next apply.
+			log.Printf("[WARN] Build run %s no longer exists in Code Engine, keeping its last known state", d.Id())
+			return nil
+		}
+		log.Printf("[DEBUG] GetBuildRunWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("GetBuildRunWithContext failed %s\n%s", err, response))
+	}
+
+	if err = d.Set("project_id", buildRun.ProjectID); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting project_id: %s", err))
+	}
+	if err = d.Set("build_name", buildRun.BuildName); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting build_name: %s", err))
+	}
+	if err = d.Set("name", buildRun.Name); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting name: %s", err))
+	}
+	if !core.IsNil(buildRun.Timeout) {
+		if err = d.Set("timeout", flex.IntValue(buildRun.Timeout)); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting timeout: %s", err))
+		}
+	}
+	if !core.IsNil(buildRun.ID) {
+		if err = d.Set("build_run_id", buildRun.ID); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting build_run_id: %s", err))
+		}
+	}
+	if !core.IsNil(buildRun.OutputImage) {
+		if err = d.Set("output_image", buildRun.OutputImage); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting output_image: %s", err))
+		}
+	}
+	if !core.IsNil(buildRun.Status) {
+		if err = d.Set("status", buildRun.Status); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting status: %s", err))
+		}
+	}
+	if !core.IsNil(buildRun.StatusDetails) {
+		if err = d.Set("output_digest", buildRun.StatusDetails.OutputDigest); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting output_digest: %s", err))
+		}
+		statusDetailsMap, err := resourceIbmCodeEngineBuildRunBuildRunStatusToMap(buildRun.StatusDetails)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err = d.Set("status_details", []map[string]interface{}{statusDetailsMap}); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting status_details: %s", err))
+		}
+	}
+	if !core.IsNil(buildRun.CreatedAt) {
+		if err = d.Set("created_at", buildRun.CreatedAt); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting created_at: %s", err))
+		}
+	}
+	if !core.IsNil(buildRun.Href) {
+		if err = d.Set("href", buildRun.Href); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting href: %s", err))
+		}
+	}
+
+	return nil
+}
+
+func resourceIbmCodeEngineBuildRunUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Every argument that changes the build run forces a new one; only
+	// wait_for_completion can change in place and it needs no API call.
+	return resourceIbmCodeEngineBuildRunRead(context, d, meta)
+}
+
+func resourceIbmCodeEngineBuildRunDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deleteBuildRunOptions := &codeenginev2.DeleteBuildRunOptions{}
+
+	parts, err := flex.SepIdParts(d.Id(), "/")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deleteBuildRunOptions.SetProjectID(parts[0])
+	deleteBuildRunOptions.SetName(parts[1])
+
+	response, err := codeEngineClient.DeleteBuildRunWithContext(context, deleteBuildRunOptions)
+	if err != nil && (response == nil || response.StatusCode != 404) {
+		log.Printf("[DEBUG] DeleteBuildRunWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("DeleteBuildRunWithContext failed %s\n%s", err, response))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceIbmCodeEngineBuildRunBuildRunStatusToMap(model *codeenginev2.BuildRunStatus) (map[string]interface{}, error) {
+	modelMap := make(map[string]interface{})
+	if model.CompletionTime != nil {
+		modelMap["completion_time"] = model.CompletionTime
+	}
+	if model.Reason != nil {
+		modelMap["reason"] = model.Reason
+	}
+	if model.StartTime != nil {
+		modelMap["start_time"] = model.StartTime
+	}
+	return modelMap, nil
+}
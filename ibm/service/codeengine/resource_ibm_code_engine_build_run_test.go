@@ -0,0 +1,147 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package codeengine_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/code-engine-go-sdk/codeenginev2"
+)
+
+func TestAccIbmCodeEngineBuildRunBasic(t *testing.T) {
+	var conf codeenginev2.BuildRun
+	buildName := fmt.Sprintf("tf-build-run-%d", acctest.RandIntRange(10, 1000))
+	outputImage := fmt.Sprintf("private.us.icr.io/ce-terraform-test/%s", buildName)
+	outputSecret := "ce-terraform-test"
+	sourceURL := "https://github.com/IBM/CodeEngine"
+
+	projectID := acc.CeProjectId
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIbmCodeEngineBuildRunDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIbmCodeEngineBuildRunConfigBasic(projectID, buildName, outputImage, outputSecret, sourceURL, "v1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIbmCodeEngineBuildRunExists("ibm_code_engine_build_run.code_engine_build_run_instance", conf),
+					resource.TestCheckResourceAttrSet("ibm_code_engine_build_run.code_engine_build_run_instance", "build_run_id"),
+					resource.TestCheckResourceAttr("ibm_code_engine_build_run.code_engine_build_run_instance", "project_id", projectID),
+					resource.TestCheckResourceAttr("ibm_code_engine_build_run.code_engine_build_run_instance", "build_name", buildName),
+					resource.TestCheckResourceAttr("ibm_code_engine_build_run.code_engine_build_run_instance", "status", "succeeded"),
+					resource.TestCheckResourceAttr("ibm_code_engine_build_run.code_engine_build_run_instance", "output_image", outputImage),
+					resource.TestCheckResourceAttrSet("ibm_code_engine_build_run.code_engine_build_run_instance", "output_digest"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIbmCodeEngineBuildRunConfigBasic(projectID, buildName, outputImage, outputSecret, sourceURL, "v2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_code_engine_build_run.code_engine_build_run_instance", "triggers.version", "v2"),
+					resource.TestCheckResourceAttr("ibm_code_engine_build_run.code_engine_build_run_instance", "status", "succeeded"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIbmCodeEngineBuildRunConfigBasic(projectID string, buildName string, outputImage string, outputSecret string, sourceURL string, version string) string {
+	return fmt.Sprintf(`
+		data "ibm_code_engine_project" "code_engine_project_instance" {
+			project_id = "%s"
+		}
+
+		resource "ibm_code_engine_build" "code_engine_build_instance" {
+			project_id = data.ibm_code_engine_project.code_engine_project_instance.project_id
+			name = "%s"
+			output_image = "%s"
+			output_secret = "%s"
+			source_url = "%s"
+			source_context_dir = "helloworld"
+			strategy_type = "dockerfile"
+		}
+
+		resource "ibm_code_engine_build_run" "code_engine_build_run_instance" {
+			project_id = data.ibm_code_engine_project.code_engine_project_instance.project_id
+			build_name = ibm_code_engine_build.code_engine_build_instance.name
+			triggers = {
+				version = "%s"
+			}
+		}
+	`, projectID, buildName, outputImage, outputSecret, sourceURL, version)
+}
+
+func testAccCheckIbmCodeEngineBuildRunExists(n string, obj codeenginev2.BuildRun) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		codeEngineClient, err := acc.TestAccProvider.Meta().(conns.ClientSession).CodeEngineV2()
+		if err != nil {
+			return err
+		}
+
+		getBuildRunOptions := &codeenginev2.GetBuildRunOptions{}
+
+		parts, err := flex.SepIdParts(rs.Primary.ID, "/")
+		if err != nil {
+			return err
+		}
+
+		getBuildRunOptions.SetProjectID(parts[0])
+		getBuildRunOptions.SetName(parts[1])
+
+		buildRun, _, err := codeEngineClient.GetBuildRun(getBuildRunOptions)
+		if err != nil {
+			return err
+		}
+
+		obj = *buildRun
+		return nil
+	}
+}
+
+func testAccCheckIbmCodeEngineBuildRunDestroy(s *terraform.State) error {
+	codeEngineClient, err := acc.TestAccProvider.Meta().(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_code_engine_build_run" {
+			continue
+		}
+
+		getBuildRunOptions := &codeenginev2.GetBuildRunOptions{}
+
+		parts, err := flex.SepIdParts(rs.Primary.ID, "/")
+		if err != nil {
+			return err
+		}
+
+		getBuildRunOptions.SetProjectID(parts[0])
+		getBuildRunOptions.SetName(parts[1])
+
+		// Try to find the key
+		_, response, err := codeEngineClient.GetBuildRun(getBuildRunOptions)
+
+		if err == nil {
+			return fmt.Errorf("code_engine_build_run still exists: %s", rs.Primary.ID)
+		} else if response.StatusCode != 404 {
+			return fmt.Errorf("Error checking for code_engine_build_run (%s) has been destroyed: %s", rs.Primary.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,691 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package codeengine
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/secretsmanager"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/code-engine-go-sdk/codeenginev2"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/secrets-manager-go-sdk/v2/secretsmanagerv2"
+)
+
+const (
+	codeEngineDomainMappingStatusReady     = "ready"
+	codeEngineDomainMappingStatusDeploying = "deploying"
+	codeEngineDomainMappingStatusFailed    = "failed"
+)
+
+// The vendored Code Engine SDK predates the domain mapping API, so the
+// requests below are built directly against the client's service endpoint.
+
+type codeEngineDomainMappingComponent struct {
+	Name         *string `json:"name,omitempty"`
+	ResourceType *string `json:"resource_type,omitempty"`
+}
+
+type codeEngineDomainMappingStatusDetails struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+type codeEngineDomainMapping struct {
+	CnameTarget   *string                               `json:"cname_target,omitempty"`
+	Component     *codeEngineDomainMappingComponent     `json:"component,omitempty"`
+	CreatedAt     *string                               `json:"created_at,omitempty"`
+	EntityTag     *string                               `json:"entity_tag,omitempty"`
+	Href          *string                               `json:"href,omitempty"`
+	ID            *string                               `json:"id,omitempty"`
+	Name          *string                               `json:"name,omitempty"`
+	ProjectID     *string                               `json:"project_id,omitempty"`
+	ResourceType  *string                               `json:"resource_type,omitempty"`
+	Status        *string                               `json:"status,omitempty"`
+	StatusDetails *codeEngineDomainMappingStatusDetails `json:"status_details,omitempty"`
+	TlsSecret     *string                               `json:"tls_secret,omitempty"`
+	UserManaged   *bool                                 `json:"user_managed,omitempty"`
+	Visibility    *string                               `json:"visibility,omitempty"`
+}
+
+func ResourceIbmCodeEngineDomainMapping() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIbmCodeEngineDomainMappingCreate,
+		ReadContext:   resourceIbmCodeEngineDomainMappingRead,
+		UpdateContext: resourceIbmCodeEngineDomainMappingUpdate,
+		DeleteContext: resourceIbmCodeEngineDomainMappingDelete,
+		Importer:      &schema.ResourceImporter{},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+		CustomizeDiff: resourceIbmCodeEngineDomainMappingCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.InvokeValidator("ibm_code_engine_domain_mapping", "project_id"),
+				Description:  "The ID of the project.",
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.InvokeValidator("ibm_code_engine_domain_mapping", "name"),
+				Description:  "The name of the domain mapping, which is the custom domain that is mapped to the component.",
+			},
+			"component": &schema.Schema{
+				Type:        schema.TypeList,
+				MinItems:    1,
+				MaxItems:    1,
+				Required:    true,
+				Description: "A reference to another component.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the referenced component.",
+						},
+						"resource_type": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The type of the referenced resource.",
+						},
+					},
+				},
+			},
+			"tls_secret": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"tls_secret", "secrets_manager_certificate"},
+				ValidateFunc: validate.InvokeValidator("ibm_code_engine_domain_mapping", "tls_secret"),
+				Description:  "The name of the TLS secret that holds the certificate and private key of the custom domain. The secret must be of format `tls`.",
+			},
+			"secrets_manager_certificate": &schema.Schema{
+				Type:         schema.TypeList,
+				MaxItems:     1,
+				Optional:     true,
+				ExactlyOneOf: []string{"tls_secret", "secrets_manager_certificate"},
+				Description:  "A Secrets Manager certificate that is copied into a TLS secret of the project, which is named after the domain mapping and used by it.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the Secrets Manager instance.",
+						},
+						"secret_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the public, imported or private certificate.",
+						},
+						"region": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The region of the Secrets Manager instance. Defaults to the region of the provider.",
+						},
+						"endpoint_type": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private"}),
+							Description:  "The endpoint of the Secrets Manager instance, public or private. Defaults to the endpoint type of the provider.",
+						},
+					},
+				},
+			},
+			"certificate_versions_total": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of versions of the Secrets Manager certificate when it was last copied into the TLS secret.",
+			},
+			"tls_secret_created": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the TLS secret was created by the resource from the Secrets Manager certificate, in which case it is deleted with it.",
+			},
+			"cname_target": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The value of the CNAME record that must be configured in the DNS settings of the domain.",
+			},
+			"created_at": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The timestamp when the resource was created.",
+			},
+			"entity_tag": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the domain mapping instance, which is used to achieve optimistic locking.",
+			},
+			"href": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When you provision a new domain mapping, a URL is created identifying the location of the instance.",
+			},
+			"domain_mapping_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The identifier of the resource.",
+			},
+			"resource_type": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the Code Engine resource.",
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The current status of the domain mapping.",
+			},
+			"status_details": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The detailed status of the domain mapping.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"reason": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Optional information to provide more context in case of a 'failed' or 'warning' status.",
+						},
+					},
+				},
+			},
+			"user_managed": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Specifies whether the domain mapping is managed by the user or by Code Engine.",
+			},
+			"visibility": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Specifies whether the domain mapping is reachable through the public internet, or private IBM network, or only through other components within the same Code Engine project.",
+			},
+			"etag": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func ResourceIbmCodeEngineDomainMappingValidator() *validate.ResourceValidator {
+	validateSchema := make([]validate.ValidateSchema, 0)
+	validateSchema = append(validateSchema,
+		validate.ValidateSchema{
+			Identifier:                 "project_id",
+			ValidateFunctionIdentifier: validate.ValidateRegexpLen,
+			Type:                       validate.TypeString,
+			Required:                   true,
+			Regexp:                     `^[0-9a-z]{8}-[0-9a-z]{4}-[0-9a-z]{4}-[0-9a-z]{4}-[0-9a-z]{12}$`,
+			MinValueLength:             36,
+			MaxValueLength:             36,
+		},
+		validate.ValidateSchema{
+			Identifier:                 "name",
+			ValidateFunctionIdentifier: validate.ValidateRegexpLen,
+			Type:                       validate.TypeString,
+			Required:                   true,
+			Regexp:                     `^([a-z0-9]([\-a-z0-9]*[a-z0-9])?)+(\.([a-z0-9]([\-a-z0-9]*[a-z0-9])?))+$`,
+			MinValueLength:             1,
+			MaxValueLength:             253,
+		},
+		validate.ValidateSchema{
+			Identifier:                 "tls_secret",
+			ValidateFunctionIdentifier: validate.ValidateRegexpLen,
+			Type:                       validate.TypeString,
+			Optional:                   true,
+			Regexp:                     `^[a-z0-9]([\-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([\-a-z0-9]*[a-z0-9])?)*$`,
+			MinValueLength:             1,
+			MaxValueLength:             253,
+		},
+	)
+
+	resourceValidator := validate.ResourceValidator{ResourceName: "ibm_code_engine_domain_mapping", Schema: validateSchema}
+	return &resourceValidator
+}
+
+// codeEngineDomainMappingRequest sends a domain mapping request to the Code
+// Engine API. The decoded domain mapping is nil for responses without a body.
+func codeEngineDomainMappingRequest(context context.Context, codeEngineClient *codeenginev2.CodeEngineV2, method, path string, pathParams map[string]string, body interface{}, ifMatch string) (*codeEngineDomainMapping, *core.DetailedResponse, error) {
+	builder := core.NewRequestBuilder(method)
+	builder = builder.WithContext(context)
+	builder.EnableGzipCompression = codeEngineClient.GetEnableGzipCompression()
+	_, err := builder.ResolveRequestURL(codeEngineClient.Service.Options.URL, path, pathParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	builder.AddHeader("Accept", "application/json")
+	if ifMatch != "" {
+		builder.AddHeader("If-Match", ifMatch)
+	}
+	if body != nil {
+		contentType := "application/json"
+		if method == core.PATCH {
+			contentType = "application/merge-patch+json"
+		}
+		builder.AddHeader("Content-Type", contentType)
+		if _, err = builder.SetBodyContentJSON(body); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	request, err := builder.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var domainMapping *codeEngineDomainMapping
+	response, err := codeEngineClient.Service.Request(request, &domainMapping)
+	return domainMapping, response, err
+}
+
+func resourceIbmCodeEngineDomainMappingCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	projectID := d.Get("project_id").(string)
+	tlsSecret := d.Get("tls_secret").(string)
+	if certificate, ok := d.GetOk("secrets_manager_certificate"); ok {
+		tlsSecret = d.Get("name").(string)
+		versionsTotal, err := syncCodeEngineDomainMappingTLSSecret(context, codeEngineClient, meta, certificate.([]interface{})[0].(map[string]interface{}), projectID, tlsSecret, false)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err = d.Set("tls_secret_created", true); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting tls_secret_created: %s", err))
+		}
+		if err = d.Set("certificate_versions_total", versionsTotal); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting certificate_versions_total: %s", err))
+		}
+	}
+
+	body := &codeEngineDomainMapping{
+		Name:      core.StringPtr(d.Get("name").(string)),
+		Component: resourceIbmCodeEngineDomainMappingMapToComponentRef(d.Get("component.0").(map[string]interface{})),
+		TlsSecret: core.StringPtr(tlsSecret),
+	}
+
+	domainMapping, response, err := codeEngineDomainMappingRequest(context, codeEngineClient, core.POST,
+		`/projects/{project_id}/domain_mappings`, map[string]string{"project_id": projectID}, body, "")
+	if err != nil {
+		log.Printf("[DEBUG] POST /projects/{project_id}/domain_mappings failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("POST /projects/{project_id}/domain_mappings failed %s\n%s", err, response))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", projectID, *domainMapping.Name))
+
+	_, err = waitForIbmCodeEngineDomainMappingReady(context, d, meta, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(
+			"Error waiting for resource IbmCodeEngineDomainMapping (%s) to be created: %s", d.Id(), err))
+	}
+
+	return resourceIbmCodeEngineDomainMappingRead(context, d, meta)
+}
+
+func waitForIbmCodeEngineDomainMappingReady(context context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) (interface{}, error) {
+	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return false, err
+	}
+
+	parts, err := flex.SepIdParts(d.Id(), "/")
+	if err != nil {
+		return false, err
+	}
+	pathParams := map[string]string{"project_id": parts[0], "name": parts[1]}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{codeEngineDomainMappingStatusDeploying},
+		Target:  []string{codeEngineDomainMappingStatusReady},
+		Refresh: func() (interface{}, string, error) {
+			stateObj, response, err := codeEngineDomainMappingRequest(context, codeEngineClient, core.GET,
+				`/projects/{project_id}/domain_mappings/{name}`, pathParams, nil, "")
+			if err != nil {
+				return nil, "", fmt.Errorf("GET /projects/{project_id}/domain_mappings/{name} failed %s\n%s", err, response)
+			}
+			status := core.StringNilMapper(stateObj.Status)
+			if status == codeEngineDomainMappingStatusFailed {
+				reason := ""
+				if stateObj.StatusDetails != nil {
+					reason = core.StringNilMapper(stateObj.StatusDetails.Reason)
+				}
+				return stateObj, status, fmt.Errorf("The domain mapping %s failed: %s", parts[1], reason)
+			}
+			return stateObj, status, nil
+		},
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	return stateConf.WaitForStateContext(context)
+}
+
+func resourceIbmCodeEngineDomainMappingRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	parts, err := flex.SepIdParts(d.Id(), "/")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	domainMapping, response, err := codeEngineDomainMappingRequest(context, codeEngineClient, core.GET,
+		`/projects/{project_id}/domain_mappings/{name}`, map[string]string{"project_id": parts[0], "name": parts[1]}, nil, "")
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] GET /projects/{project_id}/domain_mappings/{name} failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("GET /projects/{project_id}/domain_mappings/{name} failed %s\n%s", err, response))
+	}
+
+	if err = d.Set("project_id", parts[0]); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting project_id: %s", err))
+	}
+	if err = d.Set("name", domainMapping.Name); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting name: %s", err))
+	}
+	if !core.IsNil(domainMapping.Component) {
+		if err = d.Set("component", []map[string]interface{}{resourceIbmCodeEngineDomainMappingComponentRefToMap(domainMapping.Component)}); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting component: %s", err))
+		}
+	}
+	if err = d.Set("tls_secret", domainMapping.TlsSecret); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting tls_secret: %s", err))
+	}
+	if !core.IsNil(domainMapping.CnameTarget) {
+		if err = d.Set("cname_target", domainMapping.CnameTarget); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting cname_target: %s", err))
+		}
+	}
+	if !core.IsNil(domainMapping.CreatedAt) {
+		if err = d.Set("created_at", domainMapping.CreatedAt); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting created_at: %s", err))
+		}
+	}
+	if err = d.Set("entity_tag", domainMapping.EntityTag); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting entity_tag: %s", err))
+	}
+	if !core.IsNil(domainMapping.Href) {
+		if err = d.Set("href", domainMapping.Href); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting href: %s", err))
+		}
+	}
+	if !core.IsNil(domainMapping.ID) {
+		if err = d.Set("domain_mapping_id", domainMapping.ID); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting domain_mapping_id: %s", err))
+		}
+	}
+	if !core.IsNil(domainMapping.ResourceType) {
+		if err = d.Set("resource_type", domainMapping.ResourceType); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting resource_type: %s", err))
+		}
+	}
+	if !core.IsNil(domainMapping.Status) {
+		if err = d.Set("status", domainMapping.Status); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting status: %s", err))
+		}
+	}
+	if !core.IsNil(domainMapping.StatusDetails) {
+		statusDetailsMap := map[string]interface{}{}
+		if domainMapping.StatusDetails.Reason != nil {
+			statusDetailsMap["reason"] = domainMapping.StatusDetails.Reason
+		}
+		if err = d.Set("status_details", []map[string]interface{}{statusDetailsMap}); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting status_details: %s", err))
+		}
+	}
+	if !core.IsNil(domainMapping.UserManaged) {
+		if err = d.Set("user_managed", domainMapping.UserManaged); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting user_managed: %s", err))
+		}
+	}
+	if !core.IsNil(domainMapping.Visibility) {
+		if err = d.Set("visibility", domainMapping.Visibility); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting visibility: %s", err))
+		}
+	}
+	if err = d.Set("etag", response.Headers.Get("Etag")); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting etag: %s", err))
+	}
+
+	return nil
+}
+
+func resourceIbmCodeEngineDomainMappingUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	parts, err := flex.SepIdParts(d.Id(), "/")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hasChange := false
+	patch := &codeEngineDomainMapping{}
+
+	// The certificate is copied again on every update, which also picks up a
+	// certificate that Secrets Manager rotated
+	certificate, hasCertificate := d.GetOk("secrets_manager_certificate")
+	if hasCertificate {
+		versionsTotal, err := syncCodeEngineDomainMappingTLSSecret(context, codeEngineClient, meta, certificate.([]interface{})[0].(map[string]interface{}), parts[0], parts[1], d.Get("tls_secret_created").(bool))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err = d.Set("tls_secret_created", true); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting tls_secret_created: %s", err))
+		}
+		if err = d.Set("certificate_versions_total", versionsTotal); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting certificate_versions_total: %s", err))
+		}
+	}
+
+	if d.HasChange("component") {
+		patch.Component = resourceIbmCodeEngineDomainMappingMapToComponentRef(d.Get("component.0").(map[string]interface{}))
+		hasChange = true
+	}
+	if d.HasChange("tls_secret") {
+		patch.TlsSecret = core.StringPtr(d.Get("tls_secret").(string))
+		hasChange = true
+	}
+
+	if hasChange {
+		_, response, err := codeEngineDomainMappingRequest(context, codeEngineClient, core.PATCH,
+			`/projects/{project_id}/domain_mappings/{name}`, map[string]string{"project_id": parts[0], "name": parts[1]}, patch, d.Get("etag").(string))
+		if err != nil {
+			log.Printf("[DEBUG] PATCH /projects/{project_id}/domain_mappings/{name} failed %s\n%s", err, response)
+			return diag.FromErr(fmt.Errorf("PATCH /projects/{project_id}/domain_mappings/{name} failed %s\n%s", err, response))
+		}
+
+		_, err = waitForIbmCodeEngineDomainMappingReady(context, d, meta, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf(
+				"Error waiting for resource IbmCodeEngineDomainMapping (%s) to be updated: %s", d.Id(), err))
+		}
+	}
+
+	// The TLS secret that was copied from Secrets Manager is no longer used
+	// once the domain mapping refers to a secret of the user.
+	if !hasCertificate && d.Get("tls_secret_created").(bool) && d.Get("tls_secret").(string) != parts[1] {
+		if err = deleteCodeEngineDomainMappingTLSSecret(context, codeEngineClient, parts[0], parts[1]); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = d.Set("tls_secret_created", false); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting tls_secret_created: %s", err))
+		}
+	}
+
+	return resourceIbmCodeEngineDomainMappingRead(context, d, meta)
+}
+
+func resourceIbmCodeEngineDomainMappingDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	parts, err := flex.SepIdParts(d.Id(), "/")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, response, err := codeEngineDomainMappingRequest(context, codeEngineClient, core.DELETE,
+		`/projects/{project_id}/domain_mappings/{name}`, map[string]string{"project_id": parts[0], "name": parts[1]}, nil, "")
+	if err != nil && (response == nil || response.StatusCode != 404) {
+		log.Printf("[DEBUG] DELETE /projects/{project_id}/domain_mappings/{name} failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("DELETE /projects/{project_id}/domain_mappings/{name} failed %s\n%s", err, response))
+	}
+
+	if d.Get("tls_secret_created").(bool) {
+		if err = deleteCodeEngineDomainMappingTLSSecret(context, codeEngineClient, parts[0], parts[1]); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceIbmCodeEngineDomainMappingMapToComponentRef(modelMap map[string]interface{}) *codeEngineDomainMappingComponent {
+	return &codeEngineDomainMappingComponent{
+		Name:         core.StringPtr(modelMap["name"].(string)),
+		ResourceType: core.StringPtr(modelMap["resource_type"].(string)),
+	}
+}
+
+func resourceIbmCodeEngineDomainMappingComponentRefToMap(model *codeEngineDomainMappingComponent) map[string]interface{} {
+	modelMap := make(map[string]interface{})
+	if model.Name != nil {
+		modelMap["name"] = model.Name
+	}
+	if model.ResourceType != nil {
+		modelMap["resource_type"] = model.ResourceType
+	}
+	return modelMap
+}
+
+func resourceIbmCodeEngineDomainMappingCustomizeDiff(context context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if _, ok := diff.GetOk("secrets_manager_certificate"); !ok {
+		return nil
+	}
+
+	// The TLS secret that is copied from Secrets Manager is named after the
+	// domain mapping.
+	if !diff.NewValueKnown("name") {
+		if err := diff.SetNewComputed("tls_secret"); err != nil {
+			return err
+		}
+	} else if diff.Get("tls_secret").(string) != diff.Get("name").(string) {
+		if err := diff.SetNew("tls_secret", diff.Get("name").(string)); err != nil {
+			return err
+		}
+	}
+
+	// The certificate is only read from Secrets Manager when it is applied
+	if diff.Id() == "" || diff.HasChange("secrets_manager_certificate") {
+		return diff.SetNewComputed("certificate_versions_total")
+	}
+	return nil
+}
+
+// syncCodeEngineDomainMappingTLSSecret copies the Secrets Manager
+// certificate into the TLS secret of the project with the given name, and
+// returns the number of versions of the certificate that was copied. An
+// existing TLS secret is only replaced when the resource created it.
+func syncCodeEngineDomainMappingTLSSecret(context context.Context, codeEngineClient *codeenginev2.CodeEngineV2, meta interface{}, certificate map[string]interface{}, projectID, name string, created bool) (int, error) {
+	secretsManagerClient, err := meta.(conns.ClientSession).SecretsManagerV2()
+	if err != nil {
+		return 0, err
+	}
+	secretsManagerClient, err = secretsmanager.GetClientWithInstanceEndpoint(secretsManagerClient, certificate["instance_id"].(string), certificate["region"].(string), certificate["endpoint_type"].(string))
+	if err != nil {
+		return 0, err
+	}
+	secretID := certificate["secret_id"].(string)
+	secret, response, err := secretsManagerClient.GetSecretWithContext(context, secretsManagerClient.NewGetSecretOptions(secretID))
+	if err != nil {
+		return 0, fmt.Errorf("GetSecretWithContext failed for the certificate %s: %s\n%s", secretID, err, response)
+	}
+	var cert, intermediate, privateKey *string
+	var versionsTotal *int64
+	switch secret := secret.(type) {
+	case *secretsmanagerv2.PublicCertificate:
+		cert, intermediate, privateKey, versionsTotal = secret.Certificate, secret.Intermediate, secret.PrivateKey, secret.VersionsTotal
+	case *secretsmanagerv2.ImportedCertificate:
+		cert, intermediate, privateKey, versionsTotal = secret.Certificate, secret.Intermediate, secret.PrivateKey, secret.VersionsTotal
+	case *secretsmanagerv2.PrivateCertificate:
+		cert, privateKey, versionsTotal = secret.Certificate, secret.PrivateKey, secret.VersionsTotal
+	default:
+		return 0, fmt.Errorf("The secret %s is not a public, imported or private certificate", secretID)
+	}
+	if core.StringNilMapper(cert) == "" || core.StringNilMapper(privateKey) == "" {
+		return 0, fmt.Errorf("The certificate %s has no certificate or private key, it might not be issued yet", secretID)
+	}
+
+	tlsCert := strings.TrimRight(*cert, "\n") + "\n"
+	if core.StringNilMapper(intermediate) != "" {
+		tlsCert += strings.TrimRight(*intermediate, "\n") + "\n"
+	}
+	data, err := codeEngineClient.NewSecretDataTLSSecretData(tlsCert, *privateKey)
+	if err != nil {
+		return 0, err
+	}
+
+	existing, response, err := codeEngineClient.GetSecretWithContext(context, codeEngineClient.NewGetSecretOptions(projectID, name))
+	if err != nil {
+		if response == nil || response.StatusCode != 404 {
+			return 0, fmt.Errorf("GetSecretWithContext failed for the TLS secret %s: %s\n%s", name, err, response)
+		}
+		createSecretOptions := codeEngineClient.NewCreateSecretOptions(projectID, codeenginev2.CreateSecretOptions_Format_Tls, name)
+		createSecretOptions.SetData(data)
+		if _, response, err = codeEngineClient.CreateSecretWithContext(context, createSecretOptions); err != nil {
+			return 0, fmt.Errorf("CreateSecretWithContext failed for the TLS secret %s: %s\n%s", name, err, response)
+		}
+		return flex.IntValue(versionsTotal), nil
+	}
+	if !created {
+		return 0, fmt.Errorf("The TLS secret %s already exists in the project and is not created by the domain mapping, delete it or set tls_secret to use it", name)
+	}
+
+	replaceSecretOptions := codeEngineClient.NewReplaceSecretOptions(projectID, name, core.StringNilMapper(existing.EntityTag))
+	replaceSecretOptions.SetFormat(codeenginev2.ReplaceSecretOptions_Format_Tls)
+	replaceSecretOptions.SetData(data)
+	if _, response, err = codeEngineClient.ReplaceSecretWithContext(context, replaceSecretOptions); err != nil {
+		return 0, fmt.Errorf("ReplaceSecretWithContext failed for the TLS secret %s: %s\n%s", name, err, response)
+	}
+	return flex.IntValue(versionsTotal), nil
+}
+
+func deleteCodeEngineDomainMappingTLSSecret(context context.Context, codeEngineClient *codeenginev2.CodeEngineV2, projectID, name string) error {
+	response, err := codeEngineClient.DeleteSecretWithContext(context, codeEngineClient.NewDeleteSecretOptions(projectID, name))
+	if err != nil && (response == nil || response.StatusCode != 404) {
+		return fmt.Errorf("DeleteSecretWithContext failed for the TLS secret %s: %s\n%s", name, err, response)
+	}
+	return nil
+}
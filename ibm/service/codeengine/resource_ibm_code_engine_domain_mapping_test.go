@@ -0,0 +1,192 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package codeengine_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+func TestAccIbmCodeEngineDomainMappingBasic(t *testing.T) {
+	appName := fmt.Sprintf("tf-app-domain-mapping-%d", acctest.RandIntRange(10, 1000))
+	appNameUpdate := fmt.Sprintf("tf-app-domain-mapping-update-%d", acctest.RandIntRange(10, 1000))
+	secretName := fmt.Sprintf("tf-secret-domain-mapping-%d", acctest.RandIntRange(10, 1000))
+
+	projectID := acc.CeProjectId
+	domainMappingName := acc.CeDomainMappingName
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIbmCodeEngineDomainMappingDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIbmCodeEngineDomainMappingConfigBasic(projectID, appName, appNameUpdate, secretName, domainMappingName, "code_engine_app_instance"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ibm_code_engine_domain_mapping.code_engine_domain_mapping_instance", "domain_mapping_id"),
+					resource.TestCheckResourceAttr("ibm_code_engine_domain_mapping.code_engine_domain_mapping_instance", "project_id", projectID),
+					resource.TestCheckResourceAttr("ibm_code_engine_domain_mapping.code_engine_domain_mapping_instance", "name", domainMappingName),
+					resource.TestCheckResourceAttr("ibm_code_engine_domain_mapping.code_engine_domain_mapping_instance", "component.0.name", appName),
+					resource.TestCheckResourceAttr("ibm_code_engine_domain_mapping.code_engine_domain_mapping_instance", "tls_secret", secretName),
+					resource.TestCheckResourceAttr("ibm_code_engine_domain_mapping.code_engine_domain_mapping_instance", "status", "ready"),
+					resource.TestCheckResourceAttrSet("ibm_code_engine_domain_mapping.code_engine_domain_mapping_instance", "cname_target"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIbmCodeEngineDomainMappingConfigBasic(projectID, appName, appNameUpdate, secretName, domainMappingName, "code_engine_app_instance_update"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_code_engine_domain_mapping.code_engine_domain_mapping_instance", "component.0.name", appNameUpdate),
+					resource.TestCheckResourceAttr("ibm_code_engine_domain_mapping.code_engine_domain_mapping_instance", "status", "ready"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "ibm_code_engine_domain_mapping.code_engine_domain_mapping_instance",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccIbmCodeEngineDomainMappingSecretsManagerCertificate(t *testing.T) {
+	appName := fmt.Sprintf("tf-app-domain-mapping-sm-%d", acctest.RandIntRange(10, 1000))
+
+	projectID := acc.CeProjectId
+	domainMappingName := acc.CeDomainMappingName
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIbmCodeEngineDomainMappingDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIbmCodeEngineDomainMappingConfigSecretsManagerCertificate(projectID, appName, domainMappingName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_code_engine_domain_mapping.code_engine_domain_mapping_instance", "tls_secret", domainMappingName),
+					resource.TestCheckResourceAttrSet("ibm_code_engine_domain_mapping.code_engine_domain_mapping_instance", "certificate_versions_total"),
+					resource.TestCheckResourceAttr("ibm_code_engine_domain_mapping.code_engine_domain_mapping_instance", "tls_secret_created", "true"),
+					resource.TestCheckResourceAttr("ibm_code_engine_domain_mapping.code_engine_domain_mapping_instance", "status", "ready"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIbmCodeEngineDomainMappingConfigSecretsManagerCertificate(projectID string, appName string, domainMappingName string) string {
+	return fmt.Sprintf(`
+		resource "ibm_code_engine_app" "code_engine_app_instance" {
+			project_id = "%s"
+			name = "%s"
+			image_reference = "icr.io/codeengine/helloworld"
+		}
+
+		resource "ibm_code_engine_domain_mapping" "code_engine_domain_mapping_instance" {
+			project_id = ibm_code_engine_app.code_engine_app_instance.project_id
+			name = "%s"
+			component {
+				name = ibm_code_engine_app.code_engine_app_instance.name
+				resource_type = "app_v2"
+			}
+			secrets_manager_certificate {
+				instance_id = "%s"
+				region = "%s"
+				secret_id = "%s"
+			}
+		}
+	`, projectID, appName, domainMappingName, acc.SecretsManagerInstanceID, acc.SecretsManagerInstanceRegion, acc.CeSecretsManagerCertificateID)
+}
+
+func testAccCheckIbmCodeEngineDomainMappingConfigBasic(projectID string, appName string, appNameUpdate string, secretName string, domainMappingName string, component string) string {
+	return fmt.Sprintf(`
+		data "ibm_code_engine_project" "code_engine_project_instance" {
+			project_id = "%s"
+		}
+
+		resource "ibm_code_engine_app" "code_engine_app_instance" {
+			project_id = data.ibm_code_engine_project.code_engine_project_instance.project_id
+			name = "%s"
+			image_reference = "icr.io/codeengine/helloworld"
+		}
+
+		resource "ibm_code_engine_app" "code_engine_app_instance_update" {
+			project_id = data.ibm_code_engine_project.code_engine_project_instance.project_id
+			name = "%s"
+			image_reference = "icr.io/codeengine/helloworld"
+		}
+
+		resource "ibm_code_engine_secret" "code_engine_secret_instance" {
+			project_id = data.ibm_code_engine_project.code_engine_project_instance.project_id
+			name = "%s"
+			format = "tls"
+			data = {
+				"tls_cert" = <<EOT
+%s
+EOT
+				"tls_key" = <<EOT
+%s
+EOT
+			}
+		}
+
+		resource "ibm_code_engine_domain_mapping" "code_engine_domain_mapping_instance" {
+			project_id = data.ibm_code_engine_project.code_engine_project_instance.project_id
+			name = "%s"
+			component {
+				name = ibm_code_engine_app.%s.name
+				resource_type = "app_v2"
+			}
+			tls_secret = ibm_code_engine_secret.code_engine_secret_instance.name
+		}
+	`, projectID, appName, appNameUpdate, secretName, acc.CeTLSCert, acc.CeTLSKey, domainMappingName, component)
+}
+
+func testAccCheckIbmCodeEngineDomainMappingDestroy(s *terraform.State) error {
+	codeEngineClient, err := acc.TestAccProvider.Meta().(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_code_engine_domain_mapping" {
+			continue
+		}
+
+		parts, err := flex.SepIdParts(rs.Primary.ID, "/")
+		if err != nil {
+			return err
+		}
+
+		builder := core.NewRequestBuilder(core.GET)
+		_, err = builder.ResolveRequestURL(codeEngineClient.Service.Options.URL,
+			`/projects/{project_id}/domain_mappings/{name}`, map[string]string{"project_id": parts[0], "name": parts[1]})
+		if err != nil {
+			return err
+		}
+		builder.AddHeader("Accept", "application/json")
+		request, err := builder.Build()
+		if err != nil {
+			return err
+		}
+
+		// Try to find the key
+		var domainMapping map[string]interface{}
+		response, err := codeEngineClient.Service.Request(request, &domainMapping)
+
+		if err == nil {
+			return fmt.Errorf("code_engine_domain_mapping still exists: %s", rs.Primary.ID)
+		} else if response.StatusCode != 404 {
+			return fmt.Errorf("Error checking for code_engine_domain_mapping (%s) has been destroyed: %s", rs.Primary.ID, err)
+		}
+	}
+
+	return nil
+}
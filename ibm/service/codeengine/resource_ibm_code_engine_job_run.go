@@ -0,0 +1,496 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package codeengine
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/code-engine-go-sdk/codeenginev2"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+const (
+	codeEngineJobRunStatusFailed   = "failed"
+	codeEngineJobRunExitSucceeded  = "succeeded"
+	codeEngineJobRunExitFailed     = "failed"
+	codeEngineJobRunExitIncomplete = "incomplete"
+)
+
+func ResourceIbmCodeEngineJobRun() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIbmCodeEngineJobRunCreate,
+		ReadContext:   resourceIbmCodeEngineJobRunRead,
+		UpdateContext: resourceIbmCodeEngineJobRunUpdate,
+		DeleteContext: resourceIbmCodeEngineJobRunDelete,
+		Importer:      &schema.ResourceImporter{},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.InvokeValidator("ibm_code_engine_job_run", "project_id"),
+				Description:  "The ID of the project.",
+			},
+			"job_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.InvokeValidator("ibm_code_engine_job_run", "job_name"),
+				Description:  "The name of the job that is run.",
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.InvokeValidator("ibm_code_engine_job_run", "name"),
+				Description:  "The name of the job run. If omitted, a name is generated by Code Engine.",
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that trigger a new job run when they change. Without triggers the job is run once.",
+			},
+			"run_arguments": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Set arguments for the job run, overriding the arguments of the job.",
+			},
+			"run_commands": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Set commands for the job run, overriding the commands of the job.",
+			},
+			"scale_array_spec": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.InvokeValidator("ibm_code_engine_job_run", "scale_array_spec"),
+				Description:  "The array indices to run, as a comma-separated list of indices or ranges such as `0-5,10`. Defaults to the array specification of the job.",
+			},
+			"scale_max_execution_time": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The maximum execution time in seconds for each array index.",
+			},
+			"scale_retry_limit": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The number of times to rerun an instance of the job before the job is marked as failed.",
+			},
+			"wait_for_completion": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to wait until every array index of the job run completed. A job run with failed indices is reported as an error.",
+			},
+			"job_run_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The identifier of the resource.",
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The current status of the job run.",
+			},
+			"exit_status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The outcome of the job run: `succeeded` when every array index succeeded, `failed` when any index failed, `incomplete` otherwise.",
+			},
+			"status_details": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The status details of the job run, counted in array indices.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"completion_time": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time the job run completed.",
+						},
+						"failed": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of failed job run instances.",
+						},
+						"pending": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of pending job run instances.",
+						},
+						"requested": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of requested job run instances.",
+						},
+						"running": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of running job run instances.",
+						},
+						"start_time": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time the job run started.",
+						},
+						"succeeded": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of succeeded job run instances.",
+						},
+						"unknown": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of job run instances with unknown state.",
+						},
+					},
+				},
+			},
+			"created_at": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The timestamp when the resource was created.",
+			},
+			"href": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When you provision a new resource, a URL is created identifying the location of the instance.",
+			},
+		},
+	}
+}
+
+func ResourceIbmCodeEngineJobRunValidator() *validate.ResourceValidator {
+	validateSchema := make([]validate.ValidateSchema, 0)
+	validateSchema = append(validateSchema,
+		validate.ValidateSchema{
+			Identifier:                 "project_id",
+			ValidateFunctionIdentifier: validate.ValidateRegexpLen,
+			Type:                       validate.TypeString,
+			Required:                   true,
+			Regexp:                     `^[0-9a-z]{8}-[0-9a-z]{4}-[0-9a-z]{4}-[0-9a-z]{4}-[0-9a-z]{12}$`,
+			MinValueLength:             36,
+			MaxValueLength:             36,
+		},
+		validate.ValidateSchema{
+			Identifier:                 "job_name",
+			ValidateFunctionIdentifier: validate.ValidateRegexpLen,
+			Type:                       validate.TypeString,
+			Required:                   true,
+			Regexp:                     `^[a-z0-9]([\-a-z0-9]*[a-z0-9])?$`,
+			MinValueLength:             1,
+			MaxValueLength:             63,
+		},
+		validate.ValidateSchema{
+			Identifier:                 "name",
+			ValidateFunctionIdentifier: validate.ValidateRegexpLen,
+			Type:                       validate.TypeString,
+			Optional:                   true,
+			Regexp:                     `^[a-z0-9]([\-a-z0-9]*[a-z0-9])?$`,
+			MinValueLength:             1,
+			MaxValueLength:             63,
+		},
+		validate.ValidateSchema{
+			Identifier:                 "scale_array_spec",
+			ValidateFunctionIdentifier: validate.ValidateRegexpLen,
+			Type:                       validate.TypeString,
+			Optional:                   true,
+			Regexp:                     `^(?:[1-9]\d\d\d\d\d\d|[1-9]\d\d\d\d\d|[1-9]\d\d\d\d|[1-9]\d\d\d|[1-9]\d\d|[1-9]?\d)(?:-(?:[1-9]\d\d\d\d\d\d|[1-9]\d\d\d\d\d|[1-9]\d\d\d\d|[1-9]\d\d\d|[1-9]\d\d|[1-9]?\d))?(?:,(?:[1-9]\d\d\d\d\d\d|[1-9]\d\d\d\d\d|[1-9]\d\d\d\d|[1-9]\d\d\d|[1-9]\d\d|[1-9]?\d)(?:-(?:[1-9]\d\d\d\d\d\d|[1-9]\d\d\d\d\d|[1-9]\d\d\d\d|[1-9]\d\d\d|[1-9]\d\d|[1-9]?\d))?)*$`,
+			MinValueLength:             1,
+			MaxValueLength:             253,
+		},
+	)
+
+	resourceValidator := validate.ResourceValidator{ResourceName: "ibm_code_engine_job_run", Schema: validateSchema}
+	return &resourceValidator
+}
+
+func resourceIbmCodeEngineJobRunCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	createJobRunOptions := &codeenginev2.CreateJobRunOptions{}
+
+	createJobRunOptions.SetProjectID(d.Get("project_id").(string))
+	createJobRunOptions.SetJobName(d.Get("job_name").(string))
+	if _, ok := d.GetOk("name"); ok {
+		createJobRunOptions.SetName(d.Get("name").(string))
+	}
+	if _, ok := d.GetOk("run_arguments"); ok {
+		createJobRunOptions.SetRunArguments(flex.ExpandStringList(d.Get("run_arguments").([]interface{})))
+	}
+	if _, ok := d.GetOk("run_commands"); ok {
+		createJobRunOptions.SetRunCommands(flex.ExpandStringList(d.Get("run_commands").([]interface{})))
+	}
+	if _, ok := d.GetOk("scale_array_spec"); ok {
+		createJobRunOptions.SetScaleArraySpec(d.Get("scale_array_spec").(string))
+	}
+	if _, ok := d.GetOk("scale_max_execution_time"); ok {
+		createJobRunOptions.SetScaleMaxExecutionTime(int64(d.Get("scale_max_execution_time").(int)))
+	}
+	if _, ok := d.GetOk("scale_retry_limit"); ok {
+		createJobRunOptions.SetScaleRetryLimit(int64(d.Get("scale_retry_limit").(int)))
+	}
+
+	jobRun, response, err := codeEngineClient.CreateJobRunWithContext(context, createJobRunOptions)
+	if err != nil {
+		log.Printf("[DEBUG] CreateJobRunWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("CreateJobRunWithContext failed %s\n%s", err, response))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", *createJobRunOptions.ProjectID, *jobRun.Name))
+
+	if d.Get("wait_for_completion").(bool) {
+		_, err = waitForIbmCodeEngineJobRunCompletion(d, meta)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf(
+				"Error waiting for resource IbmCodeEngineJobRun (%s) to complete: %s", d.Id(), err))
+		}
+	}
+
+	return resourceIbmCodeEngineJobRunRead(context, d, meta)
+}
+
+func waitForIbmCodeEngineJobRunCompletion(d *schema.ResourceData, meta interface{}) (interface{}, error) {
+	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return false, err
+	}
+	getJobRunOptions := &codeenginev2.GetJobRunOptions{}
+
+	parts, err := flex.SepIdParts(d.Id(), "/")
+	if err != nil {
+		return false, err
+	}
+
+	getJobRunOptions.SetProjectID(parts[0])
+	getJobRunOptions.SetName(parts[1])
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{codeenginev2.JobRun_Status_Pending, codeenginev2.JobRun_Status_Running},
+		Target:  []string{codeEngineJobRunExitSucceeded, codeEngineJobRunExitIncomplete},
+		Refresh: func() (interface{}, string, error) {
+			stateObj, response, err := codeEngineClient.GetJobRun(getJobRunOptions)
+			if err != nil {
+				return nil, "", fmt.Errorf("GetJobRun failed %s\n%s", err, response)
+			}
+			status := core.StringNilMapper(stateObj.Status)
+			if status != codeenginev2.JobRun_Status_Completed && status != codeEngineJobRunStatusFailed {
+				return stateObj, status, nil
+			}
+			exitStatus := codeEngineJobRunExitStatus(stateObj)
+			if exitStatus == codeEngineJobRunExitFailed {
+				if stateObj.StatusDetails == nil {
+					return stateObj, exitStatus, fmt.Errorf("The job run %s did not succeed", core.StringNilMapper(stateObj.Name))
+				}
+				return stateObj, exitStatus, fmt.Errorf("The job run %s did not succeed: %d of %d array indices failed",
+					core.StringNilMapper(stateObj.Name), flex.IntValue(stateObj.StatusDetails.Failed), flex.IntValue(stateObj.StatusDetails.Requested))
+			}
+			return stateObj, exitStatus, nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
+
+// codeEngineJobRunExitStatus summarizes the array indices of a finished job
+// run into a single outcome.
+func codeEngineJobRunExitStatus(jobRun *codeenginev2.JobRun) string {
+	if core.StringNilMapper(jobRun.Status) == codeEngineJobRunStatusFailed {
+		return codeEngineJobRunExitFailed
+	}
+	if jobRun.StatusDetails == nil {
+		return codeEngineJobRunExitIncomplete
+	}
+	if flex.IntValue(jobRun.StatusDetails.Failed) > 0 {
+		return codeEngineJobRunExitFailed
+	}
+	if core.StringNilMapper(jobRun.Status) == codeenginev2.JobRun_Status_Completed &&
+		flex.IntValue(jobRun.StatusDetails.Succeeded) == flex.IntValue(jobRun.StatusDetails.Requested) {
+		return codeEngineJobRunExitSucceeded
+	}
+	return codeEngineJobRunExitIncomplete
+}
+
+func resourceIbmCodeEngineJobRunRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	getJobRunOptions := &codeenginev2.GetJobRunOptions{}
+
+	parts, err := flex.SepIdParts(d.Id(), "/")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	getJobRunOptions.SetProjectID(parts[0])
+	getJobRunOptions.SetName(parts[1])
+
+	jobRun, response, err := codeEngineClient.GetJobRunWithContext(context, getJobRunOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			// Code Engine prunes finished job runs. Keep the last known state so
+			// that a one-shot run is not repeated on the next apply.
+			log.Printf("[WARN] Job run %s no longer exists in Code Engine, keeping its last known state", d.Id())
+			return nil
+		}
+		log.Printf("[DEBUG] GetJobRunWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("GetJobRunWithContext failed %s\n%s", err, response))
+	}
+
+	if err = d.Set("project_id", jobRun.ProjectID); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting project_id: %s", err))
+	}
+	if err = d.Set("job_name", jobRun.JobName); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting job_name: %s", err))
+	}
+	if err = d.Set("name", jobRun.Name); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting name: %s", err))
+	}
+	if !core.IsNil(jobRun.ScaleArraySpec) {
+		if err = d.Set("scale_array_spec", jobRun.ScaleArraySpec); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting scale_array_spec: %s", err))
+		}
+	}
+	if !core.IsNil(jobRun.ScaleMaxExecutionTime) {
+		if err = d.Set("scale_max_execution_time", flex.IntValue(jobRun.ScaleMaxExecutionTime)); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting scale_max_execution_time: %s", err))
+		}
+	}
+	if !core.IsNil(jobRun.ScaleRetryLimit) {
+		if err = d.Set("scale_retry_limit", flex.IntValue(jobRun.ScaleRetryLimit)); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting scale_retry_limit: %s", err))
+		}
+	}
+	if !core.IsNil(jobRun.ID) {
+		if err = d.Set("job_run_id", jobRun.ID); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting job_run_id: %s", err))
+		}
+	}
+	if !core.IsNil(jobRun.Status) {
+		if err = d.Set("status", jobRun.Status); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting status: %s", err))
+		}
+	}
+	if err = d.Set("exit_status", codeEngineJobRunExitStatus(jobRun)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting exit_status: %s", err))
+	}
+	if !core.IsNil(jobRun.StatusDetails) {
+		statusDetailsMap, err := resourceIbmCodeEngineJobRunJobRunStatusToMap(jobRun.StatusDetails)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err = d.Set("status_details", []map[string]interface{}{statusDetailsMap}); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting status_details: %s", err))
+		}
+	}
+	if !core.IsNil(jobRun.CreatedAt) {
+		if err = d.Set("created_at", jobRun.CreatedAt); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting created_at: %s", err))
+		}
+	}
+	if !core.IsNil(jobRun.Href) {
+		if err = d.Set("href", jobRun.Href); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting href: %s", err))
+		}
+	}
+
+	return nil
+}
+
+func resourceIbmCodeEngineJobRunUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Every argument that changes the job run forces a new one; only
+	// wait_for_completion can change in place and it needs no API call.
+	return resourceIbmCodeEngineJobRunRead(context, d, meta)
+}
+
+func resourceIbmCodeEngineJobRunDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	codeEngineClient, err := meta.(conns.ClientSession).CodeEngineV2()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deleteJobRunOptions := &codeenginev2.DeleteJobRunOptions{}
+
+	parts, err := flex.SepIdParts(d.Id(), "/")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deleteJobRunOptions.SetProjectID(parts[0])
+	deleteJobRunOptions.SetName(parts[1])
+
+	response, err := codeEngineClient.DeleteJobRunWithContext(context, deleteJobRunOptions)
+	if err != nil && (response == nil || response.StatusCode != 404) {
+		log.Printf("[DEBUG] DeleteJobRunWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("DeleteJobRunWithContext failed %s\n%s", err, response))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceIbmCodeEngineJobRunJobRunStatusToMap(model *codeenginev2.JobRunStatus) (map[string]interface{}, error) {
+	modelMap := make(map[string]interface{})
+	if model.CompletionTime != nil {
+		modelMap["completion_time"] = model.CompletionTime
+	}
+	if model.Failed != nil {
+		modelMap["failed"] = flex.IntValue(model.Failed)
+	}
+	if model.Pending != nil {
+		modelMap["pending"] = flex.IntValue(model.Pending)
+	}
+	if model.Requested != nil {
+		modelMap["requested"] = flex.IntValue(model.Requested)
+	}
+	if model.Running != nil {
+		modelMap["running"] = flex.IntValue(model.Running)
+	}
+	if model.StartTime != nil {
+		modelMap["start_time"] = model.StartTime
+	}
+	if model.Succeeded != nil {
+		modelMap["succeeded"] = flex.IntValue(model.Succeeded)
+	}
+	if model.Unknown != nil {
+		modelMap["unknown"] = flex.IntValue(model.Unknown)
+	}
+	return modelMap, nil
+}
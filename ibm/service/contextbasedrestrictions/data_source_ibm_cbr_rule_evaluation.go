@@ -0,0 +1,396 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package contextbasedrestrictions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/platform-services-go-sdk/contextbasedrestrictionsv1"
+)
+
+const (
+	cbrDecisionPermit  = "permit"
+	cbrDecisionDeny    = "deny"
+	cbrDecisionUnknown = "unknown"
+)
+
+// DataSourceIBMCbrRuleEvaluation evaluates the requests of the configuration
+// locally against the contexts of a rule. It does not read the decisions that
+// the rule logged in report mode, which are only sent to Activity Tracker.
+func DataSourceIBMCbrRuleEvaluation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMCbrRuleEvaluationRead,
+
+		Schema: map[string]*schema.Schema{
+			"rule_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of a rule.",
+			},
+			"requests": &schema.Schema{
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The requests to evaluate against the rule contexts.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_ip": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The IP address the request originates from.",
+						},
+						"endpoint_type": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The endpoint type the request is sent to, such as `public`, `private` or `direct`.",
+						},
+						"label": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A free-form label echoed back in the evaluation, for example the name of the client.",
+						},
+					},
+				},
+			},
+			"enforcement_mode": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The current enforcement mode of the rule.",
+			},
+			"evaluations": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The decision the rule would take for each request once enforced.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_ip": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address the request originates from.",
+						},
+						"endpoint_type": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The endpoint type the request is sent to.",
+						},
+						"label": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The label of the request.",
+						},
+						"decision": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The decision: `permit`, `deny`, or `unknown` when it depends on addresses that cannot be evaluated locally.",
+						},
+						"matched_zone_ids": &schema.Schema{
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The zones of the rule whose addresses include the source IP.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"reason": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A short explanation of the decision.",
+						},
+					},
+				},
+			},
+			"permitted_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of requests the rule permits.",
+			},
+			"denied_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of requests the rule denies once it is enforced.",
+			},
+			"unknown_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of requests whose decision depends on attributes that cannot be evaluated locally.",
+			},
+			"denied_source_ips": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The source IPs of the denied requests.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceIBMCbrRuleEvaluationRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	contextBasedRestrictionsClient, err := meta.(conns.ClientSession).ContextBasedRestrictionsV1()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	getRuleOptions := &contextbasedrestrictionsv1.GetRuleOptions{}
+
+	getRuleOptions.SetRuleID(d.Get("rule_id").(string))
+
+	rule, response, err := contextBasedRestrictionsClient.GetRuleWithContext(context, getRuleOptions)
+	if err != nil {
+		log.Printf("[DEBUG] GetRuleWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("GetRuleWithContext failed %s\n%s", err, response))
+	}
+
+	zones := map[string][]cbrZoneMatcher{}
+	for _, ruleContext := range rule.Contexts {
+		for _, attribute := range ruleContext.Attributes {
+			if attribute.Name == nil || *attribute.Name != "networkZoneId" || attribute.Value == nil {
+				continue
+			}
+			for _, zoneID := range strings.Split(*attribute.Value, ",") {
+				zoneID = strings.TrimSpace(zoneID)
+				if _, ok := zones[zoneID]; ok || zoneID == "" {
+					continue
+				}
+				matcher, err := dataSourceIBMCbrRuleEvaluationZoneMatcher(context, contextBasedRestrictionsClient, zoneID)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				zones[zoneID] = matcher
+			}
+		}
+	}
+
+	evaluations := []map[string]interface{}{}
+	deniedSourceIPs := []string{}
+	counts := map[string]int{}
+	for _, e := range d.Get("requests").([]interface{}) {
+		request := e.(map[string]interface{})
+		evaluation, err := cbrEvaluateRequest(rule.Contexts, zones, request["source_ip"].(string), request["endpoint_type"].(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		evaluation["label"] = request["label"]
+		counts[evaluation["decision"].(string)]++
+		if evaluation["decision"] == cbrDecisionDeny {
+			deniedSourceIPs = append(deniedSourceIPs, request["source_ip"].(string))
+		}
+		evaluations = append(evaluations, evaluation)
+	}
+
+	d.SetId(*getRuleOptions.RuleID)
+
+	if err = d.Set("enforcement_mode", rule.EnforcementMode); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting enforcement_mode: %s", err))
+	}
+	if err = d.Set("evaluations", evaluations); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting evaluations: %s", err))
+	}
+	if err = d.Set("permitted_count", counts[cbrDecisionPermit]); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting permitted_count: %s", err))
+	}
+	if err = d.Set("denied_count", counts[cbrDecisionDeny]); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting denied_count: %s", err))
+	}
+	if err = d.Set("unknown_count", counts[cbrDecisionUnknown]); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting unknown_count: %s", err))
+	}
+	if err = d.Set("denied_source_ips", flex.FlattenStringList(deniedSourceIPs)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting denied_source_ips: %s", err))
+	}
+
+	return nil
+}
+
+// cbrZoneMatcher is one zone address reduced to what is needed to test an IP
+// against it. Addresses that cannot be resolved locally (vpc, serviceRef) are
+// kept with opaque set, so that a miss can be reported as unknown rather than
+// as a denial.
+type cbrZoneMatcher struct {
+	first, last net.IP
+	excluded    bool
+	opaque      bool
+}
+
+func dataSourceIBMCbrRuleEvaluationZoneMatcher(context context.Context, contextBasedRestrictionsClient *contextbasedrestrictionsv1.ContextBasedRestrictionsV1, zoneID string) ([]cbrZoneMatcher, error) {
+	getZoneOptions := &contextbasedrestrictionsv1.GetZoneOptions{}
+	getZoneOptions.SetZoneID(zoneID)
+
+	zone, response, err := contextBasedRestrictionsClient.GetZoneWithContext(context, getZoneOptions)
+	if err != nil {
+		log.Printf("[DEBUG] GetZoneWithContext failed %s\n%s", err, response)
+		return nil, fmt.Errorf("GetZoneWithContext failed %s\n%s", err, response)
+	}
+
+	matchers := []cbrZoneMatcher{}
+	for i, addresses := range [][]contextbasedrestrictionsv1.AddressIntf{zone.Addresses, zone.Excluded} {
+		for _, addressesItem := range addresses {
+			addressesItemMap, err := resourceIBMCbrZoneAddressToMap(addressesItem)
+			if err != nil {
+				return nil, err
+			}
+			matcher, err := cbrNewZoneMatcher(cbrZoneAddressField(addressesItemMap["type"]), cbrZoneAddressField(addressesItemMap["value"]))
+			if err != nil {
+				return nil, fmt.Errorf("Error reading addresses of cbr_zone %s: %s", zoneID, err)
+			}
+			matcher.excluded = i == 1
+			matchers = append(matchers, matcher)
+		}
+	}
+	return matchers, nil
+}
+
+func cbrNewZoneMatcher(addressType, value string) (cbrZoneMatcher, error) {
+	switch addressType {
+	case "ipAddress":
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return cbrZoneMatcher{}, fmt.Errorf("invalid ipAddress %q", value)
+		}
+		return cbrZoneMatcher{first: ip, last: ip}, nil
+	case "ipRange":
+		bounds := strings.SplitN(value, "-", 2)
+		if len(bounds) != 2 || net.ParseIP(bounds[0]) == nil || net.ParseIP(bounds[1]) == nil {
+			return cbrZoneMatcher{}, fmt.Errorf("invalid ipRange %q", value)
+		}
+		return cbrZoneMatcher{first: net.ParseIP(bounds[0]), last: net.ParseIP(bounds[1])}, nil
+	case "subnet":
+		_, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			return cbrZoneMatcher{}, fmt.Errorf("invalid subnet %q", value)
+		}
+		last := make(net.IP, len(ipNet.IP))
+		for i := range ipNet.IP {
+			last[i] = ipNet.IP[i] | ^ipNet.Mask[i]
+		}
+		return cbrZoneMatcher{first: ipNet.IP, last: last}, nil
+	}
+	return cbrZoneMatcher{opaque: true}, nil
+}
+
+func (m cbrZoneMatcher) contains(ip net.IP) bool {
+	if m.opaque {
+		return false
+	}
+	return bytes.Compare(ip.To16(), m.first.To16()) >= 0 && bytes.Compare(ip.To16(), m.last.To16()) <= 0
+}
+
+// cbrZoneDecision tells whether ip belongs to the zone: a permit when an
+// address includes it and no exclusion removes it, unknown when only
+// addresses that cannot be resolved locally could include it.
+func cbrZoneDecision(matchers []cbrZoneMatcher, ip net.IP) string {
+	included, opaque := false, false
+	for _, m := range matchers {
+		if m.excluded && m.contains(ip) {
+			return cbrDecisionDeny
+		}
+		if !m.excluded {
+			included = included || m.contains(ip)
+			opaque = opaque || m.opaque
+		}
+	}
+	if included {
+		return cbrDecisionPermit
+	}
+	if opaque {
+		return cbrDecisionUnknown
+	}
+	return cbrDecisionDeny
+}
+
+// cbrEvaluateRequest applies the CBR context semantics to a single request: a
+// request is permitted when any context matches, and a context matches when
+// all of its attributes match.
+func cbrEvaluateRequest(contexts []contextbasedrestrictionsv1.RuleContext, zones map[string][]cbrZoneMatcher, sourceIP, endpointType string) (map[string]interface{}, error) {
+	ip := net.ParseIP(sourceIP)
+	if ip == nil {
+		return nil, fmt.Errorf("Invalid source_ip %q", sourceIP)
+	}
+
+	evaluation := map[string]interface{}{
+		"source_ip":     sourceIP,
+		"endpoint_type": endpointType,
+	}
+	matchedZoneIDs := []string{}
+	decision, reason := cbrDecisionDeny, "no context of the rule matches the request"
+	if len(contexts) == 0 {
+		reason = "the rule has no contexts, so every request is denied"
+	}
+
+	for _, ruleContext := range contexts {
+		contextDecision := cbrDecisionPermit
+		for _, attribute := range ruleContext.Attributes {
+			if attribute.Name == nil || attribute.Value == nil {
+				continue
+			}
+			attributeDecision := cbrDecisionDeny
+			switch *attribute.Name {
+			case "networkZoneId":
+				for _, zoneID := range strings.Split(*attribute.Value, ",") {
+					zoneDecision := cbrZoneDecision(zones[strings.TrimSpace(zoneID)], ip)
+					if zoneDecision == cbrDecisionPermit {
+						matchedZoneIDs = append(matchedZoneIDs, strings.TrimSpace(zoneID))
+					}
+					attributeDecision = cbrMostPermissive(attributeDecision, zoneDecision)
+				}
+			case "endpointType":
+				if endpointType == "" {
+					attributeDecision = cbrDecisionUnknown
+				}
+				for _, value := range strings.Split(*attribute.Value, ",") {
+					if strings.TrimSpace(value) == endpointType {
+						attributeDecision = cbrDecisionPermit
+					}
+				}
+			default:
+				attributeDecision = cbrDecisionUnknown
+			}
+			contextDecision = cbrLeastPermissive(contextDecision, attributeDecision)
+		}
+		decision = cbrMostPermissive(decision, contextDecision)
+	}
+
+	switch {
+	case decision == cbrDecisionPermit:
+		reason = "a context of the rule matches the request"
+	case decision == cbrDecisionUnknown:
+		reason = "the decision depends on vpc, serviceRef or endpoint type attributes that cannot be evaluated locally"
+	}
+
+	evaluation["decision"] = decision
+	evaluation["matched_zone_ids"] = flex.FlattenStringList(matchedZoneIDs)
+	evaluation["reason"] = reason
+	return evaluation, nil
+}
+
+func cbrDecisionRank(decision string) int {
+	switch decision {
+	case cbrDecisionPermit:
+		return 2
+	case cbrDecisionUnknown:
+		return 1
+	}
+	return 0
+}
+
+func cbrMostPermissive(a, b string) string {
+	if cbrDecisionRank(b) > cbrDecisionRank(a) {
+		return b
+	}
+	return a
+}
+
+func cbrLeastPermissive(a, b string) string {
+	if cbrDecisionRank(b) < cbrDecisionRank(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,119 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package contextbasedrestrictions
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/contextbasedrestrictionsv1"
+)
+
+func TestCbrNewZoneMatcher(t *testing.T) {
+	testCases := []struct {
+		addressType string
+		value       string
+		contains    []string
+		excludes    []string
+		opaque      bool
+		err         bool
+	}{
+		{addressType: "ipAddress", value: "169.23.56.234", contains: []string{"169.23.56.234"}, excludes: []string{"169.23.56.235"}},
+		{addressType: "ipRange", value: "169.23.22.0-169.23.22.255", contains: []string{"169.23.22.0", "169.23.22.128", "169.23.22.255"}, excludes: []string{"169.23.21.255", "169.23.23.0"}},
+		{addressType: "subnet", value: "10.240.0.0/24", contains: []string{"10.240.0.0", "10.240.0.255"}, excludes: []string{"10.240.1.0"}},
+		{addressType: "subnet", value: "2001:db8::/126", contains: []string{"2001:db8::3"}, excludes: []string{"2001:db8::4", "10.240.0.0"}},
+		{addressType: "vpc", value: "crn:v1:bluemix:public:is:us-south:a/12ab34cd56ef78ab90cd12ef34ab56cd::vpc:r006-1234", excludes: []string{"10.240.0.1"}, opaque: true},
+		{addressType: "serviceRef", opaque: true},
+		{addressType: "ipAddress", value: "169.23.56", err: true},
+		{addressType: "ipRange", value: "169.23.22.0", err: true},
+		{addressType: "subnet", value: "10.240.0.0", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.addressType+" "+tc.value, func(t *testing.T) {
+			matcher, err := cbrNewZoneMatcher(tc.addressType, tc.value)
+			if (err != nil) != tc.err {
+				t.Fatalf("got error %v, want error %t", err, tc.err)
+			}
+			if matcher.opaque != tc.opaque {
+				t.Fatalf("got opaque %t, want %t", matcher.opaque, tc.opaque)
+			}
+			for _, ip := range tc.contains {
+				if !matcher.contains(net.ParseIP(ip)) {
+					t.Errorf("expected %s to contain %s", tc.value, ip)
+				}
+			}
+			for _, ip := range tc.excludes {
+				if matcher.contains(net.ParseIP(ip)) {
+					t.Errorf("expected %s not to contain %s", tc.value, ip)
+				}
+			}
+		})
+	}
+}
+
+func TestCbrEvaluateRequest(t *testing.T) {
+	zoneMatchers := func(t *testing.T, addresses ...[2]string) []cbrZoneMatcher {
+		matchers := []cbrZoneMatcher{}
+		for _, address := range addresses {
+			matcher, err := cbrNewZoneMatcher(address[0], address[1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			matchers = append(matchers, matcher)
+		}
+		return matchers
+	}
+	excluded := zoneMatchers(t, [2]string{"ipAddress", "169.23.22.10"})
+	excluded[0].excluded = true
+	zones := map[string][]cbrZoneMatcher{
+		"office": append(zoneMatchers(t, [2]string{"ipRange", "169.23.22.0-169.23.22.255"}), excluded...),
+		"vpc":    zoneMatchers(t, [2]string{"subnet", "10.240.0.0/24"}, [2]string{"vpc", "crn:v1:bluemix:public:is:us-south:a/12ab34cd56ef78ab90cd12ef34ab56cd::vpc:r006-1234"}),
+	}
+	attribute := func(name, value string) contextbasedrestrictionsv1.RuleContextAttribute {
+		return contextbasedrestrictionsv1.RuleContextAttribute{Name: core.StringPtr(name), Value: core.StringPtr(value)}
+	}
+	contexts := []contextbasedrestrictionsv1.RuleContext{
+		{Attributes: []contextbasedrestrictionsv1.RuleContextAttribute{attribute("networkZoneId", "office")}},
+		{Attributes: []contextbasedrestrictionsv1.RuleContextAttribute{attribute("networkZoneId", "vpc"), attribute("endpointType", "private,direct")}},
+	}
+
+	testCases := []struct {
+		name           string
+		contexts       []contextbasedrestrictionsv1.RuleContext
+		sourceIP       string
+		endpointType   string
+		decision       string
+		matchedZoneIDs []interface{}
+	}{
+		{name: "zone address", contexts: contexts, sourceIP: "169.23.22.20", decision: cbrDecisionPermit, matchedZoneIDs: []interface{}{"office"}},
+		{name: "excluded address", contexts: contexts, sourceIP: "169.23.22.10", endpointType: "public", decision: cbrDecisionDeny, matchedZoneIDs: []interface{}{}},
+		{name: "all attributes of a context", contexts: contexts, sourceIP: "10.240.0.5", endpointType: "private", decision: cbrDecisionPermit, matchedZoneIDs: []interface{}{"vpc"}},
+		{name: "other endpoint type", contexts: contexts, sourceIP: "10.240.0.5", endpointType: "public", decision: cbrDecisionDeny, matchedZoneIDs: []interface{}{"vpc"}},
+		{name: "unknown endpoint type", contexts: contexts, sourceIP: "10.240.0.5", decision: cbrDecisionUnknown, matchedZoneIDs: []interface{}{"vpc"}},
+		{name: "vpc address", contexts: contexts, sourceIP: "10.241.0.5", endpointType: "private", decision: cbrDecisionUnknown, matchedZoneIDs: []interface{}{}},
+		{name: "no context", sourceIP: "169.23.22.20", decision: cbrDecisionDeny, matchedZoneIDs: []interface{}{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			evaluation, err := cbrEvaluateRequest(tc.contexts, zones, tc.sourceIP, tc.endpointType)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if evaluation["decision"] != tc.decision {
+				t.Fatalf("got decision %v (%v), want %s", evaluation["decision"], evaluation["reason"], tc.decision)
+			}
+			if !reflect.DeepEqual(evaluation["matched_zone_ids"], tc.matchedZoneIDs) {
+				t.Fatalf("got matched zones %v, want %v", evaluation["matched_zone_ids"], tc.matchedZoneIDs)
+			}
+		})
+	}
+
+	if _, err := cbrEvaluateRequest(contexts, zones, "169.23.22", ""); err == nil {
+		t.Fatalf("expected an error for an invalid source IP")
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package contextbasedrestrictions_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+)
+
+func TestAccIBMCbrRuleEvaluationDataSourceBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMCbrRuleEvaluationDataSourceConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ibm_cbr_rule_evaluation.cbr_rule_evaluation", "id"),
+					resource.TestCheckResourceAttr("data.ibm_cbr_rule_evaluation.cbr_rule_evaluation", "enforcement_mode", "report"),
+					resource.TestCheckResourceAttr("data.ibm_cbr_rule_evaluation.cbr_rule_evaluation", "evaluations.#", "2"),
+					resource.TestCheckResourceAttr("data.ibm_cbr_rule_evaluation.cbr_rule_evaluation", "evaluations.0.decision", "permit"),
+					resource.TestCheckResourceAttr("data.ibm_cbr_rule_evaluation.cbr_rule_evaluation", "evaluations.1.decision", "deny"),
+					resource.TestCheckResourceAttr("data.ibm_cbr_rule_evaluation.cbr_rule_evaluation", "permitted_count", "1"),
+					resource.TestCheckResourceAttr("data.ibm_cbr_rule_evaluation.cbr_rule_evaluation", "denied_count", "1"),
+					resource.TestCheckResourceAttr("data.ibm_cbr_rule_evaluation.cbr_rule_evaluation", "denied_source_ips.0", "169.23.23.10"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCbrRuleEvaluationDataSourceConfigBasic() string {
+	return fmt.Sprintf(`
+		resource "ibm_cbr_zone" "cbr_zone" {
+			name = "Test Rule Report Data Source Config Basic"
+			account_id = "12ab34cd56ef78ab90cd12ef34ab56cd"
+			addresses {
+				type = "ipRange"
+				value = "169.23.22.0-169.23.22.255"
+			}
+		}
+
+		resource "ibm_cbr_rule" "cbr_rule" {
+			description = "Test Rule Report Data Source Config Basic"
+			contexts {
+				attributes {
+					name = "networkZoneId"
+					value = ibm_cbr_zone.cbr_zone.id
+				}
+			}
+			resources {
+				attributes {
+					name = "accountId"
+					value = "12ab34cd56ef78ab90cd12ef34ab56cd"
+				}
+				attributes {
+					name = "serviceName"
+					value = "iam-groups"
+				}
+			}
+			enforcement_mode = "report"
+		}
+
+		data "ibm_cbr_rule_evaluation" "cbr_rule_evaluation" {
+			rule_id = ibm_cbr_rule.cbr_rule.id
+			requests {
+				source_ip = "169.23.22.10"
+				label = "build-server"
+			}
+			requests {
+				source_ip = "169.23.23.10"
+				label = "laptop"
+			}
+		}
+	`)
+}
@@ -0,0 +1,335 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package contextbasedrestrictions
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM/platform-services-go-sdk/contextbasedrestrictionsv1"
+)
+
+// cbrZoneAddressesMaxAttempts bounds how many times a zone replace is retried
+// when another writer changed the zone between our read and our write.
+const cbrZoneAddressesMaxAttempts = 5
+
+func ResourceIBMCbrZoneAddresses() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMCbrZoneAddressesCreate,
+		ReadContext:   resourceIBMCbrZoneAddressesRead,
+		UpdateContext: resourceIBMCbrZoneAddressesUpdate,
+		DeleteContext: resourceIBMCbrZoneAddressesDelete,
+
+		Schema: map[string]*schema.Schema{
+			"zone_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the zone the addresses are added to.",
+			},
+			"addresses": &schema.Schema{
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The list of addresses owned by this resource. Addresses added to the zone by other writers are left untouched.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The type of address.",
+						},
+						"value": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The IP address.",
+						},
+						"ref": &schema.Schema{
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Optional:    true,
+							Description: "A service reference value.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"account_id": &schema.Schema{
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The id of the account owning the service.",
+									},
+									"service_type": &schema.Schema{
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The service type.",
+									},
+									"service_name": &schema.Schema{
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The service name.",
+									},
+									"service_instance": &schema.Schema{
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The service instance.",
+									},
+									"location": &schema.Schema{
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The location.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"address_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of addresses in the zone, including those owned by other writers.",
+			},
+		},
+	}
+}
+
+func resourceIBMCbrZoneAddressesCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	contextBasedRestrictionsClient, err := meta.(conns.ClientSession).ContextBasedRestrictionsV1()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	zoneID := d.Get("zone_id").(string)
+	addresses := d.Get("addresses").([]interface{})
+
+	conns.IbmMutexKV.Lock(zoneID)
+	defer conns.IbmMutexKV.Unlock(zoneID)
+
+	if err = resourceIBMCbrZoneAddressesMerge(context, contextBasedRestrictionsClient, zoneID, nil, addresses); err != nil {
+		return diag.FromErr(err)
+	}
+
+	addressesID, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("Error generating id for cbr_zone_addresses: %s", err))
+	}
+	d.SetId(fmt.Sprintf("%s/%s", zoneID, addressesID))
+
+	return resourceIBMCbrZoneAddressesRead(context, d, meta)
+}
+
+func resourceIBMCbrZoneAddressesRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	contextBasedRestrictionsClient, err := meta.(conns.ClientSession).ContextBasedRestrictionsV1()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	zoneID, _, err := resourceIBMCbrZoneAddressesParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	getZoneOptions := &contextbasedrestrictionsv1.GetZoneOptions{}
+	getZoneOptions.SetZoneID(zoneID)
+
+	zone, response, err := contextBasedRestrictionsClient.GetZoneWithContext(context, getZoneOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] GetZoneWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("GetZoneWithContext failed %s\n%s", err, response))
+	}
+
+	present := map[string]bool{}
+	for _, addressesItem := range zone.Addresses {
+		addressesItemMap, err := resourceIBMCbrZoneAddressToMap(addressesItem)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		present[cbrZoneAddressKey(addressesItemMap)] = true
+	}
+
+	// Only the addresses recorded in state belong to this resource; anything
+	// missing from the zone was removed out of band and shows up as drift.
+	addresses := []interface{}{}
+	for _, e := range d.Get("addresses").([]interface{}) {
+		if present[cbrZoneAddressKey(e.(map[string]interface{}))] {
+			addresses = append(addresses, e)
+		}
+	}
+
+	if err = d.Set("zone_id", zoneID); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting zone_id: %s", err))
+	}
+	if err = d.Set("addresses", addresses); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting addresses: %s", err))
+	}
+	if err = d.Set("address_count", len(zone.Addresses)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting address_count: %s", err))
+	}
+
+	return nil
+}
+
+func resourceIBMCbrZoneAddressesUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	contextBasedRestrictionsClient, err := meta.(conns.ClientSession).ContextBasedRestrictionsV1()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("addresses") {
+		zoneID := d.Get("zone_id").(string)
+		oldAddresses, newAddresses := d.GetChange("addresses")
+
+		conns.IbmMutexKV.Lock(zoneID)
+		defer conns.IbmMutexKV.Unlock(zoneID)
+
+		err = resourceIBMCbrZoneAddressesMerge(context, contextBasedRestrictionsClient, zoneID, oldAddresses.([]interface{}), newAddresses.([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceIBMCbrZoneAddressesRead(context, d, meta)
+}
+
+func resourceIBMCbrZoneAddressesDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	contextBasedRestrictionsClient, err := meta.(conns.ClientSession).ContextBasedRestrictionsV1()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	zoneID := d.Get("zone_id").(string)
+
+	conns.IbmMutexKV.Lock(zoneID)
+	defer conns.IbmMutexKV.Unlock(zoneID)
+
+	err = resourceIBMCbrZoneAddressesMerge(context, contextBasedRestrictionsClient, zoneID, d.Get("addresses").([]interface{}), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourceIBMCbrZoneAddressesMerge removes the addresses in remove from the zone
+// and adds the ones in add, keeping every other address as it is. The zone is
+// replaced with If-Match set to the ETag it was read with, so a concurrent
+// change made by another writer is never overwritten; on a version conflict
+// the zone is read again and the merge retried.
+func resourceIBMCbrZoneAddressesMerge(context context.Context, contextBasedRestrictionsClient *contextbasedrestrictionsv1.ContextBasedRestrictionsV1, zoneID string, remove []interface{}, add []interface{}) error {
+	removeKeys := map[string]bool{}
+	for _, e := range remove {
+		removeKeys[cbrZoneAddressKey(e.(map[string]interface{}))] = true
+	}
+	addKeys := map[string]bool{}
+	for _, e := range add {
+		addKeys[cbrZoneAddressKey(e.(map[string]interface{}))] = true
+	}
+
+	for attempt := 1; ; attempt++ {
+		getZoneOptions := &contextbasedrestrictionsv1.GetZoneOptions{}
+		getZoneOptions.SetZoneID(zoneID)
+
+		zone, response, err := contextBasedRestrictionsClient.GetZoneWithContext(context, getZoneOptions)
+		if err != nil {
+			if response != nil && response.StatusCode == 404 && len(add) == 0 {
+				return nil
+			}
+			log.Printf("[DEBUG] GetZoneWithContext failed %s\n%s", err, response)
+			return fmt.Errorf("GetZoneWithContext failed %s\n%s", err, response)
+		}
+
+		addresses := []contextbasedrestrictionsv1.AddressIntf{}
+		for _, addressesItem := range zone.Addresses {
+			addressesItemMap, err := resourceIBMCbrZoneAddressToMap(addressesItem)
+			if err != nil {
+				return err
+			}
+			key := cbrZoneAddressKey(addressesItemMap)
+			if removeKeys[key] || addKeys[key] {
+				continue
+			}
+			addresses = append(addresses, addressesItem)
+		}
+		for _, e := range add {
+			addressesItem, err := resourceIBMCbrZoneMapToAddress(e.(map[string]interface{}))
+			if err != nil {
+				return err
+			}
+			addresses = append(addresses, addressesItem)
+		}
+
+		replaceZoneOptions := &contextbasedrestrictionsv1.ReplaceZoneOptions{}
+		replaceZoneOptions.SetZoneID(zoneID)
+		replaceZoneOptions.SetIfMatch(response.Headers.Get("Etag"))
+		replaceZoneOptions.Name = zone.Name
+		replaceZoneOptions.AccountID = zone.AccountID
+		replaceZoneOptions.Description = zone.Description
+		replaceZoneOptions.SetAddresses(addresses)
+		replaceZoneOptions.SetExcluded(zone.Excluded)
+
+		_, response, err = contextBasedRestrictionsClient.ReplaceZoneWithContext(context, replaceZoneOptions)
+		if err != nil {
+			if response != nil && response.StatusCode == 412 && attempt < cbrZoneAddressesMaxAttempts {
+				log.Printf("[DEBUG] cbr_zone %s was modified concurrently, retrying address merge (attempt %d)", zoneID, attempt)
+				continue
+			}
+			log.Printf("[DEBUG] ReplaceZoneWithContext failed %s\n%s", err, response)
+			return fmt.Errorf("ReplaceZoneWithContext failed %s\n%s", err, response)
+		}
+
+		return nil
+	}
+}
+
+func resourceIBMCbrZoneAddressesParseID(id string) (string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Incorrect ID %s: ID should be a combination of zoneID/addressesID", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// cbrZoneAddressKey returns a string identifying an address map, so the same
+// address can be recognised in state and in the zone returned by the API.
+func cbrZoneAddressKey(modelMap map[string]interface{}) string {
+	key := []string{cbrZoneAddressField(modelMap["type"]), cbrZoneAddressField(modelMap["value"])}
+	var ref map[string]interface{}
+	switch refs := modelMap["ref"].(type) {
+	case []interface{}:
+		if len(refs) > 0 && refs[0] != nil {
+			ref = refs[0].(map[string]interface{})
+		}
+	case []map[string]interface{}:
+		if len(refs) > 0 {
+			ref = refs[0]
+		}
+	}
+	for _, field := range []string{"account_id", "service_type", "service_name", "service_instance", "location"} {
+		key = append(key, cbrZoneAddressField(ref[field]))
+	}
+	return strings.Join(key, "|")
+}
+
+func cbrZoneAddressField(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case *string:
+		if value != nil {
+			return *value
+		}
+	}
+	return ""
+}
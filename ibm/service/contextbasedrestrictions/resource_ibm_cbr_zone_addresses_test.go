@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package contextbasedrestrictions_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM/platform-services-go-sdk/contextbasedrestrictionsv1"
+)
+
+func TestAccIBMCbrZoneAddressesBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMCbrZoneDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMCbrZoneAddressesConfig("169.23.56.10"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMCbrZoneAddressesExists("ibm_cbr_zone_addresses.team_a", "169.23.56.10"),
+					testAccCheckIBMCbrZoneAddressesExists("ibm_cbr_zone_addresses.team_b", "169.23.57.0/24"),
+					resource.TestCheckResourceAttr("ibm_cbr_zone_addresses.team_a", "addresses.#", "1"),
+					resource.TestCheckResourceAttr("ibm_cbr_zone_addresses.team_b", "addresses.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIBMCbrZoneAddressesConfig("169.23.56.11"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMCbrZoneAddressesExists("ibm_cbr_zone_addresses.team_a", "169.23.56.11"),
+					testAccCheckIBMCbrZoneAddressesExists("ibm_cbr_zone_addresses.team_b", "169.23.57.0/24"),
+					resource.TestCheckResourceAttr("ibm_cbr_zone_addresses.team_a", "addresses.0.value", "169.23.56.11"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCbrZoneAddressesConfig(teamAddress string) string {
+	return fmt.Sprintf(`
+		resource "ibm_cbr_zone" "cbr_zone" {
+			name = "Test Zone Addresses Resource Config"
+			description = "Test Zone Addresses Resource Config"
+			account_id = "12ab34cd56ef78ab90cd12ef34ab56cd"
+			addresses {
+				type = "ipRange"
+				value = "169.23.22.0-169.23.22.255"
+			}
+			lifecycle {
+				ignore_changes = [addresses]
+			}
+		}
+
+		resource "ibm_cbr_zone_addresses" "team_a" {
+			zone_id = ibm_cbr_zone.cbr_zone.id
+			addresses {
+				type = "ipAddress"
+				value = "%s"
+			}
+		}
+
+		resource "ibm_cbr_zone_addresses" "team_b" {
+			zone_id = ibm_cbr_zone.cbr_zone.id
+			addresses {
+				type = "subnet"
+				value = "169.23.57.0/24"
+			}
+		}
+	`, teamAddress)
+}
+
+func testAccCheckIBMCbrZoneAddressesExists(n string, value string) resource.TestCheckFunc {
+
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		contextBasedRestrictionsClient, err := acc.TestAccProvider.Meta().(conns.ClientSession).ContextBasedRestrictionsV1()
+		if err != nil {
+			return err
+		}
+
+		getZoneOptions := &contextbasedrestrictionsv1.GetZoneOptions{}
+
+		getZoneOptions.SetZoneID(strings.Split(rs.Primary.ID, "/")[0])
+
+		zone, _, err := contextBasedRestrictionsClient.GetZone(getZoneOptions)
+		if err != nil {
+			return err
+		}
+
+		for _, address := range zone.Addresses {
+			switch address := address.(type) {
+			case *contextbasedrestrictionsv1.AddressIPAddress:
+				if *address.Value == value {
+					return nil
+				}
+			case *contextbasedrestrictionsv1.AddressSubnet:
+				if *address.Value == value {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("Address %s not found in cbr_zone %s", value, *zone.ID)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
 	"github.com/IBM/ibm-cos-sdk-go/aws"
 	"github.com/IBM/ibm-cos-sdk-go/aws/awserr"
+	"github.com/IBM/ibm-cos-sdk-go/aws/credentials"
 	"github.com/IBM/ibm-cos-sdk-go/aws/credentials/ibmiam"
 	token "github.com/IBM/ibm-cos-sdk-go/aws/credentials/ibmiam/token"
 	"github.com/IBM/ibm-cos-sdk-go/aws/session"
@@ -163,7 +164,7 @@ func resourceIBMCOSBucketObjectCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
-	s3Client, err := getS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
+	s3Client, err := GetS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -262,7 +263,7 @@ func resourceIBMCOSBucketObjectRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	s3Client, err := getS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
+	s3Client, err := GetS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -346,7 +347,7 @@ func resourceIBMCOSBucketObjectUpdate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
-	s3Client, err := getS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
+	s3Client, err := GetS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -441,7 +442,7 @@ func resourceIBMCOSBucketObjectDelete(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
-	s3Client, err := getS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
+	s3Client, err := GetS3Client(bxSession, bucketLocation, endpointType, instanceCRN)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -475,7 +476,9 @@ func getCosEndpoint(bucketLocation string, endpointType string) string {
 	return ""
 }
 
-func getS3Client(bxSession *bxsession.Session, bucketLocation string, endpointType string, instanceCRN string) (*s3.S3, error) {
+// GetS3Client returns a COS S3 client for the bucket location and endpoint
+// type, authenticated with the credentials of the session.
+func GetS3Client(bxSession *bxsession.Session, bucketLocation string, endpointType string, instanceCRN string) (*s3.S3, error) {
 	var s3Conf *aws.Config
 
 	apiEndpoint := getCosEndpoint(bucketLocation, endpointType)
@@ -510,6 +513,19 @@ func getS3Client(bxSession *bxsession.Session, bucketLocation string, endpointTy
 	return s3.New(s3Sess, s3Conf), nil
 }
 
+// GetS3HMACClient returns a COS S3 client for the bucket location and endpoint
+// type, authenticated with HMAC credentials.
+func GetS3HMACClient(bucketLocation string, endpointType string, accessKeyID string, secretAccessKey string) (*s3.S3, error) {
+	apiEndpoint := getCosEndpoint(bucketLocation, endpointType)
+	apiEndpoint = conns.EnvFallBack([]string{"IBMCLOUD_COS_ENDPOINT"}, apiEndpoint)
+	if apiEndpoint == "" {
+		return nil, fmt.Errorf("the endpoint doesn't exists for given location %s and endpoint type %s", bucketLocation, endpointType)
+	}
+	s3Conf := aws.NewConfig().WithEndpoint(apiEndpoint).WithRegion(bucketLocation).WithCredentials(credentials.NewStaticCredentials(accessKeyID, secretAccessKey, "")).WithS3ForcePathStyle(true)
+	s3Sess := session.Must(session.NewSession())
+	return s3.New(s3Sess, s3Conf), nil
+}
+
 // This is to prevent potential issues w/ binary files
 // and generally unprintable characters
 // See https://github.com/hashicorp/terraform/pull/3858#issuecomment-156856738
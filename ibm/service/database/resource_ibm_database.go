@@ -8,11 +8,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"net/url"
 	"os"
 	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -84,6 +86,7 @@ func ResourceIBMDatabaseInstance() *schema.Resource {
 
 		CustomizeDiff: customdiff.All(
 			resourceIBMDatabaseInstanceDiff,
+			resourceIBMDatabaseConfigurationDiff,
 			checkV5Groups),
 
 		Importer: &schema.ResourceImporter{},
@@ -173,7 +176,33 @@ func ResourceIBMDatabaseInstance() *schema.Resource {
 					}
 					return json
 				},
-				Description: "The configuration in JSON format",
+				Description:   "The configuration in JSON format",
+				ConflictsWith: []string{"configuration_settings"},
+			},
+			"configuration_settings": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"configuration"},
+				Description:   "The configuration settings, validated against the configuration schema of the deployment",
+			},
+			"configuration_defer_restart": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Do not apply configuration changes that require a restart of the database until this is set to false",
+			},
+			"configuration_restart_required": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The configuration settings of the last change that require a restart of the database",
+			},
+			"configuration_pending_restart": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The configuration settings that are not applied because they require a restart of the database and configuration_defer_restart is set",
 			},
 			"configuration_schema": {
 				Type:        schema.TypeString,
@@ -326,7 +355,7 @@ func ResourceIBMDatabaseInstance() *schema.Resource {
 							Type:         schema.TypeString,
 							Required:     true,
 							Sensitive:    true,
-							ValidateFunc: validation.StringLenBetween(10, 32),
+							ValidateFunc: validateDatabaseUserPassword(),
 						},
 						"type": {
 							Description:  "User type",
@@ -1243,55 +1272,6 @@ func resourceIBMDatabaseInstanceDiff(_ context.Context, diff *schema.ResourceDif
 		return fmt.Errorf("[ERROR] logical_replication_slot is only supported for databases-for-postgresql")
 	}
 
-	configJSON, configOk := diff.GetOk("configuration")
-
-	if configOk {
-		var rawConfig map[string]json.RawMessage
-		err = json.Unmarshal([]byte(configJSON.(string)), &rawConfig)
-		if err != nil {
-			return fmt.Errorf("[ERROR] configuration JSON invalid\n%s", err)
-		}
-
-		var unmarshalFn func(m map[string]json.RawMessage, result interface{}) (err error)
-
-		var configuration clouddatabasesv5.ConfigurationIntf = new(clouddatabasesv5.Configuration)
-
-		switch service {
-		case "databases-for-postgresql":
-			unmarshalFn = clouddatabasesv5.UnmarshalConfigurationPgConfiguration
-		case "databases-for-enterprisedb":
-			unmarshalFn = clouddatabasesv5.UnmarshalConfigurationPgConfiguration
-		case "databases-for-redis":
-			unmarshalFn = clouddatabasesv5.UnmarshalConfigurationRedisConfiguration
-		case "databases-for-mysql":
-			unmarshalFn = clouddatabasesv5.UnmarshalConfigurationMySQLConfiguration
-		case "messages-for-rabbitmq":
-			unmarshalFn = clouddatabasesv5.UnmarshalConfigurationRabbitMqConfiguration
-		default:
-			return fmt.Errorf("[ERROR] configuration is not supported for %s", service)
-		}
-
-		err = core.UnmarshalModel(rawConfig, "", &configuration, unmarshalFn)
-		if err != nil {
-			return fmt.Errorf("[ERROR] configuration is invalid\n%s", err)
-		}
-
-		b, _ := json.Marshal(configuration)
-		var result map[string]json.RawMessage
-		json.Unmarshal(b, &result)
-
-		invalidFields := []string{}
-		for k, _ := range rawConfig {
-			if _, ok := result[k]; !ok {
-				invalidFields = append(invalidFields, k)
-			}
-		}
-
-		if len(invalidFields) != 0 {
-			return fmt.Errorf("[ERROR] configuration contained invalid field(s): %s", invalidFields)
-		}
-	}
-
 	return nil
 }
 
@@ -1541,7 +1521,6 @@ func resourceIBMDatabaseInstanceCreate(context context.Context, d *schema.Resour
 	}
 
 	instanceID := *instance.ID
-	icdId := flex.EscapeUrlParm(instanceID)
 
 	if pw, ok := d.GetOk("adminpassword"); ok {
 		adminPassword := pw.(string)
@@ -1672,37 +1651,12 @@ func resourceIBMDatabaseInstanceCreate(context context.Context, d *schema.Resour
 		}
 	}
 
-	if config, ok := d.GetOk("configuration"); ok {
-		var rawConfig map[string]json.RawMessage
-		err = json.Unmarshal([]byte(config.(string)), &rawConfig)
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("[ERROR] configuration JSON invalid\n%s", err))
-		}
-
-		var configuration clouddatabasesv5.ConfigurationIntf = new(clouddatabasesv5.Configuration)
-		err = core.UnmarshalModel(rawConfig, "", &configuration, clouddatabasesv5.UnmarshalConfiguration)
-		if err != nil {
-			return diag.FromErr(fmt.Errorf("[ERROR] database configuration is invalid"))
-		}
-
-		updateDatabaseConfigurationOptions := &clouddatabasesv5.UpdateDatabaseConfigurationOptions{
-			ID:            &instanceID,
-			Configuration: configuration,
-		}
-
-		updateDatabaseConfigurationResponse, response, err := cloudDatabasesClient.UpdateDatabaseConfiguration(updateDatabaseConfigurationOptions)
-
+	_, configOk := d.GetOk("configuration")
+	_, settingsOk := d.GetOk("configuration_settings")
+	if configOk || settingsOk {
+		err = updateDatabaseConfiguration(d, meta, instanceID, true)
 		if err != nil {
-			return diag.FromErr(fmt.Errorf(
-				"[ERROR] Error updating database configuration failed %s\n%s", err, response))
-		}
-
-		taskID := *updateDatabaseConfigurationResponse.Task.ID
-
-		_, err = waitForDatabaseTaskComplete(taskID, d, meta, d.Timeout(schema.TimeoutUpdate))
-		if err != nil {
-			return diag.FromErr(fmt.Errorf(
-				"[ERROR] Error waiting for database (%s) configuration update task to complete: %s", icdId, err))
+			return diag.FromErr(err)
 		}
 	}
 
@@ -1945,6 +1899,9 @@ func resourceIBMDatabaseInstanceRead(context context.Context, d *schema.Resource
 			return diag.FromErr(fmt.Errorf("[ERROR] Error setting the database configuration schema: %s", err))
 		}
 	}
+
+	// Keep the default on import, the applied configuration is not returned by the API.
+	d.Set("configuration_defer_restart", d.Get("configuration_defer_restart").(bool))
 	return nil
 }
 
@@ -2014,39 +1971,10 @@ func resourceIBMDatabaseInstanceUpdate(context context.Context, d *schema.Resour
 		}
 	}
 
-	if d.HasChange("configuration") {
-		if config, ok := d.GetOk("configuration"); ok {
-			var rawConfig map[string]json.RawMessage
-			err = json.Unmarshal([]byte(config.(string)), &rawConfig)
-			if err != nil {
-				return diag.FromErr(fmt.Errorf("[ERROR] configuration JSON invalid\n%s", err))
-			}
-
-			var configuration clouddatabasesv5.ConfigurationIntf = new(clouddatabasesv5.Configuration)
-			err = core.UnmarshalModel(rawConfig, "", &configuration, clouddatabasesv5.UnmarshalConfiguration)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-
-			updateDatabaseConfigurationOptions := &clouddatabasesv5.UpdateDatabaseConfigurationOptions{
-				ID:            &instanceID,
-				Configuration: configuration,
-			}
-
-			updateDatabaseConfigurationResponse, response, err := cloudDatabasesClient.UpdateDatabaseConfiguration(updateDatabaseConfigurationOptions)
-
-			if err != nil {
-				return diag.FromErr(fmt.Errorf(
-					"[ERROR] Error updating database configuration failed %s\n%s", err, response))
-			}
-
-			taskID := *updateDatabaseConfigurationResponse.Task.ID
-
-			_, err = waitForDatabaseTaskComplete(taskID, d, meta, d.Timeout(schema.TimeoutUpdate))
-			if err != nil {
-				return diag.FromErr(fmt.Errorf(
-					"[ERROR] Error waiting for database (%s) configuration update task to complete: %s", icdId, err))
-			}
+	if d.HasChange("configuration") || d.HasChange("configuration_settings") || d.HasChange("configuration_defer_restart") {
+		err = updateDatabaseConfiguration(d, meta, instanceID, false)
+		if err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
@@ -3212,3 +3140,486 @@ func userUpdateCreate(userData map[string]interface{}, instanceID string, meta i
 
 	return nil
 }
+
+// databaseConfigurationProperty is a setting of the configuration schema of a
+// deployment, as returned by the configuration schema API.
+type databaseConfigurationProperty struct {
+	Type            string        `json:"type"`
+	Minimum         *float64      `json:"minimum,omitempty"`
+	Maximum         *float64      `json:"maximum,omitempty"`
+	Choices         []interface{} `json:"choices,omitempty"`
+	RequiresRestart bool          `json:"requires_restart"`
+}
+
+func parseDatabaseConfigurationSchema(configSchema string) (map[string]databaseConfigurationProperty, error) {
+	var rawSchema map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(configSchema), &rawSchema); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error parsing the database configuration schema: %s", err)
+	}
+	if s, ok := rawSchema["schema"]; ok {
+		rawSchema = map[string]json.RawMessage{}
+		if err := json.Unmarshal(s, &rawSchema); err != nil {
+			return nil, fmt.Errorf("[ERROR] Error parsing the database configuration schema: %s", err)
+		}
+	}
+
+	properties := map[string]databaseConfigurationProperty{}
+	for key, rawProperty := range rawSchema {
+		var property databaseConfigurationProperty
+		if err := json.Unmarshal(rawProperty, &property); err != nil {
+			return nil, fmt.Errorf("[ERROR] Error parsing the database configuration schema of %s: %s", key, err)
+		}
+		properties[key] = property
+	}
+	return properties, nil
+}
+
+// databaseConfigurationModelProperties returns the settings of the
+// configuration model of the service, with their type. The configuration schema
+// API needs a deployment, so a new deployment, and the services without a
+// configuration schema, are validated against the model of the service.
+func databaseConfigurationModelProperties(service string) map[string]databaseConfigurationProperty {
+	var model interface{}
+	switch service {
+	case "databases-for-postgresql", "databases-for-enterprisedb":
+		model = clouddatabasesv5.ConfigurationPgConfiguration{}
+	case "databases-for-redis":
+		model = clouddatabasesv5.ConfigurationRedisConfiguration{}
+	case "databases-for-mysql":
+		model = clouddatabasesv5.ConfigurationMySQLConfiguration{}
+	case "messages-for-rabbitmq":
+		model = clouddatabasesv5.ConfigurationRabbitMqConfiguration{}
+	default:
+		return nil
+	}
+
+	properties := map[string]databaseConfigurationProperty{}
+	modelType := reflect.TypeOf(model)
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		key := strings.Split(field.Tag.Get("json"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		switch fieldType.Kind() {
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			properties[key] = databaseConfigurationProperty{Type: "integer"}
+		case reflect.Float32, reflect.Float64:
+			properties[key] = databaseConfigurationProperty{Type: "number"}
+		case reflect.Bool:
+			properties[key] = databaseConfigurationProperty{Type: "boolean"}
+		case reflect.String:
+			properties[key] = databaseConfigurationProperty{Type: "string"}
+		}
+	}
+	return properties
+}
+
+// getDatabaseConfigurationProperties returns the configuration schema of the
+// deployment, or the configuration model of the service before it exists.
+func getDatabaseConfigurationProperties(d *schema.ResourceData, meta interface{}) (map[string]databaseConfigurationProperty, error) {
+	service := d.Get("service").(string)
+	if d.Id() == "" || (service != "databases-for-postgresql" && service != "databases-for-redis" && service != "databases-for-enterprisedb") {
+		return databaseConfigurationModelProperties(service), nil
+	}
+
+	icdClient, err := meta.(conns.ClientSession).ICDAPI()
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting database client settings: %s", err)
+	}
+	icdId := flex.EscapeUrlParm(d.Id())
+	configSchema, err := icdClient.Configurations().GetConfiguration(icdId)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting database (%s) configuration schema : %s", icdId, err)
+	}
+	s, err := json.Marshal(configSchema)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error marshalling the database configuration schema: %s", err)
+	}
+	return parseDatabaseConfigurationSchema(string(s))
+}
+
+// databaseConfigurationValue converts a value of configuration_settings to the
+// JSON type of the setting. Without a schema the type is inferred from the value.
+func databaseConfigurationValue(key, value string, property *databaseConfigurationProperty) (json.RawMessage, error) {
+	settingType := ""
+	if property != nil {
+		settingType = property.Type
+	}
+
+	switch settingType {
+	case "":
+		if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return json.RawMessage(value), nil
+		}
+		if b, err := strconv.ParseBool(value); err == nil && (value == "true" || value == "false") {
+			return json.Marshal(b)
+		}
+	case "integer":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an integer, got %q", key, value)
+		}
+		return json.Marshal(n)
+	case "float", "number":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number, got %q", key, value)
+		}
+		return json.Marshal(n)
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a boolean, got %q", key, value)
+		}
+		return json.Marshal(b)
+	case "choice":
+		for _, choice := range property.Choices {
+			if fmt.Sprint(choice) == value {
+				return json.Marshal(choice)
+			}
+		}
+	}
+	return json.Marshal(value)
+}
+
+// expandDatabaseConfiguration returns the configuration that is set by either
+// configuration or configuration_settings.
+func expandDatabaseConfiguration(configJSON string, settings map[string]interface{}, properties map[string]databaseConfigurationProperty) (map[string]json.RawMessage, error) {
+	config := map[string]json.RawMessage{}
+	if configJSON != "" {
+		if err := json.Unmarshal([]byte(configJSON), &config); err != nil {
+			return nil, fmt.Errorf("[ERROR] configuration JSON invalid\n%s", err)
+		}
+		return config, nil
+	}
+
+	invalidSettings := []string{}
+	for key, value := range settings {
+		var property *databaseConfigurationProperty
+		if p, ok := properties[key]; ok {
+			property = &p
+		}
+		rawValue, err := databaseConfigurationValue(key, value.(string), property)
+		if err != nil {
+			invalidSettings = append(invalidSettings, err.Error())
+			continue
+		}
+		config[key] = rawValue
+	}
+	if len(invalidSettings) != 0 {
+		sort.Strings(invalidSettings)
+		return nil, fmt.Errorf("[ERROR] configuration_settings is invalid:\n%s", strings.Join(invalidSettings, "\n"))
+	}
+	return config, nil
+}
+
+// validateDatabaseConfigurationFields checks that every setting of the
+// configuration is known to the configuration model of the service.
+func validateDatabaseConfigurationFields(service string, rawConfig map[string]json.RawMessage) error {
+	var unmarshalFn func(m map[string]json.RawMessage, result interface{}) (err error)
+
+	var configuration clouddatabasesv5.ConfigurationIntf = new(clouddatabasesv5.Configuration)
+
+	switch service {
+	case "databases-for-postgresql":
+		unmarshalFn = clouddatabasesv5.UnmarshalConfigurationPgConfiguration
+	case "databases-for-enterprisedb":
+		unmarshalFn = clouddatabasesv5.UnmarshalConfigurationPgConfiguration
+	case "databases-for-redis":
+		unmarshalFn = clouddatabasesv5.UnmarshalConfigurationRedisConfiguration
+	case "databases-for-mysql":
+		unmarshalFn = clouddatabasesv5.UnmarshalConfigurationMySQLConfiguration
+	case "messages-for-rabbitmq":
+		unmarshalFn = clouddatabasesv5.UnmarshalConfigurationRabbitMqConfiguration
+	default:
+		return fmt.Errorf("[ERROR] configuration is not supported for %s", service)
+	}
+
+	err := core.UnmarshalModel(rawConfig, "", &configuration, unmarshalFn)
+	if err != nil {
+		return fmt.Errorf("[ERROR] configuration is invalid\n%s", err)
+	}
+
+	b, _ := json.Marshal(configuration)
+	var result map[string]json.RawMessage
+	json.Unmarshal(b, &result)
+
+	invalidFields := []string{}
+	for k := range rawConfig {
+		if _, ok := result[k]; !ok {
+			invalidFields = append(invalidFields, k)
+		}
+	}
+
+	if len(invalidFields) != 0 {
+		sort.Strings(invalidFields)
+		return fmt.Errorf("[ERROR] configuration contained invalid field(s): %s", invalidFields)
+	}
+	return nil
+}
+
+// validateDatabaseConfiguration checks every setting against the configuration
+// schema of the deployment and reports all invalid settings at once.
+func validateDatabaseConfiguration(config map[string]json.RawMessage, properties map[string]databaseConfigurationProperty) error {
+	invalidSettings := []string{}
+	for key, rawValue := range config {
+		property, ok := properties[key]
+		if !ok {
+			invalidSettings = append(invalidSettings, fmt.Sprintf("%s is not a configuration setting of this deployment", key))
+			continue
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(rawValue, &value); err != nil {
+			invalidSettings = append(invalidSettings, fmt.Sprintf("%s is not valid JSON: %s", key, err))
+			continue
+		}
+
+		switch property.Type {
+		case "integer", "float", "number":
+			n, ok := value.(float64)
+			if !ok || (property.Type == "integer" && n != math.Trunc(n)) {
+				invalidSettings = append(invalidSettings, fmt.Sprintf("%s must be of type %s, got %s", key, property.Type, string(rawValue)))
+				continue
+			}
+			if (property.Minimum != nil && n < *property.Minimum) || (property.Maximum != nil && n > *property.Maximum) {
+				invalidSettings = append(invalidSettings, fmt.Sprintf("%s must be between %s and %s, got %s", key, databaseConfigurationBound(property.Minimum), databaseConfigurationBound(property.Maximum), string(rawValue)))
+			}
+		case "boolean":
+			if _, ok := value.(bool); !ok {
+				invalidSettings = append(invalidSettings, fmt.Sprintf("%s must be of type boolean, got %s", key, string(rawValue)))
+			}
+		case "choice":
+			valid := false
+			choices := make([]string, 0, len(property.Choices))
+			for _, choice := range property.Choices {
+				valid = valid || reflect.DeepEqual(choice, value)
+				choices = append(choices, fmt.Sprint(choice))
+			}
+			if !valid {
+				invalidSettings = append(invalidSettings, fmt.Sprintf("%s must be one of [%s], got %s", key, strings.Join(choices, ", "), string(rawValue)))
+			}
+		case "string":
+			if _, ok := value.(string); !ok {
+				invalidSettings = append(invalidSettings, fmt.Sprintf("%s must be of type string, got %s", key, string(rawValue)))
+			}
+		}
+	}
+
+	if len(invalidSettings) != 0 {
+		sort.Strings(invalidSettings)
+		return fmt.Errorf("[ERROR] configuration is invalid for this deployment:\n%s", strings.Join(invalidSettings, "\n"))
+	}
+	return nil
+}
+
+func databaseConfigurationBound(bound *float64) string {
+	if bound == nil {
+		return "unbounded"
+	}
+	return strconv.FormatFloat(*bound, 'f', -1, 64)
+}
+
+// databaseConfigurationChanges returns the settings whose value differs between
+// the old and the new configuration, and which of those require a restart.
+func databaseConfigurationChanges(oldConfig, newConfig map[string]json.RawMessage, properties map[string]databaseConfigurationProperty) (changed []string, restart []string) {
+	changed, restart = []string{}, []string{}
+	for key, rawValue := range newConfig {
+		if rawOldValue, ok := oldConfig[key]; ok {
+			var oldValue, newValue interface{}
+			json.Unmarshal(rawOldValue, &oldValue)
+			json.Unmarshal(rawValue, &newValue)
+			if reflect.DeepEqual(oldValue, newValue) {
+				continue
+			}
+		}
+		changed = append(changed, key)
+		if properties[key].RequiresRestart {
+			restart = append(restart, key)
+		}
+	}
+	sort.Strings(changed)
+	sort.Strings(restart)
+	return changed, restart
+}
+
+func resourceIBMDatabaseConfigurationDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) (err error) {
+	if !diff.NewValueKnown("configuration") || !diff.NewValueKnown("configuration_settings") {
+		return nil
+	}
+	_, configOk := diff.GetOk("configuration")
+	_, settingsOk := diff.GetOk("configuration_settings")
+	if !configOk && !settingsOk {
+		return nil
+	}
+
+	// The configuration schema of the deployment is known once it exists
+	properties := databaseConfigurationModelProperties(diff.Get("service").(string))
+	if configSchema := diff.Get("configuration_schema").(string); configSchema != "" {
+		properties, err = parseDatabaseConfigurationSchema(configSchema)
+		if err != nil {
+			return err
+		}
+	}
+
+	oldJSON, newJSON := diff.GetChange("configuration")
+	oldSettings, newSettings := diff.GetChange("configuration_settings")
+	newConfig, err := expandDatabaseConfiguration(newJSON.(string), newSettings.(map[string]interface{}), properties)
+	if err != nil {
+		return err
+	}
+
+	err = validateDatabaseConfigurationFields(diff.Get("service").(string), newConfig)
+	if err != nil {
+		return err
+	}
+
+	err = validateDatabaseConfiguration(newConfig, properties)
+	if err != nil {
+		return err
+	}
+
+	if !diff.HasChange("configuration") && !diff.HasChange("configuration_settings") && !diff.HasChange("configuration_defer_restart") {
+		return nil
+	}
+
+	oldConfig, err := expandDatabaseConfiguration(oldJSON.(string), oldSettings.(map[string]interface{}), properties)
+	if err != nil {
+		oldConfig = map[string]json.RawMessage{}
+	}
+	oldPending, _ := diff.GetChange("configuration_pending_restart")
+	_, restart := databaseConfigurationChanges(oldConfig, newConfig, properties)
+	_, restart = databaseConfigurationPending(flex.ExpandStringList(oldPending.([]interface{})), newConfig, nil, restart)
+	if len(restart) != 0 {
+		log.Printf("[INFO] The configuration settings %s of database (%s) require a restart", restart, diff.Id())
+	}
+
+	if diff.Get("configuration_defer_restart").(bool) {
+		err = diff.SetNew("configuration_pending_restart", restart)
+		if err == nil {
+			err = diff.SetNew("configuration_restart_required", []string{})
+		}
+	} else {
+		err = diff.SetNew("configuration_restart_required", restart)
+		if err == nil {
+			err = diff.SetNew("configuration_pending_restart", []string{})
+		}
+	}
+	return err
+}
+
+// databaseConfigurationPending adds the settings that are pending a restart to
+// the settings that changed and require a restart, as they are still to be
+// applied. The settings that are no longer configured are dropped.
+func databaseConfigurationPending(pending []string, newConfig map[string]json.RawMessage, changed, restart []string) ([]string, []string) {
+	for _, key := range pending {
+		if _, ok := newConfig[key]; !ok {
+			continue
+		}
+		if !flex.StringContains(restart, key) {
+			restart = append(restart, key)
+		}
+		if changed != nil && !flex.StringContains(changed, key) {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	sort.Strings(restart)
+	return changed, restart
+}
+
+// updateDatabaseConfiguration applies the settings of configuration or
+// configuration_settings that changed. With configuration_defer_restart, the
+// settings that require a restart are not applied and are reported in
+// configuration_pending_restart, until the flag is unset. The configuration in
+// state is the configured one, as the API does not return the applied one.
+func updateDatabaseConfiguration(d *schema.ResourceData, meta interface{}, instanceID string, isNew bool) error {
+	properties, err := getDatabaseConfigurationProperties(d, meta)
+	if err != nil {
+		return err
+	}
+
+	oldJSON, newJSON := d.GetChange("configuration")
+	oldSettings, newSettings := d.GetChange("configuration_settings")
+	newConfig, err := expandDatabaseConfiguration(newJSON.(string), newSettings.(map[string]interface{}), properties)
+	if err != nil {
+		return err
+	}
+	oldConfig := map[string]json.RawMessage{}
+	if !isNew {
+		oldConfig, err = expandDatabaseConfiguration(oldJSON.(string), oldSettings.(map[string]interface{}), properties)
+		if err != nil {
+			oldConfig = map[string]json.RawMessage{}
+		}
+	}
+
+	oldPending, _ := d.GetChange("configuration_pending_restart")
+	changed, restart := databaseConfigurationChanges(oldConfig, newConfig, properties)
+	changed, restart = databaseConfigurationPending(flex.ExpandStringList(oldPending.([]interface{})), newConfig, changed, restart)
+	pending := []string{}
+	if isNew {
+		// A new deployment has no workload yet, so nothing is deferred.
+		restart = []string{}
+	} else if d.Get("configuration_defer_restart").(bool) {
+		pending, restart = restart, []string{}
+	}
+
+	rawConfig := map[string]json.RawMessage{}
+	for _, key := range changed {
+		rawConfig[key] = newConfig[key]
+	}
+	for _, key := range pending {
+		delete(rawConfig, key)
+	}
+
+	if len(rawConfig) != 0 {
+		cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error getting database client settings: %s", err)
+		}
+
+		var configuration clouddatabasesv5.ConfigurationIntf = new(clouddatabasesv5.Configuration)
+		err = core.UnmarshalModel(rawConfig, "", &configuration, clouddatabasesv5.UnmarshalConfiguration)
+		if err != nil {
+			return fmt.Errorf("[ERROR] database configuration is invalid\n%s", err)
+		}
+
+		updateDatabaseConfigurationOptions := &clouddatabasesv5.UpdateDatabaseConfigurationOptions{
+			ID:            &instanceID,
+			Configuration: configuration,
+		}
+
+		updateDatabaseConfigurationResponse, response, err := cloudDatabasesClient.UpdateDatabaseConfiguration(updateDatabaseConfigurationOptions)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error updating database configuration failed %s\n%s", err, response)
+		}
+
+		taskID := *updateDatabaseConfigurationResponse.Task.ID
+
+		timeout := d.Timeout(schema.TimeoutUpdate)
+		if isNew {
+			timeout = d.Timeout(schema.TimeoutCreate)
+		}
+		_, err = waitForDatabaseTaskComplete(taskID, d, meta, timeout)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error waiting for database (%s) configuration update task to complete: %s", instanceID, err)
+		}
+	}
+
+	if len(pending) != 0 {
+		log.Printf("[INFO] Deferred the configuration settings %s of database (%s) that require a restart", pending, instanceID)
+	}
+
+	if err = d.Set("configuration_restart_required", restart); err != nil {
+		return fmt.Errorf("[ERROR] Error setting configuration_restart_required: %s", err)
+	}
+	if err = d.Set("configuration_pending_restart", pending); err != nil {
+		return fmt.Errorf("[ERROR] Error setting configuration_pending_restart: %s", err)
+	}
+	return nil
+}
@@ -444,6 +444,56 @@ func TestAccIBMDatabaseInstancePostgresImport(t *testing.T) {
 	})
 }
 
+func TestAccIBMDatabaseInstancePostgresConfigurationSettings(t *testing.T) {
+	t.Parallel()
+	databaseResourceGroup := "default"
+	var databaseInstanceOne string
+	serviceName := fmt.Sprintf("tf-Pgress-%d", acctest.RandIntRange(10, 100))
+	resourceName := "ibm_database." + serviceName
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMDatabaseInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseInstancePostgresConfigurationSettings(databaseResourceGroup, serviceName, "200", "1800", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIBMDatabaseInstanceExists(resourceName, &databaseInstanceOne),
+					resource.TestCheckResourceAttr(resourceName, "configuration_settings.max_connections", "200"),
+					resource.TestCheckResourceAttr(resourceName, "configuration_settings.archive_timeout", "1800"),
+					resource.TestCheckResourceAttr(resourceName, "configuration_restart_required.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "configuration_pending_restart.#", "0"),
+				),
+			},
+			{
+				// max_connections requires a restart, so only archive_timeout is applied.
+				Config: testAccCheckIBMDatabaseInstancePostgresConfigurationSettings(databaseResourceGroup, serviceName, "250", "3600", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "configuration_settings.max_connections", "200"),
+					resource.TestCheckResourceAttr(resourceName, "configuration_settings.archive_timeout", "3600"),
+					resource.TestCheckResourceAttr(resourceName, "configuration_pending_restart.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "configuration_pending_restart.0", "max_connections"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccCheckIBMDatabaseInstancePostgresConfigurationSettings(databaseResourceGroup, serviceName, "250", "3600", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "configuration_settings.max_connections", "250"),
+					resource.TestCheckResourceAttr(resourceName, "configuration_restart_required.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "configuration_restart_required.0", "max_connections"),
+					resource.TestCheckResourceAttr(resourceName, "configuration_pending_restart.#", "0"),
+				),
+			},
+			{
+				Config:      testAccCheckIBMDatabaseInstancePostgresConfigurationSettings(databaseResourceGroup, serviceName, "10", "3600", false),
+				ExpectError: regexp.MustCompile("max_connections must be between"),
+			},
+		},
+	})
+}
+
 func testAccCheckIBMDatabaseInstanceDestroy(s *terraform.State) error {
 	rsContClient, err := acc.TestAccProvider.Meta().(conns.ClientSession).ResourceControllerV2API()
 	if err != nil {
@@ -1094,3 +1144,25 @@ func testAccCheckIBMDatabaseInstancePostgresImport(databaseResourceGroup string,
 	  }
 				`, databaseResourceGroup, name, acc.IcdDbRegion)
 }
+
+func testAccCheckIBMDatabaseInstancePostgresConfigurationSettings(databaseResourceGroup string, name string, maxConnections string, archiveTimeout string, deferRestart bool) string {
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "test_acc" {
+		is_default = true
+		# name = "%[1]s"
+	}
+
+	resource "ibm_database" "%[2]s" {
+		resource_group_id           = data.ibm_resource_group.test_acc.id
+		name                        = "%[2]s"
+		service                     = "databases-for-postgresql"
+		plan                        = "standard"
+		location                    = "%[3]s"
+		configuration_defer_restart = %[6]t
+		configuration_settings = {
+			max_connections = "%[4]s"
+			archive_timeout = "%[5]s"
+		}
+	}
+				`, databaseResourceGroup, name, acc.IcdDbRegion, maxConnections, archiveTimeout, deferRestart)
+}
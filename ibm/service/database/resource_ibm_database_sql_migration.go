@@ -0,0 +1,638 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM/cloud-databases-go-sdk/clouddatabasesv5"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+const (
+	databaseSQLEnginePostgreSQL = "postgresql"
+	databaseSQLEngineMySQL      = "mysql"
+
+	databaseSQLTLSVerifyFull = "verify-full"
+	databaseSQLTLSRequire    = "require"
+	databaseSQLTLSDisable    = "disable"
+)
+
+var (
+	databaseSQLMigrationFileRegexp  = regexp.MustCompile(`^(\d+)_(.+?)(\.up)?\.sql$`)
+	databaseSQLMigrationTableRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+)
+
+// databaseSQLMigration is a migration file, or a row of the version table.
+type databaseSQLMigration struct {
+	Version  int64
+	Name     string
+	Checksum string
+	SQL      string
+}
+
+func ResourceIBMDatabaseSQLMigration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabaseSQLMigrationCreate,
+		ReadContext:   resourceIBMDatabaseSQLMigrationRead,
+		UpdateContext: resourceIBMDatabaseSQLMigrationUpdate,
+		DeleteContext: resourceIBMDatabaseSQLMigrationDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.All(
+			resourceIBMDatabaseSQLMigrationDiff,
+		),
+
+		Schema: map[string]*schema.Schema{
+			"deployment_id": {
+				Description: "The ID of the Cloud Databases deployment. The connection details are read from the deployment unless they are set",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"endpoint_type": {
+				Description:  "The endpoint of the deployment to connect to, public or private",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      clouddatabasesv5.GetConnectionOptionsEndpointTypePublicConst,
+				ValidateFunc: validation.StringInSlice([]string{clouddatabasesv5.GetConnectionOptionsEndpointTypePublicConst, clouddatabasesv5.GetConnectionOptionsEndpointTypePrivateConst}, false),
+			},
+			"engine": {
+				Description:  "The database engine, postgresql or mysql",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{databaseSQLEnginePostgreSQL, databaseSQLEngineMySQL}, false),
+			},
+			"host": {
+				Description: "The host name of the database",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"port": {
+				Description:  "The port of the database",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"database": {
+				Description: "The name of the database to migrate",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"ca_certificate": {
+				Description: "The CA certificate of the database, in PEM format or base64 encoded PEM",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"tls_mode": {
+				Description:  "How the connection is secured: verify-full, require or disable",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      databaseSQLTLSVerifyFull,
+				ValidateFunc: validation.StringInSlice([]string{databaseSQLTLSVerifyFull, databaseSQLTLSRequire, databaseSQLTLSDisable}, false),
+			},
+			"username": {
+				Description: "The user that applies the migrations",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"password": {
+				Description: "The password of the user",
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+			},
+			"migrations_dir": {
+				Description: "The directory of the migration files, named <version>_<name>.sql and applied in order of version",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"version_table": {
+				Description:  "The table that records the applied migrations",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "schema_migrations",
+				ValidateFunc: validation.StringMatch(databaseSQLMigrationTableRegexp, "must be a table name, optionally qualified with a schema name"),
+			},
+			"version": {
+				Description: "The version of the last applied migration",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"applied_migrations": {
+				Description: "The migrations that are applied to the database",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Description: "The version of the migration",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The name of the migration",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"checksum": {
+							Description: "The SHA-256 checksum of the migration file",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceIBMDatabaseSQLMigrationDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if _, ok := diff.GetOk("deployment_id"); !ok && diff.NewValueKnown("deployment_id") {
+		for _, key := range []string{"engine", "host", "database"} {
+			if _, ok := diff.GetOk(key); !ok && diff.NewValueKnown(key) {
+				return fmt.Errorf("[ERROR] %s must be set when deployment_id is not set", key)
+			}
+		}
+	}
+
+	if !diff.NewValueKnown("migrations_dir") {
+		return nil
+	}
+	migrations, err := readDatabaseSQLMigrations(diff.Get("migrations_dir").(string))
+	if err != nil {
+		return err
+	}
+
+	applied := map[int64]databaseSQLMigration{}
+	var appliedVersion int64
+	for _, item := range diff.Get("applied_migrations").([]interface{}) {
+		m := item.(map[string]interface{})
+		migration := databaseSQLMigration{
+			Version:  int64(m["version"].(int)),
+			Name:     m["name"].(string),
+			Checksum: m["checksum"].(string),
+		}
+		applied[migration.Version] = migration
+		if migration.Version > appliedVersion {
+			appliedVersion = migration.Version
+		}
+	}
+
+	pending := false
+	files := map[int64]bool{}
+	for _, migration := range migrations {
+		files[migration.Version] = true
+		if a, ok := applied[migration.Version]; ok {
+			if a.Checksum != migration.Checksum {
+				return fmt.Errorf("[ERROR] migration %d (%s) was changed after it was applied", migration.Version, migration.Name)
+			}
+			continue
+		}
+		if migration.Version < appliedVersion {
+			return fmt.Errorf("[ERROR] migration %d (%s) is older than the applied version %d", migration.Version, migration.Name, appliedVersion)
+		}
+		pending = true
+	}
+	for version, a := range applied {
+		if !files[version] {
+			return fmt.Errorf("[ERROR] migration %d (%s) was applied but is missing from %s", version, a.Name, diff.Get("migrations_dir").(string))
+		}
+	}
+
+	if !pending {
+		return nil
+	}
+	if err := diff.SetNew("applied_migrations", flattenDatabaseSQLMigrations(migrations)); err != nil {
+		return err
+	}
+	return diff.SetNew("version", int(migrations[len(migrations)-1].Version))
+}
+
+func resourceIBMDatabaseSQLMigrationCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := resolveDatabaseSQLMigrationConnection(context, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = applyDatabaseSQLMigrations(context, d, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	target := fmt.Sprintf("%s/%s", net.JoinHostPort(d.Get("host").(string), strconv.Itoa(d.Get("port").(int))), d.Get("database").(string))
+	if deploymentID, ok := d.GetOk("deployment_id"); ok {
+		target = deploymentID.(string)
+	}
+	d.SetId(fmt.Sprintf("%s/%s", target, d.Get("version_table").(string)))
+
+	return resourceIBMDatabaseSQLMigrationRead(context, d, meta)
+}
+
+func resourceIBMDatabaseSQLMigrationRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	db, err := openDatabaseSQLMigrationDB(d)
+	if err == nil {
+		defer db.Close()
+		err = db.PingContext(context)
+	}
+	if err != nil {
+		if deploymentID, ok := d.GetOk("deployment_id"); ok {
+			cloudDatabasesClient, clientErr := meta.(conns.ClientSession).CloudDatabasesV5()
+			if clientErr != nil {
+				return diag.FromErr(clientErr)
+			}
+			getDeploymentInfoOptions := &clouddatabasesv5.GetDeploymentInfoOptions{
+				ID: core.StringPtr(deploymentID.(string)),
+			}
+			_, response, infoErr := cloudDatabasesClient.GetDeploymentInfoWithContext(context, getDeploymentInfoOptions)
+			if infoErr != nil && response != nil && response.StatusCode == 404 {
+				log.Printf("[WARN] Database deployment (%s) not found, removing the SQL migrations from state", deploymentID)
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.FromErr(fmt.Errorf("[ERROR] Error connecting to database (%s): %s", d.Get("host").(string), err))
+	}
+
+	applied, err := queryDatabaseSQLMigrations(context, db, d.Get("version_table").(string))
+	if err != nil && !isDatabaseSQLMigrationTableMissing(err) {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error reading the applied migrations: %s", err))
+	}
+
+	var version int64
+	if len(applied) != 0 {
+		version = applied[len(applied)-1].Version
+	}
+	if err = d.Set("version", int(version)); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error setting version: %s", err))
+	}
+	if err = d.Set("applied_migrations", flattenDatabaseSQLMigrations(applied)); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error setting applied_migrations: %s", err))
+	}
+	return nil
+}
+
+func resourceIBMDatabaseSQLMigrationUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange("migrations_dir") || d.HasChange("applied_migrations") || d.HasChange("version") {
+		err := applyDatabaseSQLMigrations(context, d, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceIBMDatabaseSQLMigrationRead(context, d, meta)
+}
+
+// Migrations are not reverted, the resource is only removed from state.
+func resourceIBMDatabaseSQLMigrationDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// readDatabaseSQLMigrations reads the migration files of a directory, ordered by
+// version. Files named *.down.sql and files that are not SQL are ignored.
+func readDatabaseSQLMigrations(dir string) ([]databaseSQLMigration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error reading the migrations directory %s: %s", dir, err)
+	}
+
+	migrations := []databaseSQLMigration{}
+	versions := map[int64]string{}
+	for _, entry := range entries {
+		fileName := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(fileName, ".sql") || strings.HasSuffix(fileName, ".down.sql") {
+			continue
+		}
+		match := databaseSQLMigrationFileRegexp.FindStringSubmatch(fileName)
+		if match == nil {
+			return nil, fmt.Errorf("[ERROR] migration file %s must be named <version>_<name>.sql", fileName)
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error parsing the version of migration file %s: %s", fileName, err)
+		}
+		if other, ok := versions[version]; ok {
+			return nil, fmt.Errorf("[ERROR] migration files %s and %s have the same version %d", other, fileName, version)
+		}
+		versions[version] = fileName
+
+		content, err := os.ReadFile(filepath.Join(dir, fileName))
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error reading migration file %s: %s", fileName, err)
+		}
+		checksum := sha256.Sum256(content)
+		migrations = append(migrations, databaseSQLMigration{
+			Version:  version,
+			Name:     match[2],
+			Checksum: hex.EncodeToString(checksum[:]),
+			SQL:      string(content),
+		})
+	}
+
+	if len(migrations) == 0 {
+		return nil, fmt.Errorf("[ERROR] the migrations directory %s contains no migration files", dir)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+func flattenDatabaseSQLMigrations(migrations []databaseSQLMigration) []map[string]interface{} {
+	flattened := make([]map[string]interface{}, 0, len(migrations))
+	for _, migration := range migrations {
+		flattened = append(flattened, map[string]interface{}{
+			"version":  int(migration.Version),
+			"name":     migration.Name,
+			"checksum": migration.Checksum,
+		})
+	}
+	return flattened
+}
+
+// resolveDatabaseSQLMigrationConnection sets the connection details that are
+// not configured from the connection of the deployment.
+func resolveDatabaseSQLMigrationConnection(context context.Context, d *schema.ResourceData, meta interface{}) error {
+	deploymentID, ok := d.GetOk("deployment_id")
+	if !ok {
+		if _, ok := d.GetOk("port"); !ok {
+			if d.Get("engine").(string) == databaseSQLEngineMySQL {
+				d.Set("port", 3306)
+			} else {
+				d.Set("port", 5432)
+			}
+		}
+		return nil
+	}
+
+	cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+	if err != nil {
+		return err
+	}
+	getConnectionOptions := &clouddatabasesv5.GetConnectionOptions{
+		ID:           core.StringPtr(deploymentID.(string)),
+		UserType:     core.StringPtr(databaseUserTypeDatabase),
+		UserID:       core.StringPtr(d.Get("username").(string)),
+		EndpointType: core.StringPtr(d.Get("endpoint_type").(string)),
+	}
+	connection, response, err := cloudDatabasesClient.GetConnectionWithContext(context, getConnectionOptions)
+	if err != nil {
+		log.Printf("[DEBUG] GetConnectionWithContext failed %s\n%s", err, response)
+		return fmt.Errorf("[ERROR] GetConnectionWithContext failed %s\n%s", err, response)
+	}
+
+	var engine string
+	var hosts []clouddatabasesv5.ConnectionHost
+	var database *string
+	var certificate *clouddatabasesv5.ConnectionCertificate
+	if c, ok := connection.Connection.(*clouddatabasesv5.Connection); ok && c.Postgres != nil {
+		engine, hosts, database, certificate = databaseSQLEnginePostgreSQL, c.Postgres.Hosts, c.Postgres.Database, c.Postgres.Certificate
+	} else if ok && c.Mysql != nil {
+		engine, hosts, database, certificate = databaseSQLEngineMySQL, c.Mysql.Hosts, c.Mysql.Database, c.Mysql.Certificate
+	} else {
+		return fmt.Errorf("[ERROR] deployment %s is not a PostgreSQL or MySQL deployment", deploymentID)
+	}
+
+	config := d.GetRawConfig()
+	configured := func(key string) bool {
+		return !config.IsNull() && !config.GetAttr(key).IsNull()
+	}
+	if !configured("engine") {
+		d.Set("engine", engine)
+	}
+	if len(hosts) != 0 {
+		if !configured("host") && hosts[0].Hostname != nil {
+			d.Set("host", *hosts[0].Hostname)
+		}
+		if !configured("port") && hosts[0].Port != nil {
+			d.Set("port", int(*hosts[0].Port))
+		}
+	}
+	if !configured("database") && database != nil {
+		d.Set("database", *database)
+	}
+	if !configured("ca_certificate") && certificate != nil && certificate.CertificateBase64 != nil {
+		d.Set("ca_certificate", *certificate.CertificateBase64)
+	}
+	return nil
+}
+
+func openDatabaseSQLMigrationDB(d *schema.ResourceData) (*sql.DB, error) {
+	host := d.Get("host").(string)
+	address := net.JoinHostPort(host, strconv.Itoa(d.Get("port").(int)))
+	tlsMode := d.Get("tls_mode").(string)
+
+	caCertificate := d.Get("ca_certificate").(string)
+	if caCertificate != "" && !strings.Contains(caCertificate, "BEGIN CERTIFICATE") {
+		pem, err := base64.StdEncoding.DecodeString(caCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] ca_certificate is neither PEM nor base64 encoded PEM: %s", err)
+		}
+		caCertificate = string(pem)
+	}
+
+	switch d.Get("engine").(string) {
+	case databaseSQLEngineMySQL:
+		config := mysql.NewConfig()
+		config.User = d.Get("username").(string)
+		config.Passwd = d.Get("password").(string)
+		config.Net = "tcp"
+		config.Addr = address
+		config.DBName = d.Get("database").(string)
+		config.MultiStatements = true
+		switch tlsMode {
+		case databaseSQLTLSDisable:
+			config.TLSConfig = "false"
+		case databaseSQLTLSRequire:
+			config.TLSConfig = "skip-verify"
+		default:
+			tlsConfig := &tls.Config{ServerName: host}
+			if caCertificate != "" {
+				tlsConfig.RootCAs = x509.NewCertPool()
+				if !tlsConfig.RootCAs.AppendCertsFromPEM([]byte(caCertificate)) {
+					return nil, fmt.Errorf("[ERROR] ca_certificate does not contain a valid certificate")
+				}
+			}
+			config.TLSConfig = "ibm-database-sql-migration-" + address
+			if err := mysql.RegisterTLSConfig(config.TLSConfig, tlsConfig); err != nil {
+				return nil, err
+			}
+		}
+		return sql.Open("mysql", config.FormatDSN())
+	default:
+		query := url.Values{}
+		query.Set("sslmode", tlsMode)
+		query.Set("connect_timeout", "30")
+		if caCertificate != "" && tlsMode != databaseSQLTLSDisable {
+			query.Set("sslrootcert", caCertificate)
+			query.Set("sslinline", "true")
+		}
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(d.Get("username").(string), d.Get("password").(string)),
+			Host:     address,
+			Path:     "/" + d.Get("database").(string),
+			RawQuery: query.Encode(),
+		}
+		return sql.Open("postgres", dsn.String())
+	}
+}
+
+// applyDatabaseSQLMigrations applies the migrations that are not in the version
+// table, and records them, in a single transaction. MySQL commits DDL
+// statements implicitly, so there a failed migration is not rolled back.
+func applyDatabaseSQLMigrations(ctx context.Context, d *schema.ResourceData, timeout time.Duration) error {
+	migrations, err := readDatabaseSQLMigrations(d.Get("migrations_dir").(string))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	db, err := openDatabaseSQLMigrationDB(d)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	engine := d.Get("engine").(string)
+	table := d.Get("version_table").(string)
+	_, err = db.ExecContext(ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (version BIGINT PRIMARY KEY, name VARCHAR(255) NOT NULL, checksum CHAR(64) NOT NULL, applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP)", table))
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error creating the version table %s: %s", table, err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error starting the migration transaction: %s", err)
+	}
+	defer tx.Rollback()
+
+	// Concurrent runs wait for each other instead of applying a migration twice.
+	if engine == databaseSQLEnginePostgreSQL {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf("LOCK TABLE %s IN EXCLUSIVE MODE", table)); err != nil {
+			return fmt.Errorf("[ERROR] Error locking the version table %s: %s", table, err)
+		}
+	}
+
+	applied, err := queryDatabaseSQLMigrations(ctx, tx, table)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error reading the applied migrations: %s", err)
+	}
+	appliedChecksums := map[int64]string{}
+	var appliedVersion int64
+	for _, migration := range applied {
+		appliedChecksums[migration.Version] = migration.Checksum
+		appliedVersion = migration.Version
+	}
+
+	insert := fmt.Sprintf("INSERT INTO %s (version, name, checksum) VALUES ($1, $2, $3)", table)
+	if engine == databaseSQLEngineMySQL {
+		insert = fmt.Sprintf("INSERT INTO %s (version, name, checksum) VALUES (?, ?, ?)", table)
+	}
+
+	for _, migration := range migrations {
+		if checksum, ok := appliedChecksums[migration.Version]; ok {
+			if checksum != migration.Checksum {
+				return fmt.Errorf("[ERROR] migration %d (%s) was changed after it was applied", migration.Version, migration.Name)
+			}
+			continue
+		}
+		if migration.Version < appliedVersion {
+			return fmt.Errorf("[ERROR] migration %d (%s) is older than the applied version %d", migration.Version, migration.Name, appliedVersion)
+		}
+
+		log.Printf("[INFO] Applying migration %d (%s)", migration.Version, migration.Name)
+		if _, err = tx.ExecContext(ctx, migration.SQL); err != nil {
+			return fmt.Errorf("[ERROR] Error applying migration %d (%s): %s", migration.Version, migration.Name, err)
+		}
+		if _, err = tx.ExecContext(ctx, insert, migration.Version, migration.Name, migration.Checksum); err != nil {
+			return fmt.Errorf("[ERROR] Error recording migration %d (%s): %s", migration.Version, migration.Name, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("[ERROR] Error committing the migrations: %s", err)
+	}
+	return nil
+}
+
+type databaseSQLQueryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func queryDatabaseSQLMigrations(ctx context.Context, q databaseSQLQueryer, table string) ([]databaseSQLMigration, error) {
+	rows, err := q.QueryContext(ctx, fmt.Sprintf("SELECT version, name, checksum FROM %s ORDER BY version", table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	migrations := []databaseSQLMigration{}
+	for rows.Next() {
+		var migration databaseSQLMigration
+		if err = rows.Scan(&migration.Version, &migration.Name, &migration.Checksum); err != nil {
+			return nil, err
+		}
+		migration.Checksum = strings.TrimSpace(migration.Checksum)
+		migrations = append(migrations, migration)
+	}
+	return migrations, rows.Err()
+}
+
+func isDatabaseSQLMigrationTableMissing(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "42P01"
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1146
+	}
+	return false
+}
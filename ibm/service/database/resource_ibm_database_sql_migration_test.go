@@ -0,0 +1,80 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+)
+
+// The test runs against a PostgreSQL server without TLS, for example
+// `docker run -e POSTGRES_PASSWORD=postgres -p 5432:5432 postgres`.
+func TestAccIBMDatabaseSQLMigrationBasic(t *testing.T) {
+	migrationsDir := t.TempDir()
+	versionTable := fmt.Sprintf("tf_migrations_%d", acctest.RandIntRange(10, 10000))
+	name := "ibm_database_sql_migration.migration"
+
+	writeMigration := func(fileName, sql string) func() {
+		return func() {
+			if err := os.WriteFile(filepath.Join(migrationsDir, fileName), []byte(sql), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	writeMigration("001_create_schema.sql", fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s_app;", versionTable))()
+	writeMigration("002_create_widgets.sql", fmt.Sprintf("CREATE TABLE %[1]s_app.widgets (id SERIAL PRIMARY KEY, name TEXT NOT NULL);", versionTable))()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseSQLMigrationConfig(migrationsDir, versionTable),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "version", "2"),
+					resource.TestCheckResourceAttr(name, "applied_migrations.#", "2"),
+					resource.TestCheckResourceAttr(name, "applied_migrations.0.name", "create_schema"),
+					resource.TestCheckResourceAttr(name, "applied_migrations.1.name", "create_widgets"),
+				),
+			},
+			{
+				PreConfig: writeMigration("003_add_widget_color.sql", fmt.Sprintf("ALTER TABLE %s_app.widgets ADD COLUMN color TEXT;", versionTable)),
+				Config:    testAccCheckIBMDatabaseSQLMigrationConfig(migrationsDir, versionTable),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "version", "3"),
+					resource.TestCheckResourceAttr(name, "applied_migrations.#", "3"),
+					resource.TestCheckResourceAttr(name, "applied_migrations.2.name", "add_widget_color"),
+				),
+			},
+			{
+				PreConfig:   writeMigration("001_create_schema.sql", "CREATE SCHEMA IF NOT EXISTS changed;"),
+				Config:      testAccCheckIBMDatabaseSQLMigrationConfig(migrationsDir, versionTable),
+				ExpectError: regexp.MustCompile("was changed after it was applied"),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDatabaseSQLMigrationConfig(migrationsDir string, versionTable string) string {
+	return fmt.Sprintf(`
+	resource "ibm_database_sql_migration" "migration" {
+		engine         = "postgresql"
+		host           = "%[1]s"
+		database       = "postgres"
+		tls_mode       = "disable"
+		username       = "postgres"
+		password       = "%[2]s"
+		migrations_dir = "%[3]s"
+		version_table  = "%[4]s"
+	}
+	`, acc.IcdDbSqlMigrationHost, acc.IcdDbSqlMigrationPassword, migrationsDir, versionTable)
+}
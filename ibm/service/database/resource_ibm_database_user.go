@@ -0,0 +1,385 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM/cloud-databases-go-sdk/clouddatabasesv5"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+const (
+	databaseUserTypeDatabase        = "database"
+	databaseUserTypeOpsManager      = "ops_manager"
+	databaseUserTypeReadOnlyReplica = "read_only_replica"
+
+	databaseUserPasswordLength         = 32
+	databaseUserPasswordSpecialCharset = "~!@#$%^&*()=+[]{}|;:,.<>/?_-"
+)
+
+func ResourceIBMDatabaseUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMDatabaseUserCreate,
+		ReadContext:   resourceIBMDatabaseUserRead,
+		UpdateContext: resourceIBMDatabaseUserUpdate,
+		DeleteContext: resourceIBMDatabaseUserDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIBMDatabaseUserImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.All(
+			resourceIBMDatabaseUserDiff,
+		),
+
+		Schema: map[string]*schema.Schema{
+			"deployment_id": {
+				Description: "The ID of the Cloud Databases deployment",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description:  "User name",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.All(validation.StringLenBetween(4, 32), validation.StringDoesNotContainAny("/")),
+			},
+			"type": {
+				Description:  "User type",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      databaseUserTypeDatabase,
+				ValidateFunc: validation.StringInSlice([]string{databaseUserTypeDatabase, databaseUserTypeOpsManager, databaseUserTypeReadOnlyReplica}, false),
+			},
+			"password": {
+				Description:  "User password. A password is generated if not set",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Sensitive:    true,
+				ValidateFunc: validateDatabaseUserPassword(),
+			},
+			"role": {
+				Description:  "User role. Only available for ops_manager user type.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"group_read_only", "group_data_access_admin"}, false),
+			},
+			"rotation_trigger": {
+				Description: "An arbitrary value that rotates the password when it changes. A generated password is replaced by a new one, a configured password is set again",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func resourceIBMDatabaseUserDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	userType := diff.Get("type").(string)
+
+	if role, ok := diff.GetOk("role"); ok && userType != databaseUserTypeOpsManager {
+		return fmt.Errorf("[ERROR] role %s is only supported for the %s user type", role, databaseUserTypeOpsManager)
+	}
+
+	// A generated password is replaced when the rotation trigger changes.
+	if diff.HasChange("rotation_trigger") && diff.Id() != "" {
+		if raw := diff.GetRawConfig(); !raw.IsNull() && raw.GetAttr("password").IsNull() {
+			if err := diff.SetNewComputed("password"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateDatabaseUserPassword validates the password of a user of a
+// deployment, for ibm_database_user and the users of ibm_database alike. The
+// password policy of the user type is validated by Cloud Databases.
+func validateDatabaseUserPassword() schema.SchemaValidateFunc {
+	return validation.StringLenBetween(10, 32)
+}
+
+// generateDatabaseUserPassword generates a password that starts with a letter
+// and contains a number, and a special character for ops_manager users.
+func generateDatabaseUserPassword(userType string) (string, error) {
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	const digits = "0123456789"
+	charset := letters + digits
+	if userType == databaseUserTypeOpsManager {
+		charset += databaseUserPasswordSpecialCharset
+	}
+
+	pick := func(set string) (byte, error) {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(set))))
+		if err != nil {
+			return 0, err
+		}
+		return set[n.Int64()], nil
+	}
+
+	for {
+		password := make([]byte, databaseUserPasswordLength)
+		for i := range password {
+			set := charset
+			if i == 0 {
+				set = letters
+			}
+			c, err := pick(set)
+			if err != nil {
+				return "", fmt.Errorf("[ERROR] Error generating password: %s", err)
+			}
+			password[i] = c
+		}
+		if !strings.ContainsAny(string(password), digits) {
+			continue
+		}
+		if userType == databaseUserTypeOpsManager && !strings.ContainsAny(string(password), databaseUserPasswordSpecialCharset) {
+			continue
+		}
+		return string(password), nil
+	}
+}
+
+// The ID of a user is <deployment_id>/<type>/<name>. Deployment IDs are CRNs,
+// which contain `/` themselves, so the ID is parsed from the end.
+func parseDatabaseUserID(id string) (deploymentID, userType, name string, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) < 3 {
+		return "", "", "", fmt.Errorf("[ERROR] Incorrect ID %s: Id should be a combination of deploymentID/userType/userName", id)
+	}
+	return strings.Join(parts[:len(parts)-2], "/"), parts[len(parts)-2], parts[len(parts)-1], nil
+}
+
+// Users can be imported as <deployment_id>/<type>/<name>, or as
+// <deployment_id>/<name> for database users.
+func resourceIBMDatabaseUserImport(context context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	deploymentID, userType, name, err := parseDatabaseUserID(d.Id())
+	switch {
+	case err == nil && (userType == databaseUserTypeDatabase || userType == databaseUserTypeOpsManager || userType == databaseUserTypeReadOnlyReplica):
+	default:
+		index := strings.LastIndex(d.Id(), "/")
+		if index <= 0 {
+			return nil, fmt.Errorf("[ERROR] Incorrect ID %s: Id should be a combination of deploymentID/userType/userName or deploymentID/userName", d.Id())
+		}
+		deploymentID, userType, name = d.Id()[:index], databaseUserTypeDatabase, d.Id()[index+1:]
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", deploymentID, userType, name))
+	d.Set("deployment_id", deploymentID)
+	d.Set("type", userType)
+	d.Set("name", name)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceIBMDatabaseUserCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deploymentID := d.Get("deployment_id").(string)
+	userType := d.Get("type").(string)
+	password := d.Get("password").(string)
+	if password == "" {
+		password, err = generateDatabaseUserPassword(userType)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	userEntry := &clouddatabasesv5.User{
+		Username: core.StringPtr(d.Get("name").(string)),
+		Password: core.StringPtr(password),
+	}
+	if role, ok := d.GetOk("role"); ok {
+		userEntry.Role = core.StringPtr(role.(string))
+	}
+
+	createDatabaseUserOptions := &clouddatabasesv5.CreateDatabaseUserOptions{
+		ID:       &deploymentID,
+		UserType: &userType,
+		User:     userEntry,
+	}
+
+	createDatabaseUserResponse, response, err := cloudDatabasesClient.CreateDatabaseUserWithContext(context, createDatabaseUserOptions)
+	if err != nil {
+		log.Printf("[DEBUG] CreateDatabaseUserWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("[ERROR] CreateDatabaseUser (%s) failed %s\n%s", *userEntry.Username, err, response))
+	}
+
+	taskID := *createDatabaseUserResponse.Task.ID
+	_, err = waitForDatabaseTaskComplete(taskID, d, meta, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(
+			"[ERROR] Error waiting for database (%s) user (%s) create task to complete: %s", deploymentID, *userEntry.Username, err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", deploymentID, userType, *userEntry.Username))
+	d.Set("password", password)
+
+	return resourceIBMDatabaseUserRead(context, d, meta)
+}
+
+// Users cannot be read from the Cloud Databases API, so their existence is
+// checked with the connection information of the user, which is not found
+// once the user is deleted. The password is kept as last applied.
+func resourceIBMDatabaseUserRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deploymentID, userType, name, err := parseDatabaseUserID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	getDeploymentInfoOptions := &clouddatabasesv5.GetDeploymentInfoOptions{
+		ID: core.StringPtr(deploymentID),
+	}
+	deploymentInfo, response, err := cloudDatabasesClient.GetDeploymentInfoWithContext(context, getDeploymentInfoOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			log.Printf("[WARN] Database deployment (%s) not found, removing user (%s) from state", deploymentID, name)
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] GetDeploymentInfoWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database deployment (%s): %s\n%s", deploymentID, err, response))
+	}
+
+	endpointType := clouddatabasesv5.GetConnectionOptionsEndpointTypePublicConst
+	if deploymentInfo.Deployment != nil && deploymentInfo.Deployment.EnablePublicEndpoints != nil && !*deploymentInfo.Deployment.EnablePublicEndpoints {
+		endpointType = clouddatabasesv5.GetConnectionOptionsEndpointTypePrivateConst
+	}
+	getConnectionOptions := cloudDatabasesClient.NewGetConnectionOptions(deploymentID, userType, name, endpointType)
+	_, response, err = cloudDatabasesClient.GetConnectionWithContext(context, getConnectionOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			log.Printf("[WARN] Database user (%s) of deployment (%s) not found, removing it from state", name, deploymentID)
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] GetConnectionWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting database user (%s) of deployment (%s): %s\n%s", name, deploymentID, err, response))
+	}
+
+	d.Set("deployment_id", deploymentID)
+	d.Set("type", userType)
+	d.Set("name", name)
+
+	return nil
+}
+
+func resourceIBMDatabaseUserUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChange("password") && !d.HasChange("rotation_trigger") {
+		return resourceIBMDatabaseUserRead(context, d, meta)
+	}
+
+	cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deploymentID, userType, name, err := parseDatabaseUserID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	password := d.Get("password").(string)
+	if password == "" {
+		password, err = generateDatabaseUserPassword(userType)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	changeUserPasswordOptions := &clouddatabasesv5.ChangeUserPasswordOptions{
+		ID:       &deploymentID,
+		UserType: &userType,
+		Username: &name,
+		User: &clouddatabasesv5.APasswordSettingUser{
+			Password: core.StringPtr(password),
+		},
+	}
+
+	changeUserPasswordResponse, response, err := cloudDatabasesClient.ChangeUserPasswordWithContext(context, changeUserPasswordOptions)
+	if err != nil {
+		log.Printf("[DEBUG] ChangeUserPasswordWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("[ERROR] ChangeUserPassword (%s) failed %s\n%s", name, err, response))
+	}
+
+	taskID := *changeUserPasswordResponse.Task.ID
+	_, err = waitForDatabaseTaskComplete(taskID, d, meta, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(
+			"[ERROR] Error waiting for database (%s) user (%s) password update task to complete: %s", deploymentID, name, err))
+	}
+
+	d.Set("password", password)
+
+	return resourceIBMDatabaseUserRead(context, d, meta)
+}
+
+func resourceIBMDatabaseUserDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cloudDatabasesClient, err := meta.(conns.ClientSession).CloudDatabasesV5()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deploymentID, userType, name, err := parseDatabaseUserID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deleteDatabaseUserOptions := &clouddatabasesv5.DeleteDatabaseUserOptions{
+		ID:       &deploymentID,
+		UserType: &userType,
+		Username: &name,
+	}
+
+	deleteDatabaseUserResponse, response, err := cloudDatabasesClient.DeleteDatabaseUserWithContext(context, deleteDatabaseUserOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] DeleteDatabaseUserWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("[ERROR] DeleteDatabaseUser (%s) failed %s\n%s", name, err, response))
+	}
+
+	taskID := *deleteDatabaseUserResponse.Task.ID
+	_, err = waitForDatabaseTaskComplete(taskID, d, meta, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf(
+			"[ERROR] Error waiting for database (%s) user (%s) delete task to complete: %s", deploymentID, name, err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
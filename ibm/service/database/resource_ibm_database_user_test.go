@@ -0,0 +1,89 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package database_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+)
+
+func TestAccIBMDatabaseUserBasic(t *testing.T) {
+	testName := fmt.Sprintf("tf-Pgress-%s", acctest.RandString(16))
+	name := "ibm_database_user.user"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDatabaseUserConfig(testName, "password = \"secure-Password12\"", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name, "deployment_id", "ibm_database.db", "id"),
+					resource.TestCheckResourceAttr(name, "name", "appuser"),
+					resource.TestCheckResourceAttr(name, "type", "database"),
+					resource.TestCheckResourceAttr(name, "password", "secure-Password12"),
+				),
+			},
+			{
+				// Without a password, a password is generated and rotated with rotation_trigger.
+				Config: testAccCheckIBMDatabaseUserConfig(testName, "", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "rotation_trigger", "2"),
+					resource.TestCheckResourceAttrSet(name, "password"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "rotation_trigger"},
+			},
+		},
+	})
+}
+
+func TestAccIBMDatabaseUserInvalidPassword(t *testing.T) {
+	testName := fmt.Sprintf("tf-Pgress-%s", acctest.RandString(16))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckIBMDatabaseUserConfig(testName, "password = \"short1\"", "1"),
+				ExpectError: regexp.MustCompile(`to be in the range \(10 - 32\)`),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDatabaseUserConfig(name string, password string, rotationTrigger string) string {
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "test_acc" {
+		is_default = true
+	}
+
+	resource "ibm_database" "db" {
+		resource_group_id = data.ibm_resource_group.test_acc.id
+		name              = "%[1]s"
+		service           = "databases-for-postgresql"
+		plan              = "standard"
+		location          = "%[2]s"
+	}
+
+	resource "ibm_database_user" "user" {
+		deployment_id    = ibm_database.db.id
+		name             = "appuser"
+		type             = "database"
+		rotation_trigger = "%[4]s"
+		%[3]s
+	}
+	`, name, acc.IcdDbRegion, password, rotationTrigger)
+}
@@ -0,0 +1,375 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package eventnotification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	en "github.com/IBM/event-notifications-go-admin-sdk/eventnotificationsv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+func ResourceIBMEnNotification() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMEnNotificationCreate,
+		ReadContext:   resourceIBMEnNotificationRead,
+		UpdateContext: resourceIBMEnNotificationUpdate,
+		DeleteContext: resourceIBMEnNotificationDelete,
+		CustomizeDiff: resourceIBMEnNotificationDiff,
+
+		Schema: map[string]*schema.Schema{
+			"instance_guid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Unique identifier for IBM Cloud Event Notifications instance.",
+			},
+			"source_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The Event Notifications source ID that the notification is sent from.",
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The CloudEvents source of the notification.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The CloudEvents type of the notification, matched by the event type filters of the topic rules.",
+			},
+			"severity": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The severity of the notification.",
+			},
+			"subject": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The subject of the notification.",
+			},
+			"default_short": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Short description of the notification, used by destinations that do not use the data of the notification.",
+			},
+			"default_long": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Long description of the notification.",
+			},
+			"data": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: flex.SuppressEquivalentJSON,
+				Description:      "The payload of the notification as a JSON object.",
+			},
+			"topic_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The topic that the notification is expected to reach. The topic must route the source and the type with an enabled rule.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values that, when changed, sends the notification again.",
+			},
+			"notification_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the last notification returned by Event Notifications.",
+			},
+			"event_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The CloudEvents ID of the last notification.",
+			},
+			"sent_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the last notification was sent.",
+			},
+			"routes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The subscriptions of `topic_id` that the notification is sent to.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subscription_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the subscription.",
+						},
+						"subscription_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the subscription.",
+						},
+						"destination_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the destination.",
+						},
+						"destination_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the destination.",
+						},
+						"destination_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the destination.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceIBMEnNotificationDiff marks the computed values of the notification
+// unknown when a change of the payload sends it again.
+func resourceIBMEnNotificationDiff(context context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	if diff.HasChanges("source_id", "source", "type", "severity", "subject", "default_short", "default_long", "data", "topic_id", "triggers") {
+		for _, key := range []string{"notification_id", "event_id", "sent_at", "routes"} {
+			if err := diff.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceIBMEnNotificationCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	notificationID, err := enSendNotification(context, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("instance_guid").(string), notificationID))
+
+	return resourceIBMEnNotificationRead(context, d, meta)
+}
+
+func resourceIBMEnNotificationRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Event Notifications does not keep sent notifications, the state holds
+	// the last notification that was sent.
+	return nil
+}
+
+func resourceIBMEnNotificationUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	notificationID, err := enSendNotification(context, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("instance_guid").(string), notificationID))
+
+	return resourceIBMEnNotificationRead(context, d, meta)
+}
+
+func resourceIBMEnNotificationDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
+
+// enSendNotification verifies the route to topic_id when it is set, sends the
+// notification and returns its ID.
+func enSendNotification(context context.Context, d *schema.ResourceData, meta interface{}) (string, error) {
+	enClient, err := meta.(conns.ClientSession).EventNotificationsApiV1()
+	if err != nil {
+		return "", err
+	}
+
+	instanceID := d.Get("instance_guid").(string)
+	sourceID := d.Get("source_id").(string)
+
+	routes := []interface{}{}
+
+	if topicID, ok := d.GetOk("topic_id"); ok {
+		routes, err = enNotificationRoutes(context, enClient, instanceID, topicID.(string), sourceID, d.Get("type").(string))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	eventID := uuid.New().String()
+	sentAt := strfmt.DateTime(time.Now().UTC())
+
+	body := &en.NotificationCreate{
+		Specversion:       core.StringPtr("1.0"),
+		ID:                core.StringPtr(eventID),
+		Time:              &sentAt,
+		Source:            core.StringPtr(d.Get("source").(string)),
+		Type:              core.StringPtr(d.Get("type").(string)),
+		Ibmensourceid:     core.StringPtr(sourceID),
+		Ibmendefaultshort: core.StringPtr(d.Get("default_short").(string)),
+		Ibmendefaultlong:  core.StringPtr(d.Get("default_long").(string)),
+	}
+
+	if severity, ok := d.GetOk("severity"); ok {
+		body.Ibmenseverity = core.StringPtr(severity.(string))
+	}
+
+	if subject, ok := d.GetOk("subject"); ok {
+		body.Subject = core.StringPtr(subject.(string))
+	}
+
+	if data, ok := d.GetOk("data"); ok {
+		payload := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(data.(string)), &payload); err != nil {
+			return "", fmt.Errorf("data must be a JSON object: %s", err)
+		}
+		body.Data = payload
+		body.Datacontenttype = core.StringPtr("application/json")
+	}
+
+	options := &en.SendNotificationsOptions{}
+	options.SetInstanceID(instanceID)
+	options.SetBody(body)
+
+	result, response, err := enClient.SendNotificationsWithContext(context, options)
+	if err != nil {
+		return "", fmt.Errorf("SendNotificationsWithContext failed %s\n%s", err, response)
+	}
+
+	notificationID := eventID
+	if result.NotificationID != nil {
+		notificationID = *result.NotificationID
+	}
+
+	d.Set("notification_id", notificationID)
+	d.Set("event_id", eventID)
+	d.Set("sent_at", sentAt.String())
+
+	if err := d.Set("routes", routes); err != nil {
+		return "", fmt.Errorf("[ERROR] Error setting routes %s", err)
+	}
+
+	return notificationID, nil
+}
+
+// enNotificationRoutes returns the subscriptions of the topic. It fails when
+// no enabled rule of the topic routes the source and the event type, or the
+// topic has no subscription, as the notification would not reach any
+// destination. Rules with a filter that cannot be evaluated locally are
+// skipped, another rule of the source may still route the event.
+func enNotificationRoutes(context context.Context, enClient *en.EventNotificationsV1, instanceID, topicID, sourceID, eventType string) ([]interface{}, error) {
+	options := &en.GetTopicOptions{}
+
+	options.SetInstanceID(instanceID)
+	options.SetID(topicID)
+	options.SetInclude("subscriptions")
+
+	topic, response, err := enClient.GetTopicWithContext(context, options)
+	if err != nil {
+		return nil, fmt.Errorf("GetTopicWithContext failed %s\n%s", err, response)
+	}
+
+	routed := false
+	filters := []string{}
+	unsupported := []string{}
+	for _, source := range topic.Sources {
+		if source.ID == nil || *source.ID != sourceID {
+			continue
+		}
+		for _, rule := range source.Rules {
+			if rule.Enabled != nil && !*rule.Enabled {
+				continue
+			}
+			filter := core.StringNilMapper(rule.EventTypeFilter)
+			matched, err := enEventTypeFilterMatches(filter, eventType)
+			if err != nil {
+				log.Printf("[WARN] Skipping a rule of topic %s for source %s: %s", topicID, sourceID, err)
+				unsupported = append(unsupported, fmt.Sprintf("%q", filter))
+				continue
+			}
+			if matched {
+				routed = true
+			}
+			filters = append(filters, fmt.Sprintf("%q", filter))
+		}
+	}
+
+	if !routed {
+		if len(filters) == 0 && len(unsupported) == 0 {
+			return nil, fmt.Errorf("topic %s has no enabled rule for source %s, the notification would not reach the topic", topicID, sourceID)
+		}
+		if len(unsupported) > 0 {
+			return nil, fmt.Errorf("no enabled rule of topic %s for source %s matches the event type %q, the event type filters are [%s] and the filters that could not be evaluated are [%s]", topicID, sourceID, eventType, strings.Join(filters, ", "), strings.Join(unsupported, ", "))
+		}
+		return nil, fmt.Errorf("no enabled rule of topic %s for source %s matches the event type %q, the event type filters are %s", topicID, sourceID, eventType, strings.Join(filters, ", "))
+	}
+
+	if len(topic.Subscriptions) == 0 {
+		return nil, fmt.Errorf("topic %s has no subscriptions, the notification would not reach any destination", topicID)
+	}
+
+	routes := make([]interface{}, 0, len(topic.Subscriptions))
+	for _, subscription := range topic.Subscriptions {
+		routesMap := map[string]interface{}{}
+
+		if subscription.ID != nil {
+			routesMap["subscription_id"] = subscription.ID
+		}
+		if subscription.Name != nil {
+			routesMap["subscription_name"] = subscription.Name
+		}
+		if subscription.DestinationID != nil {
+			routesMap["destination_id"] = subscription.DestinationID
+		}
+		if subscription.DestinationName != nil {
+			routesMap["destination_name"] = subscription.DestinationName
+		}
+		if subscription.DestinationType != nil {
+			routesMap["destination_type"] = subscription.DestinationType
+		}
+
+		routes = append(routes, routesMap)
+	}
+
+	return routes, nil
+}
+
+// enEventTypeFilterRegexp matches the event type filters that Event
+// Notifications accepts besides `$.*`, which compare the event type of the
+// notification with a value.
+var enEventTypeFilterRegexp = regexp.MustCompile(`^\$\.notification_event_info\.event_type\s*==\s*'([^']*)'$`)
+
+// enEventTypeFilterMatches reports whether the event type filter of a topic
+// rule matches the type of a notification.
+func enEventTypeFilterMatches(filter, eventType string) (bool, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "$.*" {
+		return true, nil
+	}
+	match := enEventTypeFilterRegexp.FindStringSubmatch(filter)
+	if match == nil {
+		return false, fmt.Errorf("the event type filter %q cannot be evaluated, supported filters are $.* and $.notification_event_info.event_type == '<type>'", filter)
+	}
+	return match[1] == eventType, nil
+}
@@ -0,0 +1,129 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package eventnotification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	en "github.com/IBM/event-notifications-go-admin-sdk/eventnotificationsv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+func TestEnEventTypeFilterMatches(t *testing.T) {
+	testCases := []struct {
+		filter  string
+		matched bool
+		err     bool
+	}{
+		{filter: "$.*", matched: true},
+		{filter: " $.* ", matched: true},
+		{filter: "$.notification_event_info.event_type == 'com.example.smoke-test'", matched: true},
+		{filter: "$.notification_event_info.event_type=='com.example.smoke-test'", matched: true},
+		{filter: "$.notification_event_info.event_type == 'cert_manager'", matched: false},
+		{filter: "$.notification_event_info.event_type == 'com.example'", matched: false},
+		{filter: "$.notification.severity == 'LOW'", err: true},
+		{filter: "$.notification_event_info.event_type", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.filter, func(t *testing.T) {
+			matched, err := enEventTypeFilterMatches(tc.filter, "com.example.smoke-test")
+			if (err != nil) != tc.err {
+				t.Fatalf("got error %v, want error %t", err, tc.err)
+			}
+			if matched != tc.matched {
+				t.Fatalf("got %t, want %t", matched, tc.matched)
+			}
+		})
+	}
+}
+
+func TestEnNotificationRoutes(t *testing.T) {
+	topic := map[string]interface{}{
+		"id":   "topic-1",
+		"name": "alerts",
+		"sources": []interface{}{
+			map[string]interface{}{
+				"id": "other-source",
+				"rules": []interface{}{
+					map[string]interface{}{"id": "r0", "enabled": true, "event_type_filter": "$.*"},
+				},
+			},
+			map[string]interface{}{
+				"id": "source-1",
+				"rules": []interface{}{
+					map[string]interface{}{"id": "r1", "enabled": false, "event_type_filter": "$.*"},
+					map[string]interface{}{"id": "r2", "enabled": true, "event_type_filter": "$.notification_event_info.event_type == 'deploy'"},
+				},
+			},
+			map[string]interface{}{
+				"id": "source-3",
+				"rules": []interface{}{
+					map[string]interface{}{"id": "r3", "enabled": true, "event_type_filter": "$.notification.severity == 'LOW'"},
+					map[string]interface{}{"id": "r4", "enabled": true, "event_type_filter": "$.*"},
+				},
+			},
+			map[string]interface{}{
+				"id": "source-4",
+				"rules": []interface{}{
+					map[string]interface{}{"id": "r5", "enabled": true, "event_type_filter": "$.notification.severity == 'LOW'"},
+				},
+			},
+		},
+		"subscriptions": []interface{}{
+			map[string]interface{}{"id": "sub-1", "name": "slack", "destination_id": "dest-1", "destination_name": "team", "destination_type": "slack"},
+		},
+	}
+
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(topic)
+	}))
+	defer server.Close()
+
+	enClient, err := en.NewEventNotificationsV1(&en.EventNotificationsV1Options{URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	routes, err := enNotificationRoutes(context.Background(), enClient, "instance", "topic-1", "source-1", "deploy")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if query != "include=subscriptions" {
+		t.Fatalf("got query %q, want include=subscriptions", query)
+	}
+	if len(routes) != 1 || *routes[0].(map[string]interface{})["destination_name"].(*string) != "team" {
+		t.Fatalf("got routes %v, want the subscription to team", routes)
+	}
+
+	// The enabled rule of the source does not match the type, and the
+	// disabled rule that would match is ignored.
+	_, err = enNotificationRoutes(context.Background(), enClient, "instance", "topic-1", "source-1", "build")
+	if err == nil || !strings.Contains(err.Error(), `matches the event type "build"`) {
+		t.Fatalf("got error %v, want an error about the event type", err)
+	}
+
+	// A filter that cannot be evaluated is skipped when another rule of the
+	// source routes the event, and reported when none does.
+	if _, err := enNotificationRoutes(context.Background(), enClient, "instance", "topic-1", "source-3", "deploy"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = enNotificationRoutes(context.Background(), enClient, "instance", "topic-1", "source-4", "deploy")
+	if err == nil || !strings.Contains(err.Error(), `could not be evaluated are ["$.notification.severity == 'LOW'"]`) {
+		t.Fatalf("got error %v, want an error about the filter that could not be evaluated", err)
+	}
+
+	_, err = enNotificationRoutes(context.Background(), enClient, "instance", "topic-1", "source-2", "deploy")
+	if err == nil || !strings.Contains(err.Error(), "has no enabled rule for source source-2") {
+		t.Fatalf("got error %v, want an error about the source", err)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package eventnotification_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMEnNotificationAllArgs(t *testing.T) {
+	instanceName := fmt.Sprintf("tf_instance_%d", acctest.RandIntRange(10, 100))
+	name := fmt.Sprintf("tf_name_%d", acctest.RandIntRange(10, 100))
+	message := fmt.Sprintf("tf_message_%d", acctest.RandIntRange(10, 100))
+	messageUpdate := fmt.Sprintf("tf_message_%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMEnNotificationConfig(instanceName, name, message),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ibm_en_notification.en_notification_resource_1", "notification_id"),
+					resource.TestCheckResourceAttrSet("ibm_en_notification.en_notification_resource_1", "event_id"),
+					resource.TestCheckResourceAttrSet("ibm_en_notification.en_notification_resource_1", "sent_at"),
+					resource.TestCheckResourceAttr("ibm_en_notification.en_notification_resource_1", "default_short", message),
+					resource.TestCheckResourceAttr("ibm_en_notification.en_notification_resource_1", "routes.#", "1"),
+					resource.TestCheckResourceAttr("ibm_en_notification.en_notification_resource_1", "routes.0.destination_type", "webhook"),
+				),
+			},
+			{
+				Config: testAccCheckIBMEnNotificationConfig(instanceName, name, messageUpdate),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ibm_en_notification.en_notification_resource_1", "notification_id"),
+					resource.TestCheckResourceAttr("ibm_en_notification.en_notification_resource_1", "default_short", messageUpdate),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMEnNotificationConfig(instanceName, name, message string) string {
+	return fmt.Sprintf(`
+	resource "ibm_resource_instance" "en_notification_resource" {
+		name     = "%s"
+		location = "us-south"
+		plan     = "standard"
+		service  = "event-notifications"
+	}
+
+	resource "ibm_en_source" "en_source_resource_1" {
+		instance_guid = ibm_resource_instance.en_notification_resource.guid
+		name          = "%s"
+		description   = "tf_source_description_0364"
+		enabled       = true
+	}
+
+	resource "ibm_en_topic" "en_topic_resource_1" {
+		instance_guid = ibm_resource_instance.en_notification_resource.guid
+		name          = "%s"
+		description   = "tf_topic_description_0364"
+		sources {
+			id = ibm_en_source.en_source_resource_1.source_id
+			rules {
+				enabled           = true
+				event_type_filter = "$.*"
+			}
+		}
+	}
+
+	resource "ibm_en_destination_webhook" "en_destination_resource_1" {
+		instance_guid = ibm_resource_instance.en_notification_resource.guid
+		name          = "tf_destination_name_02983"
+		type          = "webhook"
+		description   = "tf_destinatios_description_0364"
+		config {
+			params {
+				verb = "POST"
+				url  = "https://demo.webhook.com"
+			}
+		}
+	}
+
+	resource "ibm_en_subscription_webhook" "en_subscription_resource_1" {
+		name           = "%s"
+		description    = "tf_subscription_description_0364"
+		instance_guid  = ibm_resource_instance.en_notification_resource.guid
+		topic_id       = ibm_en_topic.en_topic_resource_1.topic_id
+		destination_id = ibm_en_destination_webhook.en_destination_resource_1.destination_id
+		attributes {
+			signing_enabled = true
+		}
+	}
+
+	resource "ibm_en_notification" "en_notification_resource_1" {
+		instance_guid = ibm_resource_instance.en_notification_resource.guid
+		source_id     = ibm_en_source.en_source_resource_1.source_id
+		source        = "terraform-acceptance-test"
+		type          = "com.ibm.terraform.test"
+		severity      = "LOW"
+		default_short = "%s"
+		default_long  = "Notification sent by the acceptance test of ibm_en_notification"
+		data          = jsonencode({ message = "%s" })
+		topic_id      = ibm_en_topic.en_topic_resource_1.topic_id
+
+		depends_on = [ibm_en_subscription_webhook.en_subscription_resource_1]
+	}
+	`, instanceName, name, name, name, message, message)
+}
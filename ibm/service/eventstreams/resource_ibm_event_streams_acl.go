@@ -0,0 +1,359 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package eventstreams
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/Shopify/sarama"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	aclResourceTypes   = []string{"topic", "group", "cluster", "transactional_id"}
+	aclPatternTypes    = []string{"literal", "prefixed"}
+	aclPermissionTypes = []string{"allow", "deny"}
+	aclOperations      = []string{
+		"all",
+		"read",
+		"write",
+		"create",
+		"delete",
+		"alter",
+		"describe",
+		"cluster_action",
+		"describe_configs",
+		"alter_configs",
+		"idempotent_write",
+	}
+)
+
+func ResourceIBMEventStreamsACL() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMEventStreamsACLCreate,
+		ReadContext:   resourceIBMEventStreamsACLRead,
+		DeleteContext: resourceIBMEventStreamsACLDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIBMEventStreamsACLImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_instance_id": {
+				Type:         schema.TypeString,
+				Description:  "The CRN of the Event Streams instance",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"resource_instance_id", "bootstrap_servers"},
+			},
+			"bootstrap_servers": {
+				Type:        schema.TypeList,
+				Description: "Kafka brokers addresses of a Kafka cluster that is not an Event Streams instance, for example a local broker",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"sasl_username": {
+				Type:         schema.TypeString,
+				Description:  "The SASL PLAIN user name for bootstrap_servers",
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"bootstrap_servers", "sasl_password"},
+			},
+			"sasl_password": {
+				Type:         schema.TypeString,
+				Description:  "The SASL PLAIN password for bootstrap_servers",
+				Optional:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"bootstrap_servers", "sasl_username"},
+			},
+			"tls_enabled": {
+				Type:        schema.TypeBool,
+				Description: "Whether the connection to bootstrap_servers uses TLS",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+			},
+			"kafka_http_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "API endpoint for interacting with Event Streams REST API",
+			},
+			"kafka_brokers_sasl": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Kafka brokers addresses for interacting with Kafka native API",
+			},
+			"resource_type": {
+				Type:         schema.TypeString,
+				Description:  "The type of the Kafka resource: topic, group, cluster or transactional_id",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(aclResourceTypes, false),
+			},
+			"resource_name": {
+				Type:        schema.TypeString,
+				Description: "The name of the Kafka resource, kafka-cluster for the cluster resource",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pattern_type": {
+				Type:         schema.TypeString,
+				Description:  "How resource_name matches resources: literal or prefixed",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "literal",
+				ValidateFunc: validation.StringInSlice(aclPatternTypes, false),
+			},
+			"principal": {
+				Type:        schema.TypeString,
+				Description: "The principal of the ACL, for example User:iam-ServiceId-00000000-0000-0000-0000-000000000000",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Description: "The host the principal connects from, * for all hosts",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "*",
+			},
+			"operation": {
+				Type:         schema.TypeString,
+				Description:  "The operation of the ACL",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(aclOperations, false),
+			},
+			"permission_type": {
+				Type:         schema.TypeString,
+				Description:  "Whether the ACL allows or denies the operation",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "allow",
+				ValidateFunc: validation.StringInSlice(aclPermissionTypes, false),
+			},
+		},
+	}
+}
+
+func resourceIBMEventStreamsACLCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	adminClient, instanceCRN, err := createEventStreamsAdminClient(d, meta)
+	if err != nil {
+		log.Printf("[DEBUG] resourceIBMEventStreamsACLCreate createEventStreamsAdminClient err %s", err)
+		return diag.FromErr(err)
+	}
+	defer adminClient.Close()
+
+	resource, acl, err := expandEventStreamsACL(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	aclID, err := getACLID(instanceCRN, getACLKey(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = adminClient.CreateACL(resource, acl)
+	if err != nil {
+		log.Printf("[DEBUG] resourceIBMEventStreamsACLCreate CreateACL err %s", err)
+		return diag.FromErr(fmt.Errorf("[ERROR] Error creating ACL: %s", err))
+	}
+	log.Printf("[INFO] resourceIBMEventStreamsACLCreate ACL %s is created", getACLKey(d))
+	d.SetId(aclID)
+	return resourceIBMEventStreamsACLRead(context, d, meta)
+}
+
+func resourceIBMEventStreamsACLRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	adminClient, _, err := createEventStreamsAdminClient(d, meta)
+	if err != nil {
+		log.Printf("[DEBUG] resourceIBMEventStreamsACLRead createEventStreamsAdminClient err %s", err)
+		return diag.FromErr(err)
+	}
+	defer adminClient.Close()
+
+	filter, err := expandEventStreamsACLFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resourceAcls, err := adminClient.ListAcls(filter)
+	if err != nil {
+		log.Printf("[DEBUG] resourceIBMEventStreamsACLRead ListAcls err %s", err)
+		return diag.FromErr(fmt.Errorf("[ERROR] Error listing ACLs: %s", err))
+	}
+	for _, resourceAcl := range resourceAcls {
+		if len(resourceAcl.Acls) != 0 {
+			return nil
+		}
+	}
+	log.Printf("[INFO] resourceIBMEventStreamsACLRead ACL %s does not exist", getACLKey(d))
+	d.SetId("")
+	return nil
+}
+
+func resourceIBMEventStreamsACLDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	adminClient, _, err := createEventStreamsAdminClient(d, meta)
+	if err != nil {
+		log.Printf("[DEBUG] resourceIBMEventStreamsACLDelete createEventStreamsAdminClient err %s", err)
+		return diag.FromErr(err)
+	}
+	defer adminClient.Close()
+
+	filter, err := expandEventStreamsACLFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = adminClient.DeleteACL(filter, false)
+	if err != nil {
+		log.Printf("[DEBUG] resourceIBMEventStreamsACLDelete DeleteACL err %s", err)
+		return diag.FromErr(fmt.Errorf("[ERROR] Error deleting ACL: %s", err))
+	}
+	d.SetId("")
+	log.Printf("[INFO] resourceIBMEventStreamsACLDelete ACL %s deleted", getACLKey(d))
+	return nil
+}
+
+// resourceIBMEventStreamsACLImport imports an ACL of an Event Streams
+// instance from an ID of the form
+// <instance CRN with acl as resource type>:<resource_type>/<pattern_type>/<resource_name>/<principal>/<host>/<operation>/<permission_type>
+func resourceIBMEventStreamsACLImport(context context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	crnSegments := strings.SplitN(d.Id(), ":", 10)
+	if len(crnSegments) != 10 || crnSegments[8] != "acl" {
+		return nil, fmt.Errorf("[ERROR] Incorrect ID %s: ID should be the instance CRN with acl as resource type, followed by resource_type/pattern_type/resource_name/principal/host/operation/permission_type", d.Id())
+	}
+	parts := strings.Split(crnSegments[9], "/")
+	if len(parts) != 7 {
+		return nil, fmt.Errorf("[ERROR] Incorrect ID %s: the ACL should be resource_type/pattern_type/resource_name/principal/host/operation/permission_type", d.Id())
+	}
+	crnSegments[8] = ""
+	crnSegments[9] = ""
+	d.Set("resource_instance_id", strings.Join(crnSegments, ":"))
+	d.Set("resource_type", parts[0])
+	d.Set("pattern_type", parts[1])
+	d.Set("resource_name", parts[2])
+	d.Set("principal", parts[3])
+	d.Set("host", parts[4])
+	d.Set("operation", parts[5])
+	d.Set("permission_type", parts[6])
+	d.Set("tls_enabled", true)
+	return []*schema.ResourceData{d}, nil
+}
+
+// createEventStreamsAdminClient connects to bootstrap_servers when they are
+// set, otherwise to the kafka_brokers_sasl of the Event Streams instance. It
+// is shared by the resources that use the Kafka admin protocol.
+func createEventStreamsAdminClient(d *schema.ResourceData, meta interface{}) (sarama.ClusterAdmin, string, error) {
+	servers, ok := d.GetOk("bootstrap_servers")
+	if !ok {
+		return createSaramaAdminClient(d, meta)
+	}
+	brokerAddress := flex.ExpandStringList(servers.([]interface{}))
+
+	config := sarama.NewConfig()
+	config.ClientID, _ = os.Hostname()
+	if username, ok := d.GetOk("sasl_username"); ok {
+		config.Net.SASL.Enable = true
+		config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		config.Net.SASL.User = username.(string)
+		config.Net.SASL.Password = d.Get("sasl_password").(string)
+	}
+	if d.Get("tls_enabled").(bool) {
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	config.Version = brokerVersion
+	config.Admin.Timeout = adminClientTimeout
+	adminClient, err := sarama.NewClusterAdmin(brokerAddress, config)
+	if err != nil {
+		log.Printf("[DEBUG] createEventStreamsAdminClient NewClusterAdmin err %s", err)
+		return nil, "", err
+	}
+	log.Printf("[INFO] createEventStreamsAdminClient client for %s is initialized", brokerAddress)
+	return adminClient, "", nil
+}
+
+func expandEventStreamsACL(d *schema.ResourceData) (sarama.Resource, sarama.Acl, error) {
+	var resourceType sarama.AclResourceType
+	var patternType sarama.AclResourcePatternType
+	var operation sarama.AclOperation
+	var permissionType sarama.AclPermissionType
+
+	if err := resourceType.UnmarshalText([]byte(strings.ReplaceAll(d.Get("resource_type").(string), "_", ""))); err != nil {
+		return sarama.Resource{}, sarama.Acl{}, err
+	}
+	if err := patternType.UnmarshalText([]byte(d.Get("pattern_type").(string))); err != nil {
+		return sarama.Resource{}, sarama.Acl{}, err
+	}
+	if err := operation.UnmarshalText([]byte(strings.ReplaceAll(d.Get("operation").(string), "_", ""))); err != nil {
+		return sarama.Resource{}, sarama.Acl{}, err
+	}
+	if err := permissionType.UnmarshalText([]byte(d.Get("permission_type").(string))); err != nil {
+		return sarama.Resource{}, sarama.Acl{}, err
+	}
+
+	resource := sarama.Resource{
+		ResourceType:        resourceType,
+		ResourceName:        d.Get("resource_name").(string),
+		ResourcePatternType: patternType,
+	}
+	acl := sarama.Acl{
+		Principal:      d.Get("principal").(string),
+		Host:           d.Get("host").(string),
+		Operation:      operation,
+		PermissionType: permissionType,
+	}
+	return resource, acl, nil
+}
+
+// expandEventStreamsACLFilter returns a filter that matches exactly the ACL
+// of the resource.
+func expandEventStreamsACLFilter(d *schema.ResourceData) (sarama.AclFilter, error) {
+	resource, acl, err := expandEventStreamsACL(d)
+	if err != nil {
+		return sarama.AclFilter{}, err
+	}
+	return sarama.AclFilter{
+		Version:                   1,
+		ResourceType:              resource.ResourceType,
+		ResourceName:              &resource.ResourceName,
+		ResourcePatternTypeFilter: resource.ResourcePatternType,
+		Principal:                 &acl.Principal,
+		Host:                      &acl.Host,
+		Operation:                 acl.Operation,
+		PermissionType:            acl.PermissionType,
+	}, nil
+}
+
+func getACLKey(d *schema.ResourceData) string {
+	return strings.Join([]string{
+		d.Get("resource_type").(string),
+		d.Get("pattern_type").(string),
+		d.Get("resource_name").(string),
+		d.Get("principal").(string),
+		d.Get("host").(string),
+		d.Get("operation").(string),
+		d.Get("permission_type").(string),
+	}, "/")
+}
+
+func getACLID(instanceCRN string, aclKey string) (string, error) {
+	if instanceCRN == "" {
+		return "acl:" + aclKey, nil
+	}
+	crnSegments := strings.Split(instanceCRN, ":")
+	if len(crnSegments) != 10 {
+		return "", fmt.Errorf("[ERROR] Incorrect resource_instance_id %s: it should be the CRN of the Event Streams instance", instanceCRN)
+	}
+	crnSegments[8] = "acl"
+	crnSegments[9] = aclKey
+	return strings.Join(crnSegments, ":"), nil
+}
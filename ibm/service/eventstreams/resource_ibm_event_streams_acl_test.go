@@ -0,0 +1,93 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package eventstreams_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccIBMEventStreamsACLLocalBroker runs against the Kafka broker at
+// KAFKA_BOOTSTRAP_SERVERS, which must have an authorizer configured.
+func TestAccIBMEventStreamsACLLocalBroker(t *testing.T) {
+	topic := fmt.Sprintf("tf-acl-topic-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMEventStreamsACLLocalBrokerConfig(acc.KafkaBootstrapServers, topic, "read"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_event_streams_acl.es_acl", "id", fmt.Sprintf("acl:topic/prefixed/%s/User:tf-test/*/read/allow", topic)),
+					resource.TestCheckResourceAttr("ibm_event_streams_acl.es_acl", "operation", "read"),
+					resource.TestCheckResourceAttr("ibm_event_streams_acl.es_acl", "host", "*"),
+				),
+			},
+			{
+				Config: testAccCheckIBMEventStreamsACLLocalBrokerConfig(acc.KafkaBootstrapServers, topic, "describe_configs"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_event_streams_acl.es_acl", "operation", "describe_configs"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIBMEventStreamsACLWithExistingInstance(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMEventStreamsACLWithExistingInstanceConfig(MZREnterpriseInstanceName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ibm_event_streams_acl.es_acl", "kafka_brokers_sasl.0"),
+					resource.TestCheckResourceAttr("ibm_event_streams_acl.es_acl", "resource_type", "group"),
+				),
+			},
+			{
+				ResourceName:      "ibm_event_streams_acl.es_acl",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMEventStreamsACLLocalBrokerConfig(bootstrapServers, topic, operation string) string {
+	return fmt.Sprintf(`
+	resource "ibm_event_streams_acl" "es_acl" {
+		bootstrap_servers = ["%s"]
+		tls_enabled       = false
+		resource_type     = "topic"
+		resource_name     = "%s"
+		pattern_type      = "prefixed"
+		principal         = "User:tf-test"
+		operation         = "%s"
+	}`, bootstrapServers, topic, operation)
+}
+
+func testAccCheckIBMEventStreamsACLWithExistingInstanceConfig(instanceName string) string {
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "group" {
+		is_default = true
+	}
+
+	data "ibm_resource_instance" "es_instance" {
+		resource_group_id = data.ibm_resource_group.group.id
+		name              = "%s"
+	}
+
+	resource "ibm_event_streams_acl" "es_acl" {
+		resource_instance_id = data.ibm_resource_instance.es_instance.id
+		resource_type        = "group"
+		resource_name        = "tf-acl-group"
+		principal            = "User:iam-ServiceId-00000000-0000-0000-0000-000000000000"
+		operation            = "read"
+	}`, instanceName)
+}
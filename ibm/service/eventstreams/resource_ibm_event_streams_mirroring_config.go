@@ -0,0 +1,165 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package eventstreams
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceIBMEventStreamsMirroringConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMEventStreamsMirroringConfigUpdate,
+		ReadContext:   resourceIBMEventStreamsMirroringConfigRead,
+		UpdateContext: resourceIBMEventStreamsMirroringConfigUpdate,
+		DeleteContext: resourceIBMEventStreamsMirroringConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIBMEventStreamsMirroringConfigImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_instance_id": {
+				Type:        schema.TypeString,
+				Description: "The CRN of the target Event Streams instance of the mirroring",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"mirroring_topic_patterns": {
+				Type:        schema.TypeList,
+				Description: "The topic patterns that are mirrored from the source instance, as regular expressions",
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"active_topics": {
+				Type:        schema.TypeList,
+				Description: "The topics that are currently mirrored",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"kafka_http_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "API endpoint for interacting with Event Streams REST API",
+			},
+		},
+	}
+}
+
+func resourceIBMEventStreamsMirroringConfigUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	adminrestClient, instanceCRN, err := getAdminrestClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	mirroringConfigID, err := getMirroringConfigID(instanceCRN)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	replaceOptions := adminrestClient.NewReplaceMirroringTopicSelectionOptions()
+	replaceOptions.Includes = flex.ExpandStringList(d.Get("mirroring_topic_patterns").([]interface{}))
+	_, response, err := adminrestClient.ReplaceMirroringTopicSelectionWithContext(context, replaceOptions)
+	if err != nil {
+		log.Printf("[DEBUG] resourceIBMEventStreamsMirroringConfigUpdate ReplaceMirroringTopicSelection err %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("[ERROR] Error replacing mirroring topic selection: %s\n%s", err, response))
+	}
+	log.Printf("[INFO] resourceIBMEventStreamsMirroringConfigUpdate mirroring topic selection of %s is replaced", instanceCRN)
+	d.SetId(mirroringConfigID)
+	return resourceIBMEventStreamsMirroringConfigRead(context, d, meta)
+}
+
+func resourceIBMEventStreamsMirroringConfigRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	adminrestClient, instanceCRN, err := getAdminrestClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	selection, response, err := adminrestClient.GetMirroringTopicSelectionWithContext(context, adminrestClient.NewGetMirroringTopicSelectionOptions())
+	if err != nil {
+		log.Printf("[DEBUG] resourceIBMEventStreamsMirroringConfigRead GetMirroringTopicSelection err %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting mirroring topic selection: %s\n%s", err, response))
+	}
+	activeTopics, response, err := adminrestClient.GetMirroringActiveTopicsWithContext(context, adminrestClient.NewGetMirroringActiveTopicsOptions())
+	if err != nil {
+		log.Printf("[DEBUG] resourceIBMEventStreamsMirroringConfigRead GetMirroringActiveTopics err %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting mirroring active topics: %s\n%s", err, response))
+	}
+
+	d.Set("resource_instance_id", instanceCRN)
+	d.Set("mirroring_topic_patterns", selection.Includes)
+	d.Set("active_topics", activeTopics.ActiveTopics)
+	return nil
+}
+
+// resourceIBMEventStreamsMirroringConfigDelete stops the mirroring of all
+// topics, the mirroring itself is part of the instance configuration.
+func resourceIBMEventStreamsMirroringConfigDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	adminrestClient, instanceCRN, err := getAdminrestClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	replaceOptions := adminrestClient.NewReplaceMirroringTopicSelectionOptions()
+	replaceOptions.Includes = []string{}
+	_, response, err := adminrestClient.ReplaceMirroringTopicSelectionWithContext(context, replaceOptions)
+	if err != nil {
+		log.Printf("[DEBUG] resourceIBMEventStreamsMirroringConfigDelete ReplaceMirroringTopicSelection err %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("[ERROR] Error clearing mirroring topic selection: %s\n%s", err, response))
+	}
+	d.SetId("")
+	log.Printf("[INFO] resourceIBMEventStreamsMirroringConfigDelete mirroring topic selection of %s is cleared", instanceCRN)
+	return nil
+}
+
+// resourceIBMEventStreamsMirroringConfigImport imports the mirroring
+// configuration of an Event Streams instance from the instance CRN with
+// mirroring-config as resource type.
+func resourceIBMEventStreamsMirroringConfigImport(context context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	crnSegments := strings.Split(d.Id(), ":")
+	if len(crnSegments) != 10 || crnSegments[8] != "mirroring-config" {
+		return nil, fmt.Errorf("[ERROR] Incorrect ID %s: ID should be the instance CRN with mirroring-config as resource type", d.Id())
+	}
+	crnSegments[8] = ""
+	crnSegments[9] = ""
+	d.Set("resource_instance_id", strings.Join(crnSegments, ":"))
+	return []*schema.ResourceData{d}, nil
+}
+
+// getAdminrestClient returns the Event Streams admin REST client pointed at
+// the kafka_http_url of the instance, and the CRN of the instance.
+func getAdminrestClient(d *schema.ResourceData, meta interface{}) (*adminrestv1.AdminrestV1, string, error) {
+	adminrestClient, err := meta.(conns.ClientSession).ESadminRestSession()
+	if err != nil {
+		return nil, "", err
+	}
+	instanceCRN := d.Get("resource_instance_id").(string)
+	instance, err := getInstanceDetails(instanceCRN, meta)
+	if err != nil {
+		return nil, "", err
+	}
+	adminURL := instance.Extensions["kafka_http_url"].(string)
+	d.Set("kafka_http_url", adminURL)
+	log.Printf("[INFO] getAdminrestClient kafka_http_url is set to %s", adminURL)
+	if err := adminrestClient.SetServiceURL(adminURL); err != nil {
+		return nil, "", err
+	}
+	return adminrestClient, instanceCRN, nil
+}
+
+func getMirroringConfigID(instanceCRN string) (string, error) {
+	crnSegments := strings.Split(instanceCRN, ":")
+	if len(crnSegments) != 10 {
+		return "", fmt.Errorf("[ERROR] Incorrect resource_instance_id %s: it should be the CRN of the Event Streams instance", instanceCRN)
+	}
+	crnSegments[8] = "mirroring-config"
+	crnSegments[9] = ""
+	return strings.Join(crnSegments, ":"), nil
+}
@@ -0,0 +1,54 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package eventstreams_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// The instance must be the target of a mirroring that is already enabled.
+func TestAccIBMEventStreamsMirroringConfigBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMEventStreamsMirroringConfig(MZREnterpriseInstanceName, `["^orders\\..*"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ibm_event_streams_mirroring_config.es_mirroring_config", "kafka_http_url"),
+					resource.TestCheckResourceAttr("ibm_event_streams_mirroring_config.es_mirroring_config", "mirroring_topic_patterns.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckIBMEventStreamsMirroringConfig(MZREnterpriseInstanceName, `["^orders\\..*", "^payments\\..*"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_event_streams_mirroring_config.es_mirroring_config", "mirroring_topic_patterns.#", "2"),
+					resource.TestCheckResourceAttr("ibm_event_streams_mirroring_config.es_mirroring_config", "mirroring_topic_patterns.1", "^payments\\..*"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMEventStreamsMirroringConfig(instanceName, patterns string) string {
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "group" {
+		is_default = true
+	}
+
+	data "ibm_resource_instance" "es_instance" {
+		resource_group_id = data.ibm_resource_group.group.id
+		name              = "%s"
+	}
+
+	resource "ibm_event_streams_mirroring_config" "es_mirroring_config" {
+		resource_instance_id     = data.ibm_resource_instance.es_instance.id
+		mirroring_topic_patterns = %s
+	}`, instanceName, patterns)
+}
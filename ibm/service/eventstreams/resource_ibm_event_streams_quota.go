@@ -0,0 +1,428 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package eventstreams
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/Shopify/sarama"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// quotaKeys are the Kafka client quota keys that the resource manages, they
+// are also the names of the rate arguments.
+var quotaKeys = []string{"producer_byte_rate", "consumer_byte_rate"}
+
+// eventStreamsQuota is the body of the quotas requests of the Event Streams
+// admin REST API, which adminrestv1 does not cover.
+type eventStreamsQuota struct {
+	ProducerByteRate *int64 `json:"producer_byte_rate,omitempty"`
+	ConsumerByteRate *int64 `json:"consumer_byte_rate,omitempty"`
+}
+
+func ResourceIBMEventStreamsQuota() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIBMEventStreamsQuotaCreate,
+		ReadContext:   resourceIBMEventStreamsQuotaRead,
+		UpdateContext: resourceIBMEventStreamsQuotaUpdate,
+		DeleteContext: resourceIBMEventStreamsQuotaDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIBMEventStreamsQuotaImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_instance_id": {
+				Type:         schema.TypeString,
+				Description:  "The CRN of the Event Streams instance",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"resource_instance_id", "bootstrap_servers"},
+			},
+			"bootstrap_servers": {
+				Type:        schema.TypeList,
+				Description: "Kafka brokers addresses of a Kafka cluster that is not an Event Streams instance, for example a local broker",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"sasl_username": {
+				Type:         schema.TypeString,
+				Description:  "The SASL PLAIN user name for bootstrap_servers",
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"bootstrap_servers", "sasl_password"},
+			},
+			"sasl_password": {
+				Type:         schema.TypeString,
+				Description:  "The SASL PLAIN password for bootstrap_servers",
+				Optional:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"bootstrap_servers", "sasl_username"},
+			},
+			"tls_enabled": {
+				Type:        schema.TypeBool,
+				Description: "Whether the connection to bootstrap_servers uses TLS",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+			},
+			"kafka_http_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "API endpoint for interacting with Event Streams REST API",
+			},
+			"entity_name": {
+				Type:        schema.TypeString,
+				Description: "The user the quota applies to: default for the default quota, or an IAM service ID such as iam-ServiceId-00000000-0000-0000-0000-000000000000",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"producer_byte_rate": {
+				Type:         schema.TypeInt,
+				Description:  "The producer byte rate quota in bytes per second",
+				Optional:     true,
+				AtLeastOneOf: quotaKeys,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"consumer_byte_rate": {
+				Type:         schema.TypeInt,
+				Description:  "The consumer byte rate quota in bytes per second",
+				Optional:     true,
+				AtLeastOneOf: quotaKeys,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		},
+	}
+}
+
+func resourceIBMEventStreamsQuotaCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	entityName := d.Get("entity_name").(string)
+	var quotaID string
+	if _, ok := d.GetOk("bootstrap_servers"); ok {
+		adminClient, _, err := createEventStreamsAdminClient(d, meta)
+		if err != nil {
+			log.Printf("[DEBUG] resourceIBMEventStreamsQuotaCreate createEventStreamsAdminClient err %s", err)
+			return diag.FromErr(err)
+		}
+		defer adminClient.Close()
+
+		quotaID, _ = getQuotaID("", entityName)
+		err = alterQuota(adminClient, entityName, expandQuotaOps(d, false))
+		if err != nil {
+			log.Printf("[DEBUG] resourceIBMEventStreamsQuotaCreate alterQuota err %s", err)
+			return diag.FromErr(fmt.Errorf("[ERROR] Error creating quota for %s: %s", entityName, err))
+		}
+	} else {
+		adminrestClient, instanceCRN, err := getAdminrestClient(d, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		quotaID, err = getQuotaID(instanceCRN, entityName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		_, err = eventStreamsQuotaRequest(context, adminrestClient, core.POST, entityName, expandEventStreamsQuota(d))
+		if err != nil {
+			log.Printf("[DEBUG] resourceIBMEventStreamsQuotaCreate eventStreamsQuotaRequest err %s", err)
+			return diag.FromErr(fmt.Errorf("[ERROR] Error creating quota for %s: %s", entityName, err))
+		}
+	}
+	log.Printf("[INFO] resourceIBMEventStreamsQuotaCreate quota for %s is created", entityName)
+	d.SetId(quotaID)
+	return resourceIBMEventStreamsQuotaRead(context, d, meta)
+}
+
+func resourceIBMEventStreamsQuotaRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	entityName := d.Get("entity_name").(string)
+	values := map[string]float64{}
+	if _, ok := d.GetOk("bootstrap_servers"); ok {
+		adminClient, _, err := createEventStreamsAdminClient(d, meta)
+		if err != nil {
+			log.Printf("[DEBUG] resourceIBMEventStreamsQuotaRead createEventStreamsAdminClient err %s", err)
+			return diag.FromErr(err)
+		}
+		defer adminClient.Close()
+
+		values, err = describeQuota(adminClient, entityName)
+		if err != nil {
+			log.Printf("[DEBUG] resourceIBMEventStreamsQuotaRead describeQuota err %s", err)
+			return diag.FromErr(fmt.Errorf("[ERROR] Error getting quota for %s: %s", entityName, err))
+		}
+	} else {
+		adminrestClient, _, err := getAdminrestClient(d, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		quota := &eventStreamsQuota{}
+		response, err := eventStreamsQuotaRequest(context, adminrestClient, core.GET, entityName, nil, quota)
+		if err != nil && (response == nil || response.StatusCode != http.StatusNotFound) {
+			log.Printf("[DEBUG] resourceIBMEventStreamsQuotaRead eventStreamsQuotaRequest err %s", err)
+			return diag.FromErr(fmt.Errorf("[ERROR] Error getting quota for %s: %s", entityName, err))
+		}
+		if err == nil {
+			values = flattenEventStreamsQuota(quota)
+		}
+	}
+	if len(values) == 0 {
+		log.Printf("[INFO] resourceIBMEventStreamsQuotaRead quota for %s does not exist", entityName)
+		d.SetId("")
+		return nil
+	}
+	for _, key := range quotaKeys {
+		if value, ok := values[key]; ok {
+			d.Set(key, int(value))
+		} else {
+			d.Set(key, nil)
+		}
+	}
+	return nil
+}
+
+func resourceIBMEventStreamsQuotaUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChanges(quotaKeys...) {
+		// A rate that is removed from the configuration is removed from the
+		// quota.
+		entityName := d.Get("entity_name").(string)
+		if _, ok := d.GetOk("bootstrap_servers"); ok {
+			adminClient, _, err := createEventStreamsAdminClient(d, meta)
+			if err != nil {
+				log.Printf("[DEBUG] resourceIBMEventStreamsQuotaUpdate createEventStreamsAdminClient err %s", err)
+				return diag.FromErr(err)
+			}
+			defer adminClient.Close()
+
+			err = alterQuota(adminClient, entityName, expandQuotaOps(d, true))
+			if err != nil {
+				log.Printf("[DEBUG] resourceIBMEventStreamsQuotaUpdate alterQuota err %s", err)
+				return diag.FromErr(fmt.Errorf("[ERROR] Error updating quota for %s: %s", entityName, err))
+			}
+		} else {
+			adminrestClient, _, err := getAdminrestClient(d, meta)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			// The admin REST API removes a rate that is set to -1.
+			quota := expandEventStreamsQuota(d)
+			if quota.ProducerByteRate == nil {
+				quota.ProducerByteRate = core.Int64Ptr(-1)
+			}
+			if quota.ConsumerByteRate == nil {
+				quota.ConsumerByteRate = core.Int64Ptr(-1)
+			}
+			_, err = eventStreamsQuotaRequest(context, adminrestClient, core.PATCH, entityName, quota)
+			if err != nil {
+				log.Printf("[DEBUG] resourceIBMEventStreamsQuotaUpdate eventStreamsQuotaRequest err %s", err)
+				return diag.FromErr(fmt.Errorf("[ERROR] Error updating quota for %s: %s", entityName, err))
+			}
+		}
+		log.Printf("[INFO] resourceIBMEventStreamsQuotaUpdate quota for %s is updated", entityName)
+	}
+	return resourceIBMEventStreamsQuotaRead(context, d, meta)
+}
+
+func resourceIBMEventStreamsQuotaDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	entityName := d.Get("entity_name").(string)
+	if _, ok := d.GetOk("bootstrap_servers"); ok {
+		adminClient, _, err := createEventStreamsAdminClient(d, meta)
+		if err != nil {
+			log.Printf("[DEBUG] resourceIBMEventStreamsQuotaDelete createEventStreamsAdminClient err %s", err)
+			return diag.FromErr(err)
+		}
+		defer adminClient.Close()
+
+		ops := []sarama.ClientQuotasOp{}
+		for _, key := range quotaKeys {
+			ops = append(ops, sarama.ClientQuotasOp{Key: key, Remove: true})
+		}
+		err = alterQuota(adminClient, entityName, ops)
+		if err != nil {
+			log.Printf("[DEBUG] resourceIBMEventStreamsQuotaDelete alterQuota err %s", err)
+			return diag.FromErr(fmt.Errorf("[ERROR] Error deleting quota for %s: %s", entityName, err))
+		}
+	} else {
+		adminrestClient, _, err := getAdminrestClient(d, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		response, err := eventStreamsQuotaRequest(context, adminrestClient, core.DELETE, entityName, nil)
+		if err != nil && (response == nil || response.StatusCode != http.StatusNotFound) {
+			log.Printf("[DEBUG] resourceIBMEventStreamsQuotaDelete eventStreamsQuotaRequest err %s", err)
+			return diag.FromErr(fmt.Errorf("[ERROR] Error deleting quota for %s: %s", entityName, err))
+		}
+	}
+	d.SetId("")
+	log.Printf("[INFO] resourceIBMEventStreamsQuotaDelete quota for %s is deleted", entityName)
+	return nil
+}
+
+// resourceIBMEventStreamsQuotaImport imports a quota of an Event Streams
+// instance from an ID of the form
+// <instance CRN with quota as resource type>:<entity_name>
+func resourceIBMEventStreamsQuotaImport(context context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	instanceCRN, entityName, err := parseQuotaID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if instanceCRN == "" {
+		return nil, fmt.Errorf("[ERROR] Incorrect ID %s: only quotas of an Event Streams instance can be imported", d.Id())
+	}
+	d.Set("resource_instance_id", instanceCRN)
+	d.Set("entity_name", entityName)
+	d.Set("tls_enabled", true)
+	return []*schema.ResourceData{d}, nil
+}
+
+// getQuotaEntity returns the Kafka user entity of the quota, default is the
+// default quota of all users.
+func getQuotaEntity(entityName string) sarama.QuotaEntityComponent {
+	if entityName == "default" {
+		return sarama.QuotaEntityComponent{EntityType: sarama.QuotaEntityUser, MatchType: sarama.QuotaMatchDefault}
+	}
+	return sarama.QuotaEntityComponent{EntityType: sarama.QuotaEntityUser, MatchType: sarama.QuotaMatchExact, Name: entityName}
+}
+
+// describeQuota returns the rates of the quota of the entity, it is empty
+// when the entity has no rate.
+func describeQuota(adminClient sarama.ClusterAdmin, entityName string) (map[string]float64, error) {
+	entity := getQuotaEntity(entityName)
+	filter := sarama.QuotaFilterComponent{
+		EntityType: entity.EntityType,
+		MatchType:  entity.MatchType,
+		Match:      entity.Name,
+	}
+	entries, err := adminClient.DescribeClientQuotas([]sarama.QuotaFilterComponent{filter}, true)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]float64{}
+	for _, entry := range entries {
+		for _, key := range quotaKeys {
+			if value, ok := entry.Values[key]; ok {
+				values[key] = value
+			}
+		}
+	}
+	return values, nil
+}
+
+// alterQuota applies the operations to the quota of the entity, one request
+// per operation as the admin client alters one key at a time.
+func alterQuota(adminClient sarama.ClusterAdmin, entityName string, ops []sarama.ClientQuotasOp) error {
+	entity := []sarama.QuotaEntityComponent{getQuotaEntity(entityName)}
+	for _, op := range ops {
+		if err := adminClient.AlterClientQuotas(entity, op, false); err != nil {
+			return fmt.Errorf("altering %s: %s", op.Key, err)
+		}
+	}
+	return nil
+}
+
+// expandQuotaOps sets the configured rates, and removes the other rates when
+// remove is true.
+func expandQuotaOps(d *schema.ResourceData, remove bool) []sarama.ClientQuotasOp {
+	ops := []sarama.ClientQuotasOp{}
+	for _, key := range quotaKeys {
+		if v, ok := d.GetOk(key); ok {
+			ops = append(ops, sarama.ClientQuotasOp{Key: key, Value: float64(v.(int))})
+		} else if remove {
+			ops = append(ops, sarama.ClientQuotasOp{Key: key, Remove: true})
+		}
+	}
+	return ops
+}
+
+// eventStreamsQuotaRequest sends a request to /admin/quotas/{entity_name} and
+// unmarshals the response into result when it is given.
+func eventStreamsQuotaRequest(context context.Context, adminrestClient *adminrestv1.AdminrestV1, method string, entityName string, body *eventStreamsQuota, result ...*eventStreamsQuota) (*core.DetailedResponse, error) {
+	builder := core.NewRequestBuilder(method)
+	builder = builder.WithContext(context)
+	builder.EnableGzipCompression = adminrestClient.GetEnableGzipCompression()
+	pathParamsMap := map[string]string{
+		"entity_name": entityName,
+	}
+	_, err := builder.ResolveRequestURL(adminrestClient.Service.Options.URL, `/admin/quotas/{entity_name}`, pathParamsMap)
+	if err != nil {
+		return nil, err
+	}
+	builder.AddHeader("Accept", "application/json")
+	if body != nil {
+		builder.AddHeader("Content-Type", "application/json")
+		if _, err := builder.SetBodyContentJSON(body); err != nil {
+			return nil, err
+		}
+	}
+	request, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	if len(result) > 0 {
+		return adminrestClient.Service.Request(request, result[0])
+	}
+	return adminrestClient.Service.Request(request, nil)
+}
+
+func expandEventStreamsQuota(d *schema.ResourceData) *eventStreamsQuota {
+	quota := &eventStreamsQuota{}
+	if v, ok := d.GetOk("producer_byte_rate"); ok {
+		quota.ProducerByteRate = core.Int64Ptr(int64(v.(int)))
+	}
+	if v, ok := d.GetOk("consumer_byte_rate"); ok {
+		quota.ConsumerByteRate = core.Int64Ptr(int64(v.(int)))
+	}
+	return quota
+}
+
+// flattenEventStreamsQuota returns the rates of the quota keyed like the
+// rates of the Kafka client quota API.
+func flattenEventStreamsQuota(quota *eventStreamsQuota) map[string]float64 {
+	values := map[string]float64{}
+	if quota.ProducerByteRate != nil {
+		values["producer_byte_rate"] = float64(*quota.ProducerByteRate)
+	}
+	if quota.ConsumerByteRate != nil {
+		values["consumer_byte_rate"] = float64(*quota.ConsumerByteRate)
+	}
+	return values
+}
+
+func getQuotaID(instanceCRN string, entityName string) (string, error) {
+	if instanceCRN == "" {
+		return "quota:" + entityName, nil
+	}
+	crnSegments := strings.Split(instanceCRN, ":")
+	if len(crnSegments) != 10 {
+		return "", fmt.Errorf("[ERROR] Incorrect resource_instance_id %s: it should be the CRN of the Event Streams instance", instanceCRN)
+	}
+	crnSegments[8] = "quota"
+	crnSegments[9] = entityName
+	return strings.Join(crnSegments, ":"), nil
+}
+
+// parseQuotaID returns the instance CRN and the entity name of a quota ID,
+// the instance CRN is empty for a quota of bootstrap_servers.
+func parseQuotaID(id string) (string, string, error) {
+	if strings.HasPrefix(id, "quota:") {
+		return "", strings.TrimPrefix(id, "quota:"), nil
+	}
+	crnSegments := strings.Split(id, ":")
+	if len(crnSegments) != 10 || crnSegments[8] != "quota" || crnSegments[9] == "" {
+		return "", "", fmt.Errorf("[ERROR] Incorrect ID %s: ID should be the instance CRN with quota as resource type, followed by the entity name", id)
+	}
+	entityName := crnSegments[9]
+	crnSegments[8] = ""
+	crnSegments[9] = ""
+	return strings.Join(crnSegments, ":"), entityName, nil
+}
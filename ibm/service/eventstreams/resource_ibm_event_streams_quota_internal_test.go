@@ -0,0 +1,194 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package eventstreams
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/IBM/eventstreams-go-sdk/pkg/adminrestv1"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/Shopify/sarama"
+)
+
+func newQuotaMockBroker(t *testing.T, entries []sarama.DescribeClientQuotasEntry) (*sarama.MockBroker, sarama.ClusterAdmin) {
+	broker := sarama.NewMockBroker(t, 1)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetController(broker.BrokerID()).
+			SetBroker(broker.Addr(), broker.BrokerID()),
+		"DescribeClientQuotasRequest": sarama.NewMockWrapper(&sarama.DescribeClientQuotasResponse{Entries: entries}),
+		"AlterClientQuotasRequest":    sarama.NewMockWrapper(&sarama.AlterClientQuotasResponse{}),
+	})
+
+	config := sarama.NewConfig()
+	config.Version = brokerVersion
+	adminClient, err := sarama.NewClusterAdmin([]string{broker.Addr()}, config)
+	if err != nil {
+		broker.Close()
+		t.Fatal(err)
+	}
+	return broker, adminClient
+}
+
+func TestDescribeQuota(t *testing.T) {
+	entries := []sarama.DescribeClientQuotasEntry{
+		{
+			Entity: []sarama.QuotaEntityComponent{getQuotaEntity("iam-ServiceId-1")},
+			Values: map[string]float64{"producer_byte_rate": 1024, "request_percentage": 50},
+		},
+	}
+	broker, adminClient := newQuotaMockBroker(t, entries)
+	defer broker.Close()
+	defer adminClient.Close()
+
+	values, err := describeQuota(adminClient, "iam-ServiceId-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Only the rates of the resource are returned.
+	if !reflect.DeepEqual(values, map[string]float64{"producer_byte_rate": 1024}) {
+		t.Fatalf("got values %v, want the producer byte rate", values)
+	}
+
+	var requests []*sarama.DescribeClientQuotasRequest
+	for _, history := range broker.History() {
+		if request, ok := history.Request.(*sarama.DescribeClientQuotasRequest); ok {
+			requests = append(requests, request)
+		}
+	}
+	want := []sarama.QuotaFilterComponent{{EntityType: sarama.QuotaEntityUser, MatchType: sarama.QuotaMatchExact, Match: "iam-ServiceId-1"}}
+	if len(requests) != 1 || !requests[0].Strict || !reflect.DeepEqual(requests[0].Components, want) {
+		t.Fatalf("got requests %+v, want a strict request for the user", requests)
+	}
+}
+
+func TestDescribeQuotaNotFound(t *testing.T) {
+	broker, adminClient := newQuotaMockBroker(t, nil)
+	defer broker.Close()
+	defer adminClient.Close()
+
+	values, err := describeQuota(adminClient, "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(values) != 0 {
+		t.Fatalf("got values %v, want none", values)
+	}
+}
+
+func TestAlterQuota(t *testing.T) {
+	broker, adminClient := newQuotaMockBroker(t, nil)
+	defer broker.Close()
+	defer adminClient.Close()
+
+	ops := []sarama.ClientQuotasOp{
+		{Key: "producer_byte_rate", Value: 2048},
+		{Key: "consumer_byte_rate", Remove: true},
+	}
+	if err := alterQuota(adminClient, "default", ops); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var entries []sarama.AlterClientQuotasEntry
+	for _, history := range broker.History() {
+		if request, ok := history.Request.(*sarama.AlterClientQuotasRequest); ok {
+			entries = append(entries, request.Entries...)
+		}
+	}
+	entity := []sarama.QuotaEntityComponent{{EntityType: sarama.QuotaEntityUser, MatchType: sarama.QuotaMatchDefault}}
+	want := []sarama.AlterClientQuotasEntry{
+		{Entity: entity, Ops: ops[:1]},
+		{Entity: entity, Ops: ops[1:]},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Fatalf("got entries %+v, want %+v", entries, want)
+	}
+}
+
+func TestParseQuotaID(t *testing.T) {
+	instanceCRN := "crn:v1:bluemix:public:messagehub:us-south:a/6db1b0d0b5c54ee5c201552547febcd8:cb5a0252-8b8d-4390-b017-80b743d32839::"
+
+	testCases := []struct {
+		id          string
+		instanceCRN string
+		entityName  string
+		err         bool
+	}{
+		{id: "crn:v1:bluemix:public:messagehub:us-south:a/6db1b0d0b5c54ee5c201552547febcd8:cb5a0252-8b8d-4390-b017-80b743d32839:quota:default", instanceCRN: instanceCRN, entityName: "default"},
+		{id: "quota:tf-test", entityName: "tf-test"},
+		{id: "crn:v1:bluemix:public:messagehub:us-south:a/6db1b0d0b5c54ee5c201552547febcd8:cb5a0252-8b8d-4390-b017-80b743d32839:topic:orders", err: true},
+		{id: "crn:v1:bluemix:public:messagehub:us-south:a/6db1b0d0b5c54ee5c201552547febcd8:cb5a0252-8b8d-4390-b017-80b743d32839:quota:", err: true},
+		{id: "cb5a0252-8b8d-4390-b017-80b743d32839", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.id, func(t *testing.T) {
+			instanceCRN, entityName, err := parseQuotaID(tc.id)
+			if (err != nil) != tc.err {
+				t.Fatalf("got error %v, want error %t", err, tc.err)
+			}
+			if instanceCRN != tc.instanceCRN || entityName != tc.entityName {
+				t.Fatalf("got (%q, %q), want (%q, %q)", instanceCRN, entityName, tc.instanceCRN, tc.entityName)
+			}
+			if err != nil {
+				return
+			}
+			id, err := getQuotaID(instanceCRN, entityName)
+			if err != nil || id != tc.id {
+				t.Fatalf("got ID %q and error %v, want %q", id, err, tc.id)
+			}
+		})
+	}
+
+	if _, err := getQuotaID("cb5a0252-8b8d-4390-b017-80b743d32839", "default"); err == nil {
+		t.Fatalf("expected an error for a resource_instance_id that is not a CRN")
+	}
+	if _, err := getMirroringConfigID("cb5a0252-8b8d-4390-b017-80b743d32839"); err == nil {
+		t.Fatalf("expected an error for a resource_instance_id that is not a CRN")
+	}
+}
+
+func TestEventStreamsQuotaRequest(t *testing.T) {
+	var method, path string
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, body = r.Method, r.URL.Path, nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"producer_byte_rate": 1024}`))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	adminrestClient, err := adminrestv1.NewAdminrestV1(&adminrestv1.AdminrestV1Options{URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quota := &eventStreamsQuota{ProducerByteRate: core.Int64Ptr(2048), ConsumerByteRate: core.Int64Ptr(-1)}
+	if _, err := eventStreamsQuotaRequest(context.Background(), adminrestClient, core.PATCH, "iam-ServiceId-1", quota); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]interface{}{"producer_byte_rate": 2048.0, "consumer_byte_rate": -1.0}
+	if method != http.MethodPatch || path != "/admin/quotas/iam-ServiceId-1" || !reflect.DeepEqual(body, want) {
+		t.Fatalf("got %s %s with body %v, want PATCH /admin/quotas/iam-ServiceId-1 with %v", method, path, body, want)
+	}
+
+	quota = &eventStreamsQuota{}
+	if _, err := eventStreamsQuotaRequest(context.Background(), adminrestClient, core.GET, "default", nil, quota); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if values := flattenEventStreamsQuota(quota); method != http.MethodGet || path != "/admin/quotas/default" || !reflect.DeepEqual(values, map[string]float64{"producer_byte_rate": 1024}) {
+		t.Fatalf("got %s %s with rates %v, want the producer byte rate of GET /admin/quotas/default", method, path, values)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package eventstreams_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccIBMEventStreamsQuotaLocalBroker runs against the Kafka broker at
+// KAFKA_BOOTSTRAP_SERVERS.
+func TestAccIBMEventStreamsQuotaLocalBroker(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMEventStreamsQuotaLocalBrokerConfig(acc.KafkaBootstrapServers, `producer_byte_rate = 1024`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_event_streams_quota.es_quota", "id", "quota:tf-test"),
+					resource.TestCheckResourceAttr("ibm_event_streams_quota.es_quota", "producer_byte_rate", "1024"),
+					resource.TestCheckNoResourceAttr("ibm_event_streams_quota.es_quota", "consumer_byte_rate"),
+				),
+			},
+			{
+				Config: testAccCheckIBMEventStreamsQuotaLocalBrokerConfig(acc.KafkaBootstrapServers, `consumer_byte_rate = 2048`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("ibm_event_streams_quota.es_quota", "producer_byte_rate"),
+					resource.TestCheckResourceAttr("ibm_event_streams_quota.es_quota", "consumer_byte_rate", "2048"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIBMEventStreamsQuotaBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMEventStreamsQuotaConfig(MZREnterpriseInstanceName, `producer_byte_rate = 1024`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ibm_event_streams_quota.es_quota", "kafka_http_url"),
+					resource.TestCheckResourceAttr("ibm_event_streams_quota.es_quota", "entity_name", "default"),
+					resource.TestCheckResourceAttr("ibm_event_streams_quota.es_quota", "producer_byte_rate", "1024"),
+				),
+			},
+			{
+				Config: testAccCheckIBMEventStreamsQuotaConfig(MZREnterpriseInstanceName, `consumer_byte_rate = 2048`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_event_streams_quota.es_quota", "consumer_byte_rate", "2048"),
+				),
+			},
+			{
+				ResourceName:      "ibm_event_streams_quota.es_quota",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMEventStreamsQuotaConfig(instanceName, rates string) string {
+	return fmt.Sprintf(`
+	data "ibm_resource_group" "group" {
+		is_default = true
+	}
+
+	data "ibm_resource_instance" "es_instance" {
+		resource_group_id = data.ibm_resource_group.group.id
+		name              = "%s"
+	}
+
+	resource "ibm_event_streams_quota" "es_quota" {
+		resource_instance_id = data.ibm_resource_instance.es_instance.id
+		entity_name          = "default"
+		%s
+	}`, instanceName, rates)
+}
+
+func testAccCheckIBMEventStreamsQuotaLocalBrokerConfig(bootstrapServers, rates string) string {
+	return fmt.Sprintf(`
+	resource "ibm_event_streams_quota" "es_quota" {
+		bootstrap_servers = ["%s"]
+		tls_enabled       = false
+		entity_name       = "tf-test"
+		%s
+	}`, bootstrapServers, rates)
+}
@@ -0,0 +1,148 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceIBMKmsImportToken() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIBMKmsImportTokenCreate,
+		Read:   resourceIBMKmsImportTokenRead,
+		Delete: resourceIBMKmsImportTokenDelete,
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Key protect Instance GUID or CRN",
+				DiffSuppressFunc: suppressKMSInstanceIDDiff,
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private"}),
+				Description:  "public or private",
+			},
+			"expiration": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      600,
+				ValidateFunc: validate.InvokeValidator("ibm_kms_import_token", "expiration"),
+				Description:  "The time in seconds from the creation of the import token that determines how long it remains valid",
+			},
+			"max_allowed_retrievals": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      1,
+				ValidateFunc: validate.InvokeValidator("ibm_kms_import_token", "max_allowed_retrievals"),
+				Description:  "The number of times that the import token can be retrieved to import keys",
+			},
+			"token_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the import token",
+			},
+			"creation_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the import token was created",
+			},
+			"expiration_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the import token expires",
+			},
+			"remaining_retrievals": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of retrievals that were left when the import token was created",
+			},
+		},
+	}
+}
+
+func ResourceIBMKmsImportTokenValidator() *validate.ResourceValidator {
+
+	validateSchema := make([]validate.ValidateSchema, 0)
+
+	validateSchema = append(validateSchema,
+		validate.ValidateSchema{
+			Identifier:                 "expiration",
+			ValidateFunctionIdentifier: validate.IntBetween,
+			Type:                       validate.TypeInt,
+			Optional:                   true,
+			MinValue:                   "300",
+			MaxValue:                   "86400"},
+		validate.ValidateSchema{
+			Identifier:                 "max_allowed_retrievals",
+			ValidateFunctionIdentifier: validate.IntBetween,
+			Type:                       validate.TypeInt,
+			Optional:                   true,
+			MinValue:                   "1",
+			MaxValue:                   "500"})
+
+	ibmKmsImportTokenResourceValidator := validate.ResourceValidator{ResourceName: "ibm_kms_import_token", Schema: validateSchema}
+	return &ibmKmsImportTokenResourceValidator
+}
+
+func resourceIBMKmsImportTokenCreate(d *schema.ResourceData, meta interface{}) error {
+	instanceID := getInstanceIDFromCRN(d.Get("instance_id").(string))
+	kpAPI, instanceCRN, err := populateKPClient(d, meta, instanceID)
+	if err != nil {
+		return err
+	}
+
+	token, err := kpAPI.CreateImportToken(context.Background(), d.Get("expiration").(int), d.Get("max_allowed_retrievals").(int))
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error while creating import token: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:importToken:%s", token.ID, *instanceCRN))
+	d.Set("token_id", token.ID)
+	if token.CreationDate != nil {
+		d.Set("creation_date", token.CreationDate.Format(time.RFC3339))
+	}
+	if token.ExpirationDate != nil {
+		d.Set("expiration_date", token.ExpirationDate.Format(time.RFC3339))
+	}
+	d.Set("remaining_retrievals", token.RemainingRetrievals)
+	if strings.Contains((kpAPI.URL).String(), "private") || strings.Contains(kpAPI.Config.BaseURL, "private") {
+		d.Set("endpoint_type", "private")
+	} else {
+		d.Set("endpoint_type", "public")
+	}
+
+	return resourceIBMKmsImportTokenRead(d, meta)
+}
+
+// The only way to read an import token is to retrieve its transport key, which
+// counts against max_allowed_retrievals, so the token is not read back.
+func resourceIBMKmsImportTokenRead(d *schema.ResourceData, meta interface{}) error {
+	id := strings.Split(d.Id(), ":importToken:")
+	if len(id) < 2 {
+		return fmt.Errorf("[ERROR] Incorrect ID %s: Id should be a combination of tokenID:importToken:InstanceCRN", d.Id())
+	}
+	d.Set("instance_id", getInstanceIDFromCRN(id[1]))
+	d.Set("token_id", id[0])
+	return nil
+}
+
+// Import tokens cannot be deleted; they expire on their own.
+func resourceIBMKmsImportTokenDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
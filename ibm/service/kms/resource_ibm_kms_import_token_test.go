@@ -0,0 +1,50 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMKMSResource_ImportToken(t *testing.T) {
+	instanceName := fmt.Sprintf("tf_kms_%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMKmsResourceImportTokenConfig(instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ibm_kms_import_token.test", "token_id"),
+					resource.TestCheckResourceAttrSet("ibm_kms_import_token.test", "expiration_date"),
+					resource.TestCheckResourceAttr("ibm_kms_import_token.test", "max_allowed_retrievals", "2"),
+					resource.TestCheckResourceAttr("ibm_kms_import_token.test", "remaining_retrievals", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMKmsResourceImportTokenConfig(instanceName string) string {
+	return fmt.Sprintf(`
+	resource "ibm_resource_instance" "kms_instance" {
+		name     = "%s"
+		service  = "kms"
+		plan     = "tiered-pricing"
+		location = "us-south"
+	}
+	resource "ibm_kms_import_token" "test" {
+		instance_id            = ibm_resource_instance.kms_instance.guid
+		expiration             = 1200
+		max_allowed_retrievals = 2
+	}
+`, instanceName)
+}
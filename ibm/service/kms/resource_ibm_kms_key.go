@@ -5,7 +5,11 @@ package kms
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"net/url"
 	"strconv"
 	"strings"
@@ -109,6 +113,43 @@ func ResourceIBMKmskey() *schema.Resource {
 				ForceNew:    true,
 				Description: "Only for imported root key",
 			},
+			"secure_import": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"payload", "encrypted_nonce", "iv_value"},
+				Description:   "Import a root key with an import token. The key material is wrapped locally and is not stored in the state",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_material": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Sensitive:    true,
+							ExactlyOneOf: []string{"secure_import.0.key_material", "secure_import.0.key_material_file"},
+							StateFunc: func(v interface{}) string {
+								hash := sha256.Sum256([]byte(v.(string)))
+								return hex.EncodeToString(hash[:])
+							},
+							Description: "The base64 encoded key material to import. Only a hash of the value is stored in the state",
+						},
+						"key_material_file": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ExactlyOneOf: []string{"secure_import.0.key_material", "secure_import.0.key_material_file"},
+							Description:  "The path of a file that contains the raw key material to import",
+						},
+						"import_token_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The ID of the import token to use. If not set, a single use import token is created for the import",
+						},
+					},
+				},
+			},
 			"force_delete": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -176,6 +217,16 @@ func resourceIBMKmsKeyCreate(d *schema.ResourceData, meta interface{}) error {
 
 	kpAPI.Config.KeyRing = d.Get("key_ring_id").(string)
 
+	if _, ok := d.GetOk("secure_import"); ok {
+		if keyData.Extractable {
+			return fmt.Errorf("[ERROR] secure_import can only be used to import root keys, set standard_key to false")
+		}
+		keyData.Payload, keyData.EncryptedNonce, keyData.IV, err = wrapKeyMaterialWithImportToken(d, kpAPI)
+		if err != nil {
+			return err
+		}
+	}
+
 	key, err := kpAPI.CreateImportedKey(context.Background(), keyData.Name, keyData.Expiration, keyData.Payload, keyData.EncryptedNonce, keyData.IV, keyData.Extractable)
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error while creating key: %s", err)
@@ -276,6 +327,55 @@ func populateKPClient(d *schema.ResourceData, meta interface{}, instanceID strin
 	return kpAPI, instanceData.CRN, nil
 }
 
+// Wrap the key material of secure_import with the transport key of an import
+// token: the key material is encrypted with RSA-OAEP and the nonce of the
+// token with AES-GCM, as required to import a root key.
+func wrapKeyMaterialWithImportToken(d *schema.ResourceData, kpAPI *kp.Client) (payload, encryptedNonce, iv string, err error) {
+	var keyMaterial string
+	if v, ok := d.GetOk("secure_import.0.key_material"); ok {
+		keyMaterial = strings.TrimSpace(v.(string))
+	} else {
+		content, err := ioutil.ReadFile(d.Get("secure_import.0.key_material_file").(string))
+		if err != nil {
+			return "", "", "", fmt.Errorf("[ERROR] Error reading key material file: %s", err)
+		}
+		keyMaterial = base64.StdEncoding.EncodeToString(content)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(keyMaterial)
+	if err != nil {
+		return "", "", "", fmt.Errorf("[ERROR] The key material is not base64 encoded: %s", err)
+	}
+	if l := len(decoded); l != 16 && l != 24 && l != 32 {
+		return "", "", "", fmt.Errorf("[ERROR] The key material must be 128, 192 or 256 bits long, got %d bits", l*8)
+	}
+
+	tokenID := d.Get("secure_import.0.import_token_id").(string)
+	if tokenID == "" {
+		token, err := kpAPI.CreateImportToken(context.Background(), 300, 1)
+		if err != nil {
+			return "", "", "", fmt.Errorf("[ERROR] Error while creating import token: %s", err)
+		}
+		tokenID = token.ID
+	}
+	transportKey, err := kpAPI.GetImportTokenTransportKey(context.Background())
+	if err != nil {
+		return "", "", "", fmt.Errorf("[ERROR] Error while retrieving import token: %s", err)
+	}
+	if transportKey.ID != "" && transportKey.ID != tokenID {
+		return "", "", "", fmt.Errorf("[ERROR] The import token %s was replaced by import token %s of the instance", tokenID, transportKey.ID)
+	}
+
+	payload, err = kp.EncryptKey(keyMaterial, transportKey.Payload)
+	if err != nil {
+		return "", "", "", fmt.Errorf("[ERROR] Error while wrapping key material: %s", err)
+	}
+	encryptedNonce, iv, err = kp.EncryptNonce(keyMaterial, transportKey.Nonce, "")
+	if err != nil {
+		return "", "", "", fmt.Errorf("[ERROR] Error while encrypting nonce: %s", err)
+	}
+	return payload, encryptedNonce, iv, nil
+}
+
 // Set Key Details in the schema
 func setKeyDetails(d *schema.ResourceData, meta interface{}, instanceID string, instanceCRN string, key *kp.Key, kpAPI *kp.Client) error {
 	d.Set("instance_id", instanceID)
@@ -283,8 +383,12 @@ func setKeyDetails(d *schema.ResourceData, meta interface{}, instanceID string,
 	d.Set("key_id", key.ID)
 	d.Set("standard_key", key.Extractable)
 	d.Set("payload", d.Get("payload"))
-	d.Set("encrypted_nonce", key.EncryptedNonce)
-	d.Set("iv_value", key.IV)
+	// With secure_import the nonce and IV are generated during the import
+	// and are not part of the configuration.
+	if _, ok := d.GetOk("secure_import"); !ok {
+		d.Set("encrypted_nonce", key.EncryptedNonce)
+		d.Set("iv_value", key.IV)
+	}
 	d.Set("key_name", key.Name)
 	d.Set("crn", key.CRN)
 	if strings.Contains((kpAPI.URL).String(), "private") || strings.Contains(kpAPI.Config.BaseURL, "private") {
@@ -4,6 +4,8 @@
 package kms_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"math/rand"
 	"regexp"
@@ -182,6 +184,56 @@ func testAccCheckIBMKmsResourceImportConfig(instanceName, resource, KeyName stri
 `, instanceName, resource, KeyName, standard_key, payload)
 }
 
+func TestAccIBMKMSResource_SecureImport(t *testing.T) {
+	instanceName := fmt.Sprintf("kms_%d", acctest.RandIntRange(10, 100))
+	keyName := fmt.Sprintf("key_%d", acctest.RandIntRange(10, 100))
+	keyMaterial := "LqMWNtSi3Snr4gFNO0PsFFLFRNs57mSXCQE7O2oE+g0="
+	keyMaterialHash := sha256.Sum256([]byte(keyMaterial))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMKmsResourceSecureImportConfig(instanceName, keyName, keyMaterial),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_kms_key.test", "key_name", keyName),
+					resource.TestCheckResourceAttr("ibm_kms_key.test", "standard_key", "false"),
+					resource.TestCheckResourceAttr("ibm_kms_key.test", "secure_import.0.key_material", hex.EncodeToString(keyMaterialHash[:])),
+					resource.TestCheckResourceAttr("ibm_kms_key.test", "payload", ""),
+					resource.TestCheckResourceAttrPair("ibm_kms_key.test", "secure_import.0.import_token_id", "ibm_kms_import_token.test", "token_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMKmsResourceSecureImportConfig(instanceName, KeyName, keyMaterial string) string {
+	return fmt.Sprintf(`
+	resource "ibm_resource_instance" "kms_instance" {
+		name              = "%s"
+		service           = "kms"
+		plan              = "tiered-pricing"
+		location          = "us-south"
+	}
+	resource "ibm_kms_import_token" "test" {
+		instance_id            = ibm_resource_instance.kms_instance.guid
+		expiration             = 600
+		max_allowed_retrievals = 1
+	}
+	resource "ibm_kms_key" "test" {
+		instance_id  = ibm_resource_instance.kms_instance.guid
+		key_name     = "%s"
+		standard_key = false
+		force_delete = true
+		secure_import {
+			key_material    = "%s"
+			import_token_id = ibm_kms_import_token.test.token_id
+		}
+	}
+`, instanceName, KeyName, keyMaterial)
+}
+
 func testAccCheckIBMKmsResourceRootkeyWithCOSConfig(instanceName, resource, KeyName, cosInstanceName, bucketName string) string {
 	return fmt.Sprintf(`
 	resource "ibm_resource_instance" "kms_instance1" {
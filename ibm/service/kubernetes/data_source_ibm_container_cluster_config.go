@@ -4,20 +4,30 @@
 package kubernetes
 
 import (
+	"archive/zip"
+	"bytes"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	homedir "github.com/mitchellh/go-homedir"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	v1 "github.com/IBM-Cloud/bluemix-go/api/container/containerv1"
+	v2 "github.com/IBM-Cloud/bluemix-go/api/container/containerv2"
 	"github.com/IBM-Cloud/bluemix-go/helpers"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
 )
 
@@ -71,7 +81,7 @@ func DataSourceIBMContainerClusterConfig() *schema.Resource {
 				Computed:    true,
 			},
 			"download": {
-				Description: "If set to false will not download the config, otherwise they are downloaded each time but onto the same path for a given cluster name/id",
+				Description: "If set to false will not download the config, otherwise they are downloaded each time but onto the same path for a given cluster name/id. It can not be false with in_memory, endpoint_type or exec_plugin",
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     true,
@@ -83,11 +93,65 @@ func DataSourceIBMContainerClusterConfig() *schema.Resource {
 				Default:     false,
 			},
 			"network": {
-				Description: "If set to true will download the Calico network config with the Admin config",
+				Description:   "If set to true will download the Calico network config with the Admin config",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"in_memory", "endpoint_type", "exec_plugin"},
+			},
+			"in_memory": {
+				Description: "If set to true, the config is not written to the config_dir, and is returned in kubeconfig",
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
 			},
+			"endpoint_type": {
+				Description:  "The service endpoint of the cluster master in the config, public, private or vpe. Default is the public service endpoint",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private", "vpe"}),
+			},
+			"exec_plugin": {
+				Description: "The exec credential plugin that the config uses to get a token for the cluster, instead of embedding the token",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"command": {
+							Description: "The command that prints the ExecCredential",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"args": {
+							Description: "The arguments of the command",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"env": {
+							Description: "The environment variables of the command",
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"api_version": {
+							Description: "The ExecCredential API version of the command",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "client.authentication.k8s.io/v1",
+							ValidateFunc: validate.ValidateAllowedStringValues([]string{
+								"client.authentication.k8s.io/v1", "client.authentication.k8s.io/v1beta1"}),
+						},
+					},
+				},
+			},
+			"kubeconfig": {
+				Description: "The content of the kubernetes config, with the certificates embedded. It is set when in_memory, endpoint_type or exec_plugin is set",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
 			"config_file_path": {
 				Description: "The absolute path to the kubernetes config yml file ",
 				Type:        schema.TypeString,
@@ -151,6 +215,20 @@ func dataSourceIBMContainerClusterConfigRead(d *schema.ResourceData, meta interf
 	admin := d.Get("admin").(bool)
 	configDir := d.Get("config_dir").(string)
 	network := d.Get("network").(bool)
+	inMemory := d.Get("in_memory").(bool)
+	endpointType := d.Get("endpoint_type").(string)
+	_, execPlugin := d.GetOk("exec_plugin")
+
+	// The config is built in memory when it is not written to disk, or when
+	// it differs from the config that the API stores in the config_dir. A
+	// config that is not downloaded is the one already in the config_dir,
+	// which has neither.
+	if inMemory || endpointType != "" || execPlugin {
+		if !download {
+			return fmt.Errorf("[ERROR] download can not be false with in_memory, endpoint_type or exec_plugin, the config in the config_dir is not built with them")
+		}
+		return dataSourceIBMContainerClusterKubeconfigRead(d, meta)
+	}
 
 	clusterId := "Cluster_Config_" + name
 	conns.IbmMutexKV.Lock(clusterId)
@@ -250,3 +328,226 @@ func dataSourceIBMContainerClusterConfigRead(d *schema.ResourceData, meta interf
 	d.Set("config_dir", configDir)
 	return nil
 }
+
+// dataSourceIBMContainerClusterKubeconfigRead reads the config of the cluster
+// without the config_dir, and writes it to the config_dir unless in_memory
+// is set.
+func dataSourceIBMContainerClusterKubeconfigRead(d *schema.ResourceData, meta interface{}) error {
+	csClient, err := meta.(conns.ClientSession).VpcContainerAPI()
+	if err != nil {
+		return err
+	}
+	name := d.Get("cluster_name_id").(string)
+	admin := d.Get("admin").(bool)
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	var execConfig *clientcmdapi.ExecConfig
+	if v, ok := d.GetOk("exec_plugin"); ok {
+		execConfig = expandClusterConfigExecPlugin(v.([]interface{})[0].(map[string]interface{}))
+	}
+
+	var config *clientcmdapi.Config
+	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+		var err error
+		config, err = getClusterKubeconfig(csClient, name, admin, d.Get("endpoint_type").(string), execConfig, targetEnv)
+		if err != nil {
+			log.Printf("[DEBUG] Failed to fetch cluster config err %s", err)
+			if strings.Contains(err.Error(), "Could not login to openshift account runtime error:") {
+				return resource.RetryableError(err)
+			}
+			if intermittentUserLookupFailure, _ := regexp.MatchString("Error: lookup of user for \"(.+)\" failed", err.Error()); intermittentUserLookupFailure {
+				// Intermittent error resulting from synchronisation delay
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if conns.IsResourceTimeoutError(err) {
+		config, err = getClusterKubeconfig(csClient, name, admin, d.Get("endpoint_type").(string), execConfig, targetEnv)
+	}
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error getting the cluster config [%s]: %s", name, err)
+	}
+	kubeconfig, err := clientcmd.Write(*config)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error writing the cluster config [%s]: %s", name, err)
+	}
+
+	if !d.Get("in_memory").(bool) {
+		clusterId := "Cluster_Config_" + name
+		conns.IbmMutexKV.Lock(clusterId)
+		defer conns.IbmMutexKV.Unlock(clusterId)
+
+		configDir := d.Get("config_dir").(string)
+		if len(configDir) == 0 {
+			configDir, err = homedir.Dir()
+			if err != nil {
+				return fmt.Errorf("[ERROR] Error fetching homedir: %s", err)
+			}
+		}
+		configDir, _ = filepath.Abs(configDir)
+		resultDir := v1.ComputeClusterConfigDir(configDir, name, admin)
+		if err := os.MkdirAll(resultDir, 0755); err != nil {
+			return fmt.Errorf("[ERROR] Error creating directory to download the cluster config: %s", err)
+		}
+		configPath := filepath.Join(resultDir, "config.yml")
+		if err := os.WriteFile(configPath, kubeconfig, 0600); err != nil {
+			return fmt.Errorf("[ERROR] Error writing the cluster config [%s]: %s", name, err)
+		}
+		d.Set("config_dir", configDir)
+		d.Set("config_file_path", configPath)
+	}
+
+	d.SetId(name)
+	d.Set("kubeconfig", string(kubeconfig))
+	if context, ok := config.Contexts[config.CurrentContext]; ok {
+		if cluster, ok := config.Clusters[context.Cluster]; ok {
+			d.Set("host", cluster.Server)
+			d.Set("ca_certificate", string(cluster.CertificateAuthorityData))
+		}
+		if authInfo, ok := config.AuthInfos[context.AuthInfo]; ok {
+			d.Set("admin_key", string(authInfo.ClientKeyData))
+			d.Set("admin_certificate", string(authInfo.ClientCertificateData))
+			token := authInfo.Token
+			if authInfo.AuthProvider != nil {
+				token = authInfo.AuthProvider.Config["id-token"]
+			}
+			d.Set("token", token)
+		}
+	}
+	return nil
+}
+
+// getClusterKubeconfig downloads the config zip of the cluster to memory, and
+// returns the config with the certificates of the zip embedded in it.
+func getClusterKubeconfig(csClient v2.ContainerServiceAPI, name string, admin bool, endpointType string, execConfig *clientcmdapi.ExecConfig, target v2.ClusterTargetHeader) (*clientcmdapi.Config, error) {
+	clusterInfo, err := csClient.Clusters().GetCluster(name, target)
+	if err != nil {
+		return nil, err
+	}
+	// ServerURL is blank for vpc clusters
+	if clusterInfo.ServerURL == "" {
+		clusterInfo.ServerURL = clusterInfo.MasterURL
+	}
+	postBody := map[string]interface{}{
+		"cluster": name,
+		"format":  "zip",
+	}
+	if admin {
+		postBody["admin"] = true
+	}
+	if endpointType != "" && endpointType != "public" {
+		postBody["endpointType"] = endpointType
+	}
+	if clusterInfo.Provider == "satellite" {
+		postBody["endpointType"] = "link"
+		postBody["admin"] = true
+	}
+
+	// The clusters API only downloads the config zip to a directory, the
+	// client of the container service is used to download it to memory
+	client, ok := csClient.(interface {
+		Post(path string, data interface{}, respV interface{}, extraHeader ...interface{}) (*http.Response, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("the container service client does not support downloading the cluster config")
+	}
+	var configZip bytes.Buffer
+	if _, err := client.Post("/v2/applyRBACAndGetKubeconfig", postBody, &configZip, target.ToMap()); err != nil {
+		return nil, err
+	}
+	zipReader, err := zip.NewReader(bytes.NewReader(configZip.Bytes()), int64(configZip.Len()))
+	if err != nil {
+		return nil, fmt.Errorf("Error reading the cluster config zip: %s", err)
+	}
+	files := make(map[string][]byte, len(zipReader.File))
+	var kubeconfig []byte
+	for _, f := range zipReader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		base := filepath.Base(f.Name)
+		files[base] = content
+		if strings.HasSuffix(base, ".yml") || strings.HasSuffix(base, ".yaml") {
+			kubeconfig = content
+		}
+	}
+	if kubeconfig == nil {
+		return nil, fmt.Errorf("Unable to locate kube config in zip archive")
+	}
+
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	embed := func(path *string, data *[]byte) {
+		if content, ok := files[filepath.Base(*path)]; ok && *path != "" {
+			*data = content
+			*path = ""
+		}
+	}
+	for _, cluster := range config.Clusters {
+		embed(&cluster.CertificateAuthority, &cluster.CertificateAuthorityData)
+	}
+	for _, authInfo := range config.AuthInfos {
+		embed(&authInfo.ClientCertificate, &authInfo.ClientCertificateData)
+		embed(&authInfo.ClientKey, &authInfo.ClientKeyData)
+	}
+
+	if execConfig != nil {
+		for key := range config.AuthInfos {
+			config.AuthInfos[key] = &clientcmdapi.AuthInfo{Exec: execConfig}
+		}
+		return config, nil
+	}
+	if clusterInfo.Type == "openshift" && clusterInfo.Provider != "satellite" {
+		// Block to add token for openshift clusters, like GetClusterConfigDetail
+		clusters, ok := csClient.Clusters().(interface {
+			FetchOCTokenForKubeConfig(kubecfg []byte, cMeta *v2.ClusterInfo, skipSSLVerification bool) ([]byte, error)
+		})
+		if !ok {
+			return nil, fmt.Errorf("the container service client does not support openshift tokens")
+		}
+		kubeconfig, err := clientcmd.Write(*config)
+		if err != nil {
+			return nil, err
+		}
+		if kubeconfig, err = clusters.FetchOCTokenForKubeConfig(kubeconfig, clusterInfo, clusterInfo.IsStagingSatelliteCluster()); err != nil {
+			return nil, err
+		}
+		return clientcmd.Load(kubeconfig)
+	}
+	return config, nil
+}
+
+func expandClusterConfigExecPlugin(execPlugin map[string]interface{}) *clientcmdapi.ExecConfig {
+	execConfig := &clientcmdapi.ExecConfig{
+		Command:         execPlugin["command"].(string),
+		Args:            flex.ExpandStringList(execPlugin["args"].([]interface{})),
+		APIVersion:      execPlugin["api_version"].(string),
+		InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+	}
+	env := execPlugin["env"].(map[string]interface{})
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		execConfig.Env = append(execConfig.Env, clientcmdapi.ExecEnvVar{Name: name, Value: env[name].(string)})
+	}
+	return execConfig
+}
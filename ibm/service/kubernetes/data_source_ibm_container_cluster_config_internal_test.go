@@ -0,0 +1,219 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kubernetes
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"reflect"
+	"testing"
+
+	v2 "github.com/IBM-Cloud/bluemix-go/api/container/containerv2"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const testClusterKubeconfig = `apiVersion: v1
+kind: Config
+current-context: mycluster
+clusters:
+- name: mycluster
+  cluster:
+    server: https://c100.us-south.containers.cloud.ibm.com:30000
+    certificate-authority: ca-aaa00-mycluster.pem
+contexts:
+- name: mycluster
+  context:
+    cluster: mycluster
+    user: admin
+users:
+- name: admin
+  user:
+    client-certificate: admin.pem
+    client-key: admin-key.pem
+`
+
+type fakeClusters struct {
+	v2.Clusters
+	clusterInfo v2.ClusterInfo
+}
+
+func (c *fakeClusters) GetCluster(name string, target v2.ClusterTargetHeader) (*v2.ClusterInfo, error) {
+	clusterInfo := c.clusterInfo
+	return &clusterInfo, nil
+}
+
+// fakeContainerServiceAPI serves the config zip of applyRBACAndGetKubeconfig
+// and records the bodies that are posted to it
+type fakeContainerServiceAPI struct {
+	v2.ContainerServiceAPI
+	clusters  *fakeClusters
+	configZip []byte
+	paths     []string
+	bodies    []map[string]interface{}
+}
+
+func (c *fakeContainerServiceAPI) Clusters() v2.Clusters {
+	return c.clusters
+}
+
+func (c *fakeContainerServiceAPI) Post(path string, data interface{}, respV interface{}, extraHeader ...interface{}) (*http.Response, error) {
+	c.paths = append(c.paths, path)
+	c.bodies = append(c.bodies, data.(map[string]interface{}))
+	respV.(*bytes.Buffer).Write(c.configZip)
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func newFakeContainerServiceAPI(t *testing.T, clusterInfo v2.ClusterInfo, files map[string]string) *fakeContainerServiceAPI {
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zipWriter.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &fakeContainerServiceAPI{
+		clusters:  &fakeClusters{clusterInfo: clusterInfo},
+		configZip: buf.Bytes(),
+	}
+}
+
+func testClusterConfigFiles() map[string]string {
+	return map[string]string{
+		"kubeConfig-aaa00/kube-config-aaa00-mycluster.yml": testClusterKubeconfig,
+		"kubeConfig-aaa00/ca-aaa00-mycluster.pem":          "ca-data",
+		"kubeConfig-aaa00/admin.pem":                       "cert-data",
+		"kubeConfig-aaa00/admin-key.pem":                   "key-data",
+	}
+}
+
+func TestGetClusterKubeconfig(t *testing.T) {
+	csClient := newFakeContainerServiceAPI(t, v2.ClusterInfo{Provider: "vpc-gen2", Type: "kubernetes"}, testClusterConfigFiles())
+
+	config, err := getClusterKubeconfig(csClient, "mycluster", true, "", nil, v2.ClusterTargetHeader{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cluster := config.Clusters["mycluster"]
+	if cluster.CertificateAuthority != "" || string(cluster.CertificateAuthorityData) != "ca-data" {
+		t.Fatalf("got certificate authority %q with data %q, want the data of the zip embedded", cluster.CertificateAuthority, cluster.CertificateAuthorityData)
+	}
+	authInfo := config.AuthInfos["admin"]
+	if authInfo.ClientCertificate != "" || string(authInfo.ClientCertificateData) != "cert-data" {
+		t.Fatalf("got client certificate %q with data %q, want the data of the zip embedded", authInfo.ClientCertificate, authInfo.ClientCertificateData)
+	}
+	if authInfo.ClientKey != "" || string(authInfo.ClientKeyData) != "key-data" {
+		t.Fatalf("got client key %q with data %q, want the data of the zip embedded", authInfo.ClientKey, authInfo.ClientKeyData)
+	}
+
+	want := map[string]interface{}{"cluster": "mycluster", "format": "zip", "admin": true}
+	if !reflect.DeepEqual(csClient.paths, []string{"/v2/applyRBACAndGetKubeconfig"}) || !reflect.DeepEqual(csClient.bodies[0], want) {
+		t.Fatalf("got posts %v with bodies %v, want %v", csClient.paths, csClient.bodies, want)
+	}
+}
+
+func TestGetClusterKubeconfigEndpointType(t *testing.T) {
+	testCases := []struct {
+		name         string
+		clusterInfo  v2.ClusterInfo
+		admin        bool
+		endpointType string
+		want         map[string]interface{}
+	}{
+		{
+			name:         "public",
+			clusterInfo:  v2.ClusterInfo{Provider: "vpc-gen2"},
+			endpointType: "public",
+			want:         map[string]interface{}{"cluster": "mycluster", "format": "zip"},
+		},
+		{
+			name:         "private",
+			clusterInfo:  v2.ClusterInfo{Provider: "vpc-gen2"},
+			endpointType: "private",
+			want:         map[string]interface{}{"cluster": "mycluster", "format": "zip", "endpointType": "private"},
+		},
+		{
+			name:         "satellite",
+			clusterInfo:  v2.ClusterInfo{Provider: "satellite", Type: "openshift"},
+			endpointType: "private",
+			want:         map[string]interface{}{"cluster": "mycluster", "format": "zip", "endpointType": "link", "admin": true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			csClient := newFakeContainerServiceAPI(t, tc.clusterInfo, testClusterConfigFiles())
+			if _, err := getClusterKubeconfig(csClient, "mycluster", tc.admin, tc.endpointType, nil, v2.ClusterTargetHeader{}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(csClient.bodies) != 1 || !reflect.DeepEqual(csClient.bodies[0], tc.want) {
+				t.Fatalf("got bodies %v, want %v", csClient.bodies, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetClusterKubeconfigExecPlugin(t *testing.T) {
+	csClient := newFakeContainerServiceAPI(t, v2.ClusterInfo{Provider: "vpc-gen2", Type: "kubernetes"}, testClusterConfigFiles())
+	execConfig := &clientcmdapi.ExecConfig{Command: "ibmcloud", APIVersion: "client.authentication.k8s.io/v1beta1"}
+
+	config, err := getClusterKubeconfig(csClient, "mycluster", true, "", execConfig, v2.ClusterTargetHeader{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The client certificates of the admin are replaced by the plugin
+	want := map[string]*clientcmdapi.AuthInfo{"admin": {Exec: execConfig}}
+	if !reflect.DeepEqual(config.AuthInfos, want) {
+		t.Fatalf("got auth infos %+v, want only the exec plugin", config.AuthInfos["admin"])
+	}
+	if string(config.Clusters["mycluster"].CertificateAuthorityData) != "ca-data" {
+		t.Fatalf("got certificate authority data %q, want the data of the zip embedded", config.Clusters["mycluster"].CertificateAuthorityData)
+	}
+}
+
+func TestGetClusterKubeconfigNoConfig(t *testing.T) {
+	csClient := newFakeContainerServiceAPI(t, v2.ClusterInfo{Provider: "vpc-gen2"}, map[string]string{
+		"kubeConfig-aaa00/ca-aaa00-mycluster.pem": "ca-data",
+	})
+
+	if _, err := getClusterKubeconfig(csClient, "mycluster", false, "", nil, v2.ClusterTargetHeader{}); err == nil {
+		t.Fatalf("expected an error for a zip without a kube config")
+	}
+}
+
+func TestExpandClusterConfigExecPlugin(t *testing.T) {
+	execPlugin := map[string]interface{}{
+		"command":     "ibmcloud",
+		"args":        []interface{}{"ks", "cluster", "config"},
+		"api_version": "client.authentication.k8s.io/v1beta1",
+		"env": map[string]interface{}{
+			"IBMCLOUD_REGION":  "us-south",
+			"IBMCLOUD_API_KEY": "apikey",
+		},
+	}
+
+	want := &clientcmdapi.ExecConfig{
+		Command:    "ibmcloud",
+		Args:       []string{"ks", "cluster", "config"},
+		APIVersion: "client.authentication.k8s.io/v1beta1",
+		// The variables are sorted by name, to keep the config stable
+		Env: []clientcmdapi.ExecEnvVar{
+			{Name: "IBMCLOUD_API_KEY", Value: "apikey"},
+			{Name: "IBMCLOUD_REGION", Value: "us-south"},
+		},
+		InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+	}
+	if got := expandClusterConfigExecPlugin(execPlugin); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
@@ -5,6 +5,7 @@ package kubernetes_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
@@ -82,6 +83,31 @@ func TestAccIBMContainer_ClusterConfigCalicoDataSourceBasic(t *testing.T) {
 	})
 }
 
+func TestAccIBMContainer_ClusterConfigDataSourceInMemory(t *testing.T) {
+	clusterName := fmt.Sprintf("tf-cluster-config-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerClusterInMemoryConfigDataSource(clusterName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "kubeconfig"),
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "host"),
+					resource.TestCheckResourceAttr(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "config_file_path", ""),
+					resource.TestMatchResourceAttr(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "kubeconfig", regexp.MustCompile(`command: /usr/local/bin/iks-token`)),
+					resource.TestMatchResourceAttr(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "kubeconfig", regexp.MustCompile(`certificate-authority-data: `)),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckIBMContainerClusterDataSourceConfig(clustername string) string {
 	return fmt.Sprintf(`
 resource "ibm_container_cluster" "testacc_cluster" {
@@ -136,3 +162,28 @@ data "ibm_container_cluster_config" "testacc_ds_cluster" {
   network         = true
 }`, clustername, acc.Datacenter, acc.MachineType, acc.PublicVlanID, acc.PrivateVlanID)
 }
+
+func testAccCheckIBMContainerClusterInMemoryConfigDataSource(clustername string) string {
+	return fmt.Sprintf(`
+	resource "ibm_container_vpc_cluster" "testacc_cluster" {
+		name              = "%[1]s"
+		vpc_id            = "%[2]s"
+		flavor            = "bx2.4x16"
+		worker_count      = 1
+		resource_group_id = "%[3]s"
+		zones {
+			subnet_id = "%[4]s"
+			name      = "us-south-1"
+		}
+		wait_till = "Normal"
+	}
+
+data "ibm_container_cluster_config" "testacc_ds_cluster" {
+  cluster_name_id = ibm_container_vpc_cluster.testacc_cluster.id
+  in_memory       = true
+  endpoint_type   = "private"
+  exec_plugin {
+    command = "/usr/local/bin/iks-token"
+  }
+}`, clustername, acc.IksClusterVpcID, acc.IksClusterResourceGroupID, acc.IksClusterSubnetID)
+}
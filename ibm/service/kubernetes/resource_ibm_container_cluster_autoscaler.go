@@ -0,0 +1,506 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+)
+
+const (
+	clusterAutoscalerNamespace         = "kube-system"
+	clusterAutoscalerConfigMap         = "iks-ca-configmap"
+	clusterAutoscalerWorkerPoolsConfig = "workerPoolsConfig.json"
+)
+
+func ResourceIBMContainerClusterAutoscaler() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMContainerClusterAutoscalerCreate,
+		Read:     resourceIBMContainerClusterAutoscalerRead,
+		Update:   resourceIBMContainerClusterAutoscalerUpdate,
+		Delete:   resourceIBMContainerClusterAutoscalerDelete,
+		Importer: &schema.ResourceImporter{},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		CustomizeDiff: resourceIBMContainerClusterAutoscalerCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Cluster Name or ID",
+				ValidateFunc: validate.InvokeValidator(
+					"ibm_container_cluster_autoscaler",
+					"cluster"),
+			},
+			"resource_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "ID of the resource group.",
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private", "vpe"}),
+				Description:  "The service endpoint of the cluster master that the autoscaler config map is managed through, public, private or vpe. Default is the public service endpoint",
+			},
+			"worker_pool": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The worker pools that the cluster autoscaler scales",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The worker pool name",
+						},
+						"min_size": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validate.ValidateSizePerZone,
+							Description:  "The minimum number of workers per zone",
+						},
+						"max_size": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validate.ValidateSizePerZone,
+							Description:  "The maximum number of workers per zone",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the cluster autoscaler scales the worker pool",
+						},
+					},
+				},
+			},
+			"options": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The global options of the cluster autoscaler, such as scanInterval and expander",
+			},
+			"wait_for_worker_pools": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Wait until the worker pools report that they are autoscaled, so that their worker count is no longer managed",
+			},
+		},
+	}
+}
+
+func ResourceIBMContainerClusterAutoscalerValidator() *validate.ResourceValidator {
+	validateSchema := make([]validate.ValidateSchema, 0)
+	validateSchema = append(validateSchema,
+		validate.ValidateSchema{
+			Identifier:                 "cluster",
+			ValidateFunctionIdentifier: validate.ValidateCloudData,
+			Type:                       validate.TypeString,
+			Required:                   true,
+			CloudDataType:              "cluster",
+			CloudDataRange:             []string{"resolved_to:id"}})
+
+	iBMContainerClusterAutoscalerValidator := validate.ResourceValidator{ResourceName: "ibm_container_cluster_autoscaler", Schema: validateSchema}
+	return &iBMContainerClusterAutoscalerValidator
+}
+
+func resourceIBMContainerClusterAutoscalerCustomizeDiff(context context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	for _, v := range diff.Get("worker_pool").(*schema.Set).List() {
+		pool := v.(map[string]interface{})
+		if pool["min_size"].(int) > pool["max_size"].(int) {
+			return fmt.Errorf("[ERROR] min_size of the worker pool %s is greater than its max_size", pool["name"])
+		}
+	}
+	if _, ok := diff.Get("options").(map[string]interface{})[clusterAutoscalerWorkerPoolsConfig]; ok {
+		return fmt.Errorf("[ERROR] %s can not be set in options, use worker_pool", clusterAutoscalerWorkerPoolsConfig)
+	}
+	return nil
+}
+
+// clusterAutoscalerWorkerPool is a worker pool entry of the autoscaler config
+// map, the fields that are not managed are kept as they are.
+type clusterAutoscalerWorkerPool map[string]interface{}
+
+func (p clusterAutoscalerWorkerPool) name() string {
+	name, _ := p["name"].(string)
+	return name
+}
+
+func (p clusterAutoscalerWorkerPool) size(key string) int {
+	switch size := p[key].(type) {
+	case float64:
+		return int(size)
+	case json.Number:
+		n, _ := size.Int64()
+		return int(n)
+	}
+	return 0
+}
+
+func (p clusterAutoscalerWorkerPool) enabled() bool {
+	enabled, _ := p["enabled"].(bool)
+	return enabled
+}
+
+func resourceIBMContainerClusterAutoscalerCreate(d *schema.ResourceData, meta interface{}) error {
+	cluster := d.Get("cluster").(string)
+	pools := expandClusterAutoscalerWorkerPools(d.Get("worker_pool").(*schema.Set).List())
+	options := expandClusterAutoscalerOptions(d.Get("options").(map[string]interface{}))
+
+	configMaps, err := clusterAutoscalerConfigMaps(d, meta)
+	if err != nil {
+		return err
+	}
+	if err := updateClusterAutoscalerConfigMap(configMaps, pools, nil, options, nil); err != nil {
+		return fmt.Errorf("[ERROR] Error configuring the cluster autoscaler of the cluster (%s): %s", cluster, err)
+	}
+	d.SetId(cluster)
+
+	if d.Get("wait_for_worker_pools").(bool) {
+		if _, err := waitForClusterAutoscalerWorkerPools(d, meta, cluster, pools, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return fmt.Errorf("[ERROR] Error waiting for the worker pools of the cluster (%s) to be autoscaled: %s", cluster, err)
+		}
+	}
+	return readClusterAutoscalerConfigMap(d, configMaps)
+}
+
+func resourceIBMContainerClusterAutoscalerRead(d *schema.ResourceData, meta interface{}) error {
+	d.Set("cluster", d.Id())
+
+	configMaps, err := clusterAutoscalerConfigMaps(d, meta)
+	if err != nil {
+		return err
+	}
+	return readClusterAutoscalerConfigMap(d, configMaps)
+}
+
+// readClusterAutoscalerConfigMap sets the worker pools and options of the
+// autoscaler config map that the resource manages.
+func readClusterAutoscalerConfigMap(d *schema.ResourceData, configMaps corev1.ConfigMapInterface) error {
+	cluster := d.Id()
+	configMap, err := configMaps.Get(context.Background(), clusterAutoscalerConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if apierror.IsNotFound(err) {
+			log.Printf("[WARN] The cluster autoscaler config map of the cluster (%s) is not found", cluster)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("[ERROR] Error getting the cluster autoscaler config map of the cluster (%s): %s", cluster, err)
+	}
+	entries, err := parseClusterAutoscalerWorkerPools(configMap.Data[clusterAutoscalerWorkerPoolsConfig])
+	if err != nil {
+		return err
+	}
+
+	// Only the worker pools and options in the configuration are managed.
+	// The state of an imported resource has no worker pools, as they are
+	// required otherwise, and the enabled worker pools and all options are
+	// imported then, the disabled entries being the defaults of the add-on.
+	managed := make(map[string]bool)
+	for _, v := range d.Get("worker_pool").(*schema.Set).List() {
+		managed[v.(map[string]interface{})["name"].(string)] = true
+	}
+	imported := len(managed) == 0
+	pools := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		if imported && !entry.enabled() || !imported && !managed[entry.name()] {
+			continue
+		}
+		pools = append(pools, map[string]interface{}{
+			"name":     entry.name(),
+			"min_size": entry.size("minSize"),
+			"max_size": entry.size("maxSize"),
+			"enabled":  entry.enabled(),
+		})
+	}
+	d.Set("worker_pool", pools)
+
+	managedOptions := d.Get("options").(map[string]interface{})
+	options := make(map[string]interface{})
+	for key, value := range configMap.Data {
+		if _, ok := managedOptions[key]; (ok || imported) && key != clusterAutoscalerWorkerPoolsConfig {
+			options[key] = value
+		}
+	}
+	d.Set("options", options)
+	return nil
+}
+
+func resourceIBMContainerClusterAutoscalerUpdate(d *schema.ResourceData, meta interface{}) error {
+	cluster := d.Id()
+	pools := expandClusterAutoscalerWorkerPools(d.Get("worker_pool").(*schema.Set).List())
+	options := expandClusterAutoscalerOptions(d.Get("options").(map[string]interface{}))
+
+	// Worker pools that are removed from the configuration are disabled, and
+	// options that are removed fall back to the default of the add-on
+	var disabled, removedOptions []string
+	if d.HasChange("worker_pool") {
+		old, _ := d.GetChange("worker_pool")
+		for _, name := range sortedClusterAutoscalerWorkerPoolNames(expandClusterAutoscalerWorkerPools(old.(*schema.Set).List())) {
+			if _, ok := pools[name]; !ok {
+				disabled = append(disabled, name)
+			}
+		}
+	}
+	if d.HasChange("options") {
+		old, _ := d.GetChange("options")
+		for key := range old.(map[string]interface{}) {
+			if _, ok := options[key]; !ok {
+				removedOptions = append(removedOptions, key)
+			}
+		}
+	}
+
+	configMaps, err := clusterAutoscalerConfigMaps(d, meta)
+	if err != nil {
+		return err
+	}
+	if err := updateClusterAutoscalerConfigMap(configMaps, pools, disabled, options, removedOptions); err != nil {
+		return fmt.Errorf("[ERROR] Error configuring the cluster autoscaler of the cluster (%s): %s", cluster, err)
+	}
+
+	if d.Get("wait_for_worker_pools").(bool) {
+		for _, name := range disabled {
+			pools[name] = clusterAutoscalerWorkerPool{"name": name, "enabled": false}
+		}
+		if _, err := waitForClusterAutoscalerWorkerPools(d, meta, cluster, pools, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("[ERROR] Error waiting for the worker pools of the cluster (%s) to be autoscaled: %s", cluster, err)
+		}
+	}
+	return readClusterAutoscalerConfigMap(d, configMaps)
+}
+
+func resourceIBMContainerClusterAutoscalerDelete(d *schema.ResourceData, meta interface{}) error {
+	cluster := d.Id()
+	pools := expandClusterAutoscalerWorkerPools(d.Get("worker_pool").(*schema.Set).List())
+	disabled := sortedClusterAutoscalerWorkerPoolNames(pools)
+	removedOptions := make([]string, 0)
+	for key := range d.Get("options").(map[string]interface{}) {
+		removedOptions = append(removedOptions, key)
+	}
+
+	configMaps, err := clusterAutoscalerConfigMaps(d, meta)
+	if err != nil {
+		return err
+	}
+	err = updateClusterAutoscalerConfigMap(configMaps, nil, disabled, nil, removedOptions)
+	if err != nil && !apierror.IsNotFound(err) {
+		return fmt.Errorf("[ERROR] Error disabling the cluster autoscaler of the cluster (%s): %s", cluster, err)
+	}
+
+	if err == nil && d.Get("wait_for_worker_pools").(bool) {
+		for _, name := range disabled {
+			pools[name] = clusterAutoscalerWorkerPool{"name": name, "enabled": false}
+		}
+		if _, err := waitForClusterAutoscalerWorkerPools(d, meta, cluster, pools, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return fmt.Errorf("[ERROR] Error waiting for the worker pools of the cluster (%s) to stop being autoscaled: %s", cluster, err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// clusterAutoscalerConfigMaps returns the config maps client of the namespace
+// of the cluster autoscaler, through the admin config of the cluster. Getting
+// the admin config applies the RBAC of the user, so it is done once for each
+// operation of the resource.
+func clusterAutoscalerConfigMaps(d *schema.ResourceData, meta interface{}) (corev1.ConfigMapInterface, error) {
+	csClient, err := meta.(conns.ClientSession).VpcContainerAPI()
+	if err != nil {
+		return nil, err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return nil, err
+	}
+	cluster := d.Get("cluster").(string)
+	if cluster == "" {
+		cluster = d.Id()
+	}
+
+	config, err := getClusterKubeconfig(csClient, cluster, true, d.Get("endpoint_type").(string), nil, targetEnv)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting the cluster config [%s]: %s", cluster, err)
+	}
+	restConfig, err := clientcmd.NewDefaultClientConfig(*config, nil).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Failed to set context: %s", err)
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Failed to create clientset: %s", err)
+	}
+	return clientset.CoreV1().ConfigMaps(clusterAutoscalerNamespace), nil
+}
+
+// updateClusterAutoscalerConfigMap sets the worker pool entries and options of
+// the autoscaler config map, and disables the worker pools in disabled.
+func updateClusterAutoscalerConfigMap(configMaps corev1.ConfigMapInterface, pools map[string]clusterAutoscalerWorkerPool, disabled []string, options map[string]string, removedOptions []string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := configMaps.Get(context.Background(), clusterAutoscalerConfigMap, metav1.GetOptions{})
+		if err != nil {
+			if apierror.IsNotFound(err) {
+				return fmt.Errorf("enable the cluster-autoscaler add-on of the cluster with ibm_container_addons: %w", err)
+			}
+			return err
+		}
+		entries, err := parseClusterAutoscalerWorkerPools(configMap.Data[clusterAutoscalerWorkerPoolsConfig])
+		if err != nil {
+			return err
+		}
+
+		indexes := make(map[string]int, len(entries))
+		for i, entry := range entries {
+			indexes[entry.name()] = i
+		}
+		for _, name := range sortedClusterAutoscalerWorkerPoolNames(pools) {
+			i, ok := indexes[name]
+			if !ok {
+				entries = append(entries, clusterAutoscalerWorkerPool{"name": name})
+				i = len(entries) - 1
+			}
+			for key, value := range pools[name] {
+				entries[i][key] = value
+			}
+		}
+		for _, name := range disabled {
+			if i, ok := indexes[name]; ok {
+				entries[i]["enabled"] = false
+			}
+		}
+
+		workerPoolsConfig, err := json.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string)
+		}
+		configMap.Data[clusterAutoscalerWorkerPoolsConfig] = string(workerPoolsConfig)
+		for key, value := range options {
+			configMap.Data[key] = value
+		}
+		for _, key := range removedOptions {
+			delete(configMap.Data, key)
+		}
+		_, err = configMaps.Update(context.Background(), configMap, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// waitForClusterAutoscalerWorkerPools waits until the worker pools report that
+// they are autoscaled when they are enabled, and that they are not otherwise.
+func waitForClusterAutoscalerWorkerPools(d *schema.ResourceData, meta interface{}, cluster string, pools map[string]clusterAutoscalerWorkerPool, timeout time.Duration) (interface{}, error) {
+	csClient, err := meta.(conns.ClientSession).VpcContainerAPI()
+	if err != nil {
+		return nil, err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"done"},
+		Refresh: func() (interface{}, string, error) {
+			workerPools, err := csClient.WorkerPools().ListWorkerPools(cluster, targetEnv)
+			if err != nil {
+				return nil, "", err
+			}
+			autoscaled := make(map[string]bool, len(workerPools))
+			for _, workerPool := range workerPools {
+				autoscaled[workerPool.PoolName] = workerPool.AutoscaleEnabled
+			}
+			pending := []string{}
+			for _, name := range sortedClusterAutoscalerWorkerPoolNames(pools) {
+				enabled, ok := autoscaled[name]
+				if !ok {
+					return nil, "", fmt.Errorf("the worker pool %s is not found in the cluster", name)
+				}
+				if enabled != pools[name].enabled() {
+					pending = append(pending, name)
+				}
+			}
+			if len(pending) > 0 {
+				log.Printf("[DEBUG] Waiting for the autoscaling of the worker pools %s to be updated", strings.Join(pending, ", "))
+				return workerPools, "pending", nil
+			}
+			return workerPools, "done", nil
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+	return stateConf.WaitForState()
+}
+
+func parseClusterAutoscalerWorkerPools(workerPoolsConfig string) ([]clusterAutoscalerWorkerPool, error) {
+	entries := []clusterAutoscalerWorkerPool{}
+	if strings.TrimSpace(workerPoolsConfig) == "" {
+		return entries, nil
+	}
+	if err := json.Unmarshal([]byte(workerPoolsConfig), &entries); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error parsing %s of the cluster autoscaler config map: %s", clusterAutoscalerWorkerPoolsConfig, err)
+	}
+	return entries, nil
+}
+
+func expandClusterAutoscalerWorkerPools(workerPools []interface{}) map[string]clusterAutoscalerWorkerPool {
+	pools := make(map[string]clusterAutoscalerWorkerPool, len(workerPools))
+	for _, v := range workerPools {
+		pool := v.(map[string]interface{})
+		name := pool["name"].(string)
+		pools[name] = clusterAutoscalerWorkerPool{
+			"name":    name,
+			"minSize": pool["min_size"].(int),
+			"maxSize": pool["max_size"].(int),
+			"enabled": pool["enabled"].(bool),
+		}
+	}
+	return pools
+}
+
+func expandClusterAutoscalerOptions(options map[string]interface{}) map[string]string {
+	expanded := make(map[string]string, len(options))
+	for key, value := range options {
+		expanded[key] = value.(string)
+	}
+	return expanded
+}
+
+func sortedClusterAutoscalerWorkerPoolNames(pools map[string]clusterAutoscalerWorkerPool) []string {
+	names := make([]string, 0, len(pools))
+	for name := range pools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
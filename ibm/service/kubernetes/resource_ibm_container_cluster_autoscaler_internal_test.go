@@ -0,0 +1,162 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	v1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+const testClusterAutoscalerWorkerPoolsConfig = `[
+	{"name": "default", "minSize": 1, "maxSize": 2, "enabled": false},
+	{"name": "pool1", "minSize": 1, "maxSize": 3, "enabled": true, "zones": ["us-south-1"]}
+]`
+
+func newFakeClusterAutoscalerConfigMaps(data map[string]string) corev1.ConfigMapInterface {
+	clientset := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterAutoscalerConfigMap, Namespace: clusterAutoscalerNamespace},
+		Data:       data,
+	})
+	return clientset.CoreV1().ConfigMaps(clusterAutoscalerNamespace)
+}
+
+func getFakeClusterAutoscalerConfigMap(t *testing.T, configMaps corev1.ConfigMapInterface) (map[string]string, []map[string]interface{}) {
+	configMap, err := configMaps.Get(context.Background(), clusterAutoscalerConfigMap, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []map[string]interface{}
+	if err := json.Unmarshal([]byte(configMap.Data[clusterAutoscalerWorkerPoolsConfig]), &entries); err != nil {
+		t.Fatal(err)
+	}
+	return configMap.Data, entries
+}
+
+func TestUpdateClusterAutoscalerConfigMap(t *testing.T) {
+	configMaps := newFakeClusterAutoscalerConfigMaps(map[string]string{
+		clusterAutoscalerWorkerPoolsConfig: testClusterAutoscalerWorkerPoolsConfig,
+		"expander":                         "random",
+	})
+
+	pools := expandClusterAutoscalerWorkerPools([]interface{}{
+		map[string]interface{}{"name": "pool1", "min_size": 2, "max_size": 5, "enabled": true},
+		map[string]interface{}{"name": "pool2", "min_size": 1, "max_size": 2, "enabled": true},
+	})
+	options := map[string]string{"scaleDownUnneededTime": "10m"}
+	if err := updateClusterAutoscalerConfigMap(configMaps, pools, nil, options, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	data, entries := getFakeClusterAutoscalerConfigMap(t, configMaps)
+	// The existing entries are updated in place and keep the fields that are
+	// not managed, the new worker pools are added at the end
+	want := []map[string]interface{}{
+		{"name": "default", "minSize": 1.0, "maxSize": 2.0, "enabled": false},
+		{"name": "pool1", "minSize": 2.0, "maxSize": 5.0, "enabled": true, "zones": []interface{}{"us-south-1"}},
+		{"name": "pool2", "minSize": 1.0, "maxSize": 2.0, "enabled": true},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Fatalf("got entries %v, want %v", entries, want)
+	}
+	if data["expander"] != "random" || data["scaleDownUnneededTime"] != "10m" {
+		t.Fatalf("got data %v, want the option added to the existing ones", data)
+	}
+}
+
+func TestUpdateClusterAutoscalerConfigMapDisable(t *testing.T) {
+	configMaps := newFakeClusterAutoscalerConfigMaps(map[string]string{
+		clusterAutoscalerWorkerPoolsConfig: testClusterAutoscalerWorkerPoolsConfig,
+		"expander":                         "random",
+		"scaleDownUnneededTime":            "10m",
+	})
+
+	if err := updateClusterAutoscalerConfigMap(configMaps, nil, []string{"pool1", "pool2"}, nil, []string{"scaleDownUnneededTime"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	data, entries := getFakeClusterAutoscalerConfigMap(t, configMaps)
+	// Disabled worker pools keep their entry, and worker pools without an
+	// entry are not added
+	want := []map[string]interface{}{
+		{"name": "default", "minSize": 1.0, "maxSize": 2.0, "enabled": false},
+		{"name": "pool1", "minSize": 1.0, "maxSize": 3.0, "enabled": false, "zones": []interface{}{"us-south-1"}},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Fatalf("got entries %v, want %v", entries, want)
+	}
+	if _, ok := data["scaleDownUnneededTime"]; ok || data["expander"] != "random" {
+		t.Fatalf("got data %v, want only the removed option to be deleted", data)
+	}
+}
+
+func TestUpdateClusterAutoscalerConfigMapNotFound(t *testing.T) {
+	configMaps := fake.NewSimpleClientset().CoreV1().ConfigMaps(clusterAutoscalerNamespace)
+
+	err := updateClusterAutoscalerConfigMap(configMaps, nil, []string{"pool1"}, nil, nil)
+	if !apierror.IsNotFound(err) {
+		t.Fatalf("got error %v, want a not found error", err)
+	}
+}
+
+func TestReadClusterAutoscalerConfigMap(t *testing.T) {
+	configMaps := newFakeClusterAutoscalerConfigMaps(map[string]string{
+		clusterAutoscalerWorkerPoolsConfig: testClusterAutoscalerWorkerPoolsConfig,
+		"expander":                         "random",
+		"scaleDownUnneededTime":            "15m",
+	})
+
+	testCases := []struct {
+		name        string
+		raw         map[string]interface{}
+		workerPools []interface{}
+		options     map[string]interface{}
+	}{
+		{
+			name: "managed",
+			raw: map[string]interface{}{
+				"worker_pool": []interface{}{
+					map[string]interface{}{"name": "default", "min_size": 1, "max_size": 2, "enabled": false},
+				},
+				"options": map[string]interface{}{"scaleDownUnneededTime": "10m"},
+			},
+			workerPools: []interface{}{
+				map[string]interface{}{"name": "default", "min_size": 1, "max_size": 2, "enabled": false},
+			},
+			options: map[string]interface{}{"scaleDownUnneededTime": "15m"},
+		},
+		{
+			name: "imported",
+			raw:  map[string]interface{}{},
+			workerPools: []interface{}{
+				map[string]interface{}{"name": "pool1", "min_size": 1, "max_size": 3, "enabled": true},
+			},
+			options: map[string]interface{}{"expander": "random", "scaleDownUnneededTime": "15m"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, ResourceIBMContainerClusterAutoscaler().Schema, tc.raw)
+			d.SetId("mycluster")
+			if err := readClusterAutoscalerConfigMap(d, configMaps); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if workerPools := d.Get("worker_pool").(*schema.Set).List(); !reflect.DeepEqual(workerPools, tc.workerPools) {
+				t.Fatalf("got worker pools %v, want %v", workerPools, tc.workerPools)
+			}
+			if options := d.Get("options").(map[string]interface{}); !reflect.DeepEqual(options, tc.options) {
+				t.Fatalf("got options %v, want %v", options, tc.options)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kubernetes_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccIBMContainerClusterAutoscaler_Basic(t *testing.T) {
+	name := fmt.Sprintf("tf-cluster-autoscaler-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerClusterAutoscalerBasic(name, 1, 2, "10m"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_cluster_autoscaler.autoscaler", "worker_pool.#", "1"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_cluster_autoscaler.autoscaler", "options.scaleDownUnneededTime", "10m"),
+				),
+			},
+			{
+				Config: testAccCheckIBMContainerClusterAutoscalerBasic(name, 1, 3, "15m"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_cluster_autoscaler.autoscaler", "options.scaleDownUnneededTime", "15m"),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"ibm_container_cluster_autoscaler.autoscaler", "worker_pool.*", map[string]string{"max_size": "3"}),
+				),
+			},
+			{
+				ResourceName:      "ibm_container_cluster_autoscaler.autoscaler",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// All options of the config map are imported, including the
+				// defaults of the add-on that are not in the configuration
+				ImportStateVerifyIgnore: []string{"options", "wait_for_worker_pools"},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported state, got %d", len(states))
+					}
+					if value := states[0].Attributes["options.scaleDownUnneededTime"]; value != "15m" {
+						return fmt.Errorf("expected the imported option scaleDownUnneededTime to be 15m, got %q", value)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckIBMContainerClusterAutoscalerBasic(name string, minSize, maxSize int, scaleDownUnneededTime string) string {
+	return fmt.Sprintf(`
+	provider "ibm"{
+		region = "eu-de"
+	}
+	resource "ibm_is_vpc" "vpc" {
+		name = "%[1]s"
+	}
+	resource "ibm_is_subnet" "subnet" {
+		name                     = "%[1]s"
+		vpc                      = ibm_is_vpc.vpc.id
+		zone                     = "eu-de-1"
+		total_ipv4_address_count = 256
+	}
+	resource "ibm_container_vpc_cluster" "cluster" {
+		name              = "%[1]s"
+		vpc_id            = ibm_is_vpc.vpc.id
+		flavor            = "cx2.2x4"
+		worker_count      = 1
+		wait_till         = "OneWorkerNodeReady"
+		zones {
+			subnet_id = ibm_is_subnet.subnet.id
+			name      = "eu-de-1"
+		}
+	}
+	resource "ibm_container_vpc_worker_pool" "pool" {
+		cluster          = ibm_container_vpc_cluster.cluster.id
+		worker_pool_name = "%[1]s"
+		flavor           = "cx2.2x4"
+		vpc_id           = ibm_is_vpc.vpc.id
+		worker_count     = 1
+		zones {
+			subnet_id = ibm_is_subnet.subnet.id
+			name      = "eu-de-1"
+		}
+	}
+	resource "ibm_container_addons" "addons" {
+		cluster = ibm_container_vpc_cluster.cluster.id
+		addons {
+			name = "cluster-autoscaler"
+		}
+	}
+	resource "ibm_container_cluster_autoscaler" "autoscaler" {
+		cluster = ibm_container_addons.addons.cluster
+		worker_pool {
+			name     = ibm_container_vpc_worker_pool.pool.worker_pool_name
+			min_size = %[2]d
+			max_size = %[3]d
+		}
+		options = {
+			scaleDownUnneededTime = "%[4]s"
+		}
+	}`, name, minSize, maxSize, scaleDownUnneededTime)
+}
@@ -4,8 +4,13 @@
 package satellite
 
 import (
+	"bytes"
 	"fmt"
 	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/IBM-Cloud/container-services-go-sdk/kubernetesserviceapiv1"
@@ -16,6 +21,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
 )
 
 const (
@@ -27,6 +34,7 @@ const (
 	hostZone       = "zone"
 	hostWorkerPool = "worker_pool"
 	hostProvider   = "host_provider"
+	hostAttach     = "attach"
 
 	rsHostNormalStatus       = "normal"
 	rsHostProvisioningStatus = "provisioning"
@@ -102,6 +110,85 @@ func ResourceIBMSatelliteHost() *schema.Resource {
 				Description:  "Wait until location is normal",
 				ValidateFunc: validate.InvokeValidator("ibm_satellite_host", "wait_till"),
 			},
+			hostAttach: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Attach the host to the location over SSH before it is assigned. The host script is uploaded to the host and run there",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host_script": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The attach host script to run on the host, such as the host_script of the ibm_satellite_attach_host_script data source",
+						},
+						"host": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The address of the host to connect to",
+						},
+						"port": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							ForceNew:    true,
+							Default:     22,
+							Description: "The SSH port of the host",
+						},
+						"user": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "root",
+							Description: "The user to connect as. The host script is run with sudo if the user is not root",
+						},
+						"private_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The PEM encoded private key to authenticate with. If not set, the keys of the SSH agent are used",
+						},
+						"host_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The public key of the host in authorized_keys format. If not set, the host key is not verified",
+						},
+						"bastion_host": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The address of a bastion host to connect through",
+						},
+						"bastion_port": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     22,
+							Description: "The SSH port of the bastion host",
+						},
+						"bastion_user": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The user to connect to the bastion host as. Defaults to user",
+						},
+						"bastion_private_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The PEM encoded private key to authenticate with the bastion host. Defaults to private_key",
+						},
+						"bastion_host_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The public key of the bastion host in authorized_keys format. If not set, the host key is not verified",
+						},
+						"connection_timeout": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     300,
+							Description: "The time in seconds to keep retrying to connect while the host is starting",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -140,6 +227,13 @@ func resourceIBMSatelliteHostCreate(d *schema.ResourceData, meta interface{}) er
 	}
 	hostAssignOptions.HostID = flex.PtrToString(hostName)
 
+	if _, ok := d.GetOk(hostAttach); ok {
+		attach := d.Get(hostAttach + ".0").(map[string]interface{})
+		if err := attachSatelliteHostOverSSH(attach, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return fmt.Errorf("[ERROR] Error attaching host (%s) to location (%s) over SSH: %s", hostName, location, err)
+		}
+	}
+
 	//Check host attached to location
 	hostStatus, err := waitForHostAttachment(hostName, location, d, meta)
 	if err != nil {
@@ -260,6 +354,14 @@ func resourceIBMSatelliteHostUpdate(d *schema.ResourceData, meta interface{}) er
 		return err
 	}
 
+	// A host is attached only once, when it is created. Attaching a host at
+	// another address replaces it, the other attach settings, such as a
+	// regenerated host script or new credentials, are kept for the next time
+	// the host is attached.
+	if d.HasChange(hostAttach) {
+		log.Printf("[INFO] Satellite host (%s) is already attached, the changed attach settings are used when the host is attached again", hostID)
+	}
+
 	updateHostOptions := &kubernetesserviceapiv1.UpdateSatelliteHostOptions{}
 	updateHostOptions.Controller = &locationName
 	updateHostOptions.HostID = &hostID
@@ -347,3 +449,161 @@ func waitForHostAttachment(hostName, location string, d *schema.ResourceData, me
 
 	return stateConf.WaitForState()
 }
+
+// attachSatelliteHostOverSSH uploads the attach host script to the host and
+// runs it, connecting through a bastion host if one is configured.
+func attachSatelliteHostOverSSH(attach map[string]interface{}, timeout time.Duration) error {
+	user := attach["user"].(string)
+	hostConfig, hostAgent, err := satelliteHostSSHConfig(user, attach["private_key"].(string), attach["host_key"].(string))
+	if err != nil {
+		return err
+	}
+	if hostAgent != nil {
+		defer hostAgent.Close()
+	}
+	hostAddress := net.JoinHostPort(attach["host"].(string), strconv.Itoa(attach["port"].(int)))
+
+	var bastionConfig *ssh.ClientConfig
+	bastionAddress := ""
+	if bastionHost := attach["bastion_host"].(string); bastionHost != "" {
+		bastionUser := attach["bastion_user"].(string)
+		if bastionUser == "" {
+			bastionUser = user
+		}
+		bastionKey := attach["bastion_private_key"].(string)
+		if bastionKey == "" {
+			bastionKey = attach["private_key"].(string)
+		}
+		var bastionAgent net.Conn
+		bastionConfig, bastionAgent, err = satelliteHostSSHConfig(bastionUser, bastionKey, attach["bastion_host_key"].(string))
+		if err != nil {
+			return err
+		}
+		if bastionAgent != nil {
+			defer bastionAgent.Close()
+		}
+		bastionAddress = net.JoinHostPort(bastionHost, strconv.Itoa(attach["bastion_port"].(int)))
+	}
+
+	// Newly provisioned hosts take a while until sshd accepts connections.
+	connectionTimeout := time.Duration(attach["connection_timeout"].(int)) * time.Second
+	if connectionTimeout > timeout {
+		connectionTimeout = timeout
+	}
+	var client, bastion *ssh.Client
+	err = resource.Retry(connectionTimeout, func() *resource.RetryError {
+		var err error
+		client, bastion, err = satelliteHostSSHDial(hostAddress, hostConfig, bastionAddress, bastionConfig)
+		if err != nil {
+			log.Printf("[DEBUG] Connecting to satellite host %s failed, retrying: %s", hostAddress, err)
+			return resource.RetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not connect to %s: %s", hostAddress, err)
+	}
+	defer client.Close()
+	if bastion != nil {
+		defer bastion.Close()
+	}
+
+	// The script is written to a private temporary file that only the user
+	// can read, so that other users of the host cannot replace it before it
+	// is run as root, and the file is removed however the script ends.
+	run := `bash "$f"`
+	if user != "root" {
+		run = "sudo -n " + run
+	}
+	command := `umask 077 && f=$(mktemp) && trap 'rm -f "$f"' EXIT && cat > "$f" && ` + run
+	log.Printf("[INFO] Running the satellite attach host script on %s", hostAddress)
+	if output, err := satelliteHostSSHRun(client, command, attach["host_script"].(string)); err != nil {
+		return fmt.Errorf("the host script failed: %s\n%s", err, output)
+	}
+
+	return nil
+}
+
+// satelliteHostSSHConfig returns the client configuration for the user. When
+// no private key is given, the keys of the SSH agent are used and the
+// connection to the agent is returned, to be closed by the caller once the
+// client is connected.
+func satelliteHostSSHConfig(user, privateKey, hostKey string) (*ssh.ClientConfig, net.Conn, error) {
+	config := &ssh.ClientConfig{
+		User:            user,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(), // #nosec G106 -- only when no host_key is configured
+		Timeout:         30 * time.Second,
+	}
+
+	if hostKey != "" {
+		publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not parse host key: %s", err)
+		}
+		config.HostKeyCallback = ssh.FixedHostKey(publicKey)
+	}
+
+	if privateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not parse private key: %s", err)
+		}
+		config.Auth = []ssh.AuthMethod{ssh.PublicKeys(signer)}
+		return config, nil, nil
+	}
+
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, nil, fmt.Errorf("no private_key is set and SSH_AUTH_SOCK does not point to an SSH agent")
+	}
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not connect to the SSH agent: %s", err)
+	}
+	config.Auth = []ssh.AuthMethod{ssh.PublicKeysCallback(agent.NewClient(conn).Signers)}
+	return config, conn, nil
+}
+
+// satelliteHostSSHDial connects to the host, through the bastion host if a
+// bastion configuration is given. The bastion client is returned so that it
+// can be closed together with the host client.
+func satelliteHostSSHDial(address string, config *ssh.ClientConfig, bastionAddress string, bastionConfig *ssh.ClientConfig) (*ssh.Client, *ssh.Client, error) {
+	if bastionConfig == nil {
+		client, err := ssh.Dial("tcp", address, config)
+		return client, nil, err
+	}
+
+	bastion, err := ssh.Dial("tcp", bastionAddress, bastionConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bastion %s: %s", bastionAddress, err)
+	}
+	conn, err := bastion.Dial("tcp", address)
+	if err != nil {
+		bastion.Close()
+		return nil, nil, err
+	}
+	clientConn, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+	if err != nil {
+		conn.Close()
+		bastion.Close()
+		return nil, nil, err
+	}
+	return ssh.NewClient(clientConn, chans, reqs), bastion, nil
+}
+
+func satelliteHostSSHRun(client *ssh.Client, command, stdin string) (string, error) {
+	session, err := client.NewSession()
+	if err != nil {
+		return "", err
+	}
+	defer session.Close()
+
+	var output bytes.Buffer
+	session.Stdout = &output
+	session.Stderr = &output
+	if stdin != "" {
+		session.Stdin = strings.NewReader(stdin)
+	}
+	err = session.Run(command)
+	return output.String(), err
+}
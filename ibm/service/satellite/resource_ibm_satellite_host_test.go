@@ -38,6 +38,29 @@ func TestAccFunctionSatelliteHost_Basic(t *testing.T) {
 	})
 }
 
+func TestAccFunctionSatelliteHost_AttachOverSSH(t *testing.T) {
+	name := fmt.Sprintf("tf-satellitelocation-%d", acctest.RandIntRange(10, 100))
+	resource_prefix := "tf-satellite-ssh"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckSatelliteHostDestroy,
+		Steps: []resource.TestStep{
+
+			{
+				Config: testAccCheckSatelliteHostAttachOverSSH(name, resource_prefix, acc.Satellite_ssh_public_key, acc.Satellite_ssh_private_key),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckSatelliteHostExists("ibm_satellite_host.assign_host.0"),
+					testAccCheckSatelliteHostExists("ibm_satellite_host.assign_host.1"),
+					testAccCheckSatelliteHostExists("ibm_satellite_host.assign_host.2"),
+					resource.TestCheckResourceAttr("ibm_satellite_host.assign_host.0", "zone", "us-east-1"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSatelliteHostExists(n string) resource.TestCheckFunc {
 
 	return func(s *terraform.State) error {
@@ -195,3 +218,106 @@ func testAccCheckSatelliteHostCreate(name, resource_prefix string) string {
 
 `, name, resource_prefix, resource_prefix, resource_prefix, resource_prefix, resource_prefix)
 }
+
+func testAccCheckSatelliteHostAttachOverSSH(name, resource_prefix, publicKey, privateKey string) string {
+	return fmt.Sprintf(`
+
+	provider "ibm" {
+		region = "us-east"
+	}
+
+	variable "location_zones" {
+		description = "Allocate your hosts across these three zones"
+		type        = list(string)
+		default     = ["us-east-1", "us-east-2", "us-east-3"]
+	}
+
+	resource "ibm_satellite_location" "location" {
+		location      = "%s"
+		managed_from  = "wdc04"
+		zones		  = var.location_zones
+	}
+
+	data "ibm_satellite_attach_host_script" "script" {
+		location          = ibm_satellite_location.location.id
+		labels            = ["env:prod"]
+		host_provider     = "ibm"
+	}
+
+	data "ibm_resource_group" "resource_group" {
+		is_default = true
+	}
+
+	resource "ibm_is_vpc" "satellite_vpc" {
+		name = "%s-vpc-1"
+	}
+
+	resource "ibm_is_subnet" "satellite_subnet" {
+		count                    = 3
+
+		name                     = "%s-subnet-${count.index}"
+		vpc                      = ibm_is_vpc.satellite_vpc.id
+		total_ipv4_address_count = 256
+		zone                     = "us-east-${count.index + 1}"
+	}
+
+	resource "ibm_is_security_group_rule" "satellite_ssh" {
+		group     = ibm_is_vpc.satellite_vpc.default_security_group
+		direction = "inbound"
+		remote    = "0.0.0.0/0"
+		tcp {
+			port_min = 22
+			port_max = 22
+		}
+	}
+
+	resource "ibm_is_ssh_key" "satellite_ssh" {
+		name        = "%s-ibm-ssh"
+		public_key  = "%s"
+	}
+
+	resource "ibm_is_instance" "satellite_instance" {
+		count          = 3
+
+		name           = "%s-instance-${count.index}"
+		vpc            = ibm_is_vpc.satellite_vpc.id
+		zone           = "us-east-${count.index + 1}"
+		image          = "r014-931515d2-fcc3-11e9-896d-3baa2797200f"
+		profile        = "mx2-8x64"
+		keys           = [ibm_is_ssh_key.satellite_ssh.id]
+		resource_group = data.ibm_resource_group.resource_group.id
+
+		primary_network_interface {
+			subnet = ibm_is_subnet.satellite_subnet[count.index].id
+		}
+	}
+
+	resource "ibm_is_floating_ip" "satellite_ip" {
+		count  = 3
+
+		name   = "%s-fip-${count.index}"
+		target = ibm_is_instance.satellite_instance[count.index].primary_network_interface[0].id
+	}
+
+	resource "ibm_satellite_host" "assign_host" {
+		count  = 3
+
+		location      = ibm_satellite_location.location.id
+		host_id       = element(ibm_is_instance.satellite_instance[*].name, count.index)
+		labels        = ["env:prod"]
+		zone          = element(var.location_zones, count.index)
+		host_provider = "ibm"
+
+		attach {
+			host_script = data.ibm_satellite_attach_host_script.script.host_script
+			host        = ibm_is_floating_ip.satellite_ip[count.index].address
+			private_key = <<EOT
+%s
+EOT
+		}
+
+		depends_on = [ibm_is_security_group_rule.satellite_ssh]
+	}
+
+`, name, resource_prefix, resource_prefix, resource_prefix, publicKey, resource_prefix, resource_prefix, privateKey)
+}
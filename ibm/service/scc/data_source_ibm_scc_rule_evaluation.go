@@ -0,0 +1,225 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package scc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM/scc-go-sdk/v3/configurationgovernancev1"
+)
+
+func DataSourceIBMSccRuleEvaluation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMSccRuleEvaluationRead,
+
+		Schema: map[string]*schema.Schema{
+			"rules": &schema.Schema{
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The rules to evaluate. Each rule is either defined inline with required_config or fetched from the SCC service by rule_id.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A name for the rule in the results. Defaults to the name of the fetched rule.",
+						},
+						"rule_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ID of an SCC rule whose required_config is evaluated.",
+						},
+						"required_config": &schema.Schema{
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "The requirements of the rule, in the same format as the required_config of ibm_scc_rule.",
+							Elem: &schema.Resource{
+								Schema: getRequiredConfigSchema(0),
+							},
+						},
+					},
+				},
+			},
+			"resources": &schema.Schema{
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The resources to evaluate the rules against.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "A name for the resource in the results.",
+						},
+						"attributes": &schema.Schema{
+							Type:        schema.TypeMap,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The attributes of the resource, keyed by the property names that the rules use.",
+						},
+					},
+				},
+			},
+			"results": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The result of every rule for every resource.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rule_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the rule.",
+						},
+						"resource_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the resource.",
+						},
+						"passed": &schema.Schema{
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the resource complies with the rule.",
+						},
+						"failures": &schema.Schema{
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The conditions of the rule that the resource does not meet.",
+						},
+					},
+				},
+			},
+			"passed": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether every resource complies with every rule.",
+			},
+			"failed_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of rule and resource combinations that do not comply.",
+			},
+			"failures": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A message for every condition that is not met, prefixed with the rule and resource name.",
+			},
+		},
+	}
+}
+
+func dataSourceIBMSccRuleEvaluationRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	type sccEvaluatedRule struct {
+		name           string
+		requiredConfig map[string]interface{}
+	}
+
+	rules := []sccEvaluatedRule{}
+	for i, ruleItem := range d.Get("rules").([]interface{}) {
+		ruleMap := ruleItem.(map[string]interface{})
+		rule := sccEvaluatedRule{name: ruleMap["name"].(string)}
+		ruleID := ruleMap["rule_id"].(string)
+		requiredConfig := ruleMap["required_config"].([]interface{})
+
+		if (ruleID == "") == (len(requiredConfig) == 0) {
+			return diag.FromErr(fmt.Errorf("rules.%d: exactly one of rule_id or required_config must be set", i))
+		}
+
+		if ruleID != "" {
+			configurationGovernanceClient, err := meta.(conns.ClientSession).ConfigurationGovernanceV1()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			getRuleOptions := &configurationgovernancev1.GetRuleOptions{}
+			getRuleOptions.SetRuleID(ruleID)
+			sccRule, response, err := configurationGovernanceClient.GetRuleWithContext(context, getRuleOptions)
+			if err != nil {
+				log.Printf("[DEBUG] GetRuleWithContext failed %s\n%s", err, response)
+				return diag.FromErr(fmt.Errorf("GetRuleWithContext failed %s\n%s", err, response))
+			}
+			rule.requiredConfig, err = resourceIBMSccRuleRuleRequiredConfigToMap(sccRule.RequiredConfig)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if rule.name == "" && sccRule.Name != nil {
+				rule.name = *sccRule.Name
+			}
+			if rule.name == "" {
+				rule.name = ruleID
+			}
+		} else {
+			if requiredConfig[0] == nil {
+				return diag.FromErr(fmt.Errorf("rules.%d: required_config must not be empty", i))
+			}
+			rule.requiredConfig = requiredConfig[0].(map[string]interface{})
+			if rule.name == "" {
+				rule.name = fmt.Sprintf("rule-%d", i)
+			}
+		}
+		rules = append(rules, rule)
+	}
+
+	results := []map[string]interface{}{}
+	failures := []string{}
+	failedCount := 0
+	for _, resourceItem := range d.Get("resources").([]interface{}) {
+		resourceMap := resourceItem.(map[string]interface{})
+		resourceName := resourceMap["name"].(string)
+		attributes := map[string]string{}
+		for k, v := range resourceMap["attributes"].(map[string]interface{}) {
+			attributes[k] = v.(string)
+		}
+
+		for _, rule := range rules {
+			evaluation, err := sccRuleEvaluate(rule.requiredConfig, attributes)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("Error evaluating rule %s: %s", rule.name, err))
+			}
+			if !evaluation.Passed {
+				failedCount++
+				for _, failure := range evaluation.Failures {
+					failures = append(failures, fmt.Sprintf("%s on %s: %s", rule.name, resourceName, failure))
+				}
+			}
+			resultFailures := evaluation.Failures
+			if resultFailures == nil {
+				resultFailures = []string{}
+			}
+			results = append(results, map[string]interface{}{
+				"rule_name":     rule.name,
+				"resource_name": resourceName,
+				"passed":        evaluation.Passed,
+				"failures":      resultFailures,
+			})
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+	if err := d.Set("results", results); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting results: %s", err))
+	}
+	if err := d.Set("passed", failedCount == 0); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting passed: %s", err))
+	}
+	if err := d.Set("failed_count", failedCount); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting failed_count: %s", err))
+	}
+	if err := d.Set("failures", failures); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting failures: %s", err))
+	}
+
+	return nil
+}
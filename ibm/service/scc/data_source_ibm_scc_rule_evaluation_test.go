@@ -0,0 +1,80 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package scc_test
+
+import (
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIbmSccRuleEvaluationDataSourceBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIbmSccRuleEvaluationDataSourceConfigBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ibm_scc_rule_evaluation.scc_rule_evaluation", "id"),
+					resource.TestCheckResourceAttr("data.ibm_scc_rule_evaluation.scc_rule_evaluation", "passed", "false"),
+					resource.TestCheckResourceAttr("data.ibm_scc_rule_evaluation.scc_rule_evaluation", "failed_count", "1"),
+					resource.TestCheckResourceAttr("data.ibm_scc_rule_evaluation.scc_rule_evaluation", "results.#", "4"),
+					resource.TestCheckResourceAttr("data.ibm_scc_rule_evaluation.scc_rule_evaluation", "results.0.passed", "true"),
+					resource.TestCheckResourceAttr("data.ibm_scc_rule_evaluation.scc_rule_evaluation", "results.2.rule_name", "private-endpoints"),
+					resource.TestCheckResourceAttr("data.ibm_scc_rule_evaluation.scc_rule_evaluation", "results.2.resource_name", "bucket-b"),
+					resource.TestCheckResourceAttr("data.ibm_scc_rule_evaluation.scc_rule_evaluation", "results.2.passed", "false"),
+					resource.TestCheckResourceAttr("data.ibm_scc_rule_evaluation.scc_rule_evaluation", "failures.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIbmSccRuleEvaluationDataSourceConfigBasic() string {
+	return `
+		data "ibm_scc_rule_evaluation" "scc_rule_evaluation" {
+			rules {
+				name = "private-endpoints"
+				required_config {
+					and {
+						property = "endpoint_type"
+						operator = "string_equals"
+						value    = "private"
+					}
+					and {
+						property = "firewall_allowed_ip"
+						operator = "is_not_empty"
+					}
+				}
+			}
+			rules {
+				name = "retention"
+				required_config {
+					property = "retention_days"
+					operator = "num_greater_than_equals"
+					value    = "30"
+				}
+			}
+			resources {
+				name = "bucket-a"
+				attributes = {
+					endpoint_type       = "private"
+					firewall_allowed_ip = "10.0.0.0/8"
+					retention_days      = "90"
+				}
+			}
+			resources {
+				name = "bucket-b"
+				attributes = {
+					endpoint_type       = "public"
+					firewall_allowed_ip = "10.0.0.0/8"
+					retention_days      = "30"
+				}
+			}
+		}
+	`
+}
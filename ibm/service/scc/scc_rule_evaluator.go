@@ -0,0 +1,198 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package scc
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The rule evaluator checks the required_config of a rule, in the shape used
+// by the ibm_scc_rule schema, against a flat map of resource attributes. It
+// mirrors the operators that validateIBMSccRuleReqConfig accepts, so that a
+// configuration can be checked before the SCC service sees the resource.
+
+// sccRuleEvaluation is the outcome of evaluating a required_config against a
+// set of attributes. Failures describe every condition that did not hold.
+type sccRuleEvaluation struct {
+	Passed   bool
+	Failures []string
+}
+
+// sccRuleEvaluate evaluates a required_config. Conditions are combined with
+// `and` and `or` blocks; a block without them is a single property check.
+func sccRuleEvaluate(config map[string]interface{}, attributes map[string]string) (sccRuleEvaluation, error) {
+	if and := sccRuleConditionList(config["and"]); len(and) > 0 {
+		result := sccRuleEvaluation{Passed: true}
+		for _, condition := range and {
+			conditionResult, err := sccRuleEvaluate(condition, attributes)
+			if err != nil {
+				return result, err
+			}
+			if !conditionResult.Passed {
+				result.Passed = false
+				result.Failures = append(result.Failures, conditionResult.Failures...)
+			}
+		}
+		return result, nil
+	}
+
+	if or := sccRuleConditionList(config["or"]); len(or) > 0 {
+		result := sccRuleEvaluation{}
+		failures := []string{}
+		for _, condition := range or {
+			conditionResult, err := sccRuleEvaluate(condition, attributes)
+			if err != nil {
+				return result, err
+			}
+			if conditionResult.Passed {
+				return sccRuleEvaluation{Passed: true}, nil
+			}
+			failures = append(failures, conditionResult.Failures...)
+		}
+		result.Failures = []string{fmt.Sprintf("none of the alternatives is met: %s", strings.Join(failures, "; "))}
+		return result, nil
+	}
+
+	property := sccRuleString(config["property"])
+	operator := sccRuleString(config["operator"])
+	if property == "" || operator == "" {
+		return sccRuleEvaluation{}, fmt.Errorf("a condition must set property and operator, or contain and/or conditions")
+	}
+	passed, failure, err := sccRuleEvaluateProperty(property, operator, sccRuleString(config["value"]), attributes)
+	if err != nil {
+		return sccRuleEvaluation{}, err
+	}
+	if !passed {
+		return sccRuleEvaluation{Failures: []string{failure}}, nil
+	}
+	return sccRuleEvaluation{Passed: true}, nil
+}
+
+// sccRuleEvaluateProperty checks a single property. A property that is not in
+// the attributes only satisfies is_empty and string_not_equals.
+func sccRuleEvaluateProperty(property, operator, value string, attributes map[string]string) (bool, string, error) {
+	actual, ok := attributes[property]
+
+	switch operator {
+	case "is_empty":
+		if !ok || actual == "" {
+			return true, "", nil
+		}
+		return false, fmt.Sprintf("%s must be empty, got %q", property, actual), nil
+	case "is_not_empty":
+		if ok && actual != "" {
+			return true, "", nil
+		}
+		return false, fmt.Sprintf("%s must not be empty", property), nil
+	case "string_not_equals":
+		if !ok || actual != value {
+			return true, "", nil
+		}
+		return false, fmt.Sprintf("%s must not equal %q", property, value), nil
+	}
+
+	if !ok {
+		return false, fmt.Sprintf("%s is not set", property), nil
+	}
+
+	switch operator {
+	case "is_true", "is_false":
+		actualBool, err := strconv.ParseBool(actual)
+		if err != nil {
+			return false, fmt.Sprintf("%s must be a boolean, got %q", property, actual), nil
+		}
+		if actualBool == (operator == "is_true") {
+			return true, "", nil
+		}
+		return false, fmt.Sprintf("%s must be %t", property, operator == "is_true"), nil
+	case "string_equals":
+		if actual == value {
+			return true, "", nil
+		}
+		return false, fmt.Sprintf("%s must equal %q, got %q", property, value, actual), nil
+	case "string_match", "string_not_match":
+		matched := sccRuleWildcardRegexp(value).MatchString(actual)
+		if matched == (operator == "string_match") {
+			return true, "", nil
+		}
+		if operator == "string_match" {
+			return false, fmt.Sprintf("%s must match %q, got %q", property, value, actual), nil
+		}
+		return false, fmt.Sprintf("%s must not match %q, got %q", property, value, actual), nil
+	case "num_equals", "num_not_equals", "num_less_than", "num_less_than_equals", "num_greater_than", "num_greater_than_equals":
+		expected, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false, "", fmt.Errorf("the value %q of the %s condition on %s is not a number", value, operator, property)
+		}
+		actualNum, err := strconv.ParseFloat(actual, 64)
+		if err != nil {
+			return false, fmt.Sprintf("%s must be a number, got %q", property, actual), nil
+		}
+		var passed bool
+		var relation string
+		switch operator {
+		case "num_equals":
+			passed, relation = actualNum == expected, "equal to"
+		case "num_not_equals":
+			passed, relation = actualNum != expected, "not equal to"
+		case "num_less_than":
+			passed, relation = actualNum < expected, "less than"
+		case "num_less_than_equals":
+			passed, relation = actualNum <= expected, "less than or equal to"
+		case "num_greater_than":
+			passed, relation = actualNum > expected, "greater than"
+		case "num_greater_than_equals":
+			passed, relation = actualNum >= expected, "greater than or equal to"
+		}
+		if passed {
+			return true, "", nil
+		}
+		return false, fmt.Sprintf("%s must be %s %s, got %s", property, relation, value, actual), nil
+	}
+
+	return false, "", fmt.Errorf("unsupported operator %q on %s", operator, property)
+}
+
+// sccRuleWildcardRegexp turns a string_match pattern, in which `*` matches
+// any sequence of characters and `?` a single character, into an anchored
+// regular expression.
+func sccRuleWildcardRegexp(pattern string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(pattern)
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	quoted = strings.ReplaceAll(quoted, `\?`, ".")
+	return regexp.MustCompile("^" + quoted + "$")
+}
+
+// sccRuleConditionList accepts nested conditions both as read from the schema
+// and as converted from the API by resourceIBMSccRuleRuleRequiredConfigToMap.
+func sccRuleConditionList(v interface{}) []map[string]interface{} {
+	switch conditions := v.(type) {
+	case []map[string]interface{}:
+		return conditions
+	case []interface{}:
+		list := make([]map[string]interface{}, 0, len(conditions))
+		for _, condition := range conditions {
+			if conditionMap, ok := condition.(map[string]interface{}); ok {
+				list = append(list, conditionMap)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+func sccRuleString(v interface{}) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case *string:
+		if s != nil {
+			return *s
+		}
+	}
+	return ""
+}
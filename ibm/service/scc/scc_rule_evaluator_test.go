@@ -0,0 +1,246 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package scc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSccRuleEvaluateProperty(t *testing.T) {
+	attributes := map[string]string{
+		"enabled":  "true",
+		"disabled": "false",
+		"name":     "prod-bucket-01",
+		"empty":    "",
+		"count":    "5",
+		"label":    "five",
+	}
+
+	testCases := []struct {
+		name     string
+		property string
+		operator string
+		value    string
+		passed   bool
+		failure  string
+	}{
+		{"is_true", "enabled", "is_true", "", true, ""},
+		{"is_true on false", "disabled", "is_true", "", false, "disabled must be true"},
+		{"is_false", "disabled", "is_false", "", true, ""},
+		{"is_true on non boolean", "name", "is_true", "", false, `name must be a boolean, got "prod-bucket-01"`},
+		{"is_true on missing", "missing", "is_true", "", false, "missing is not set"},
+		{"is_empty on empty", "empty", "is_empty", "", true, ""},
+		{"is_empty on missing", "missing", "is_empty", "", true, ""},
+		{"is_empty on set", "name", "is_empty", "", false, `name must be empty, got "prod-bucket-01"`},
+		{"is_not_empty", "name", "is_not_empty", "", true, ""},
+		{"is_not_empty on empty", "empty", "is_not_empty", "", false, "empty must not be empty"},
+		{"is_not_empty on missing", "missing", "is_not_empty", "", false, "missing must not be empty"},
+		{"string_equals", "name", "string_equals", "prod-bucket-01", true, ""},
+		{"string_equals mismatch", "name", "string_equals", "dev", false, `name must equal "dev", got "prod-bucket-01"`},
+		{"string_not_equals", "name", "string_not_equals", "dev", true, ""},
+		{"string_not_equals on missing", "missing", "string_not_equals", "dev", true, ""},
+		{"string_not_equals match", "name", "string_not_equals", "prod-bucket-01", false, `name must not equal "prod-bucket-01"`},
+		{"string_match star", "name", "string_match", "prod-*", true, ""},
+		{"string_match question mark", "name", "string_match", "prod-bucket-0?", true, ""},
+		{"string_match is anchored", "name", "string_match", "bucket", false, `name must match "bucket", got "prod-bucket-01"`},
+		{"string_match quotes metacharacters", "name", "string_match", "prod.bucket.01", false, `name must match "prod.bucket.01", got "prod-bucket-01"`},
+		{"string_not_match", "name", "string_not_match", "dev-*", true, ""},
+		{"string_not_match match", "name", "string_not_match", "*-01", false, `name must not match "*-01", got "prod-bucket-01"`},
+		{"num_equals", "count", "num_equals", "5.0", true, ""},
+		{"num_equals mismatch", "count", "num_equals", "4", false, "count must be equal to 4, got 5"},
+		{"num_not_equals", "count", "num_not_equals", "4", true, ""},
+		{"num_less_than", "count", "num_less_than", "6", true, ""},
+		{"num_less_than on equal", "count", "num_less_than", "5", false, "count must be less than 5, got 5"},
+		{"num_less_than_equals", "count", "num_less_than_equals", "5", true, ""},
+		{"num_greater_than", "count", "num_greater_than", "4", true, ""},
+		{"num_greater_than on equal", "count", "num_greater_than", "5", false, "count must be greater than 5, got 5"},
+		{"num_greater_than_equals", "count", "num_greater_than_equals", "5", true, ""},
+		{"num on non number", "label", "num_equals", "5", false, `label must be a number, got "five"`},
+		{"num on missing", "missing", "num_equals", "5", false, "missing is not set"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			passed, failure, err := sccRuleEvaluateProperty(tc.property, tc.operator, tc.value, attributes)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if passed != tc.passed || failure != tc.failure {
+				t.Fatalf("got (%t, %q), want (%t, %q)", passed, failure, tc.passed, tc.failure)
+			}
+		})
+	}
+}
+
+func TestSccRuleEvaluatePropertyErrors(t *testing.T) {
+	attributes := map[string]string{"count": "5"}
+
+	testCases := []struct {
+		name     string
+		operator string
+		value    string
+		err      string
+	}{
+		{"unsupported operator", "ips_in_range", "10.0.0.0/8", `unsupported operator "ips_in_range" on count`},
+		{"non numeric value", "num_less_than", "many", `the value "many" of the num_less_than condition on count is not a number`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := sccRuleEvaluateProperty("count", tc.operator, tc.value, attributes)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("got error %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestSccRuleEvaluate(t *testing.T) {
+	attributes := map[string]string{
+		"encryption": "true",
+		"versioning": "false",
+		"region":     "eu-de",
+		"retention":  "30",
+	}
+
+	condition := func(property, operator, value string) map[string]interface{} {
+		return map[string]interface{}{"property": property, "operator": operator, "value": value}
+	}
+
+	testCases := []struct {
+		name     string
+		config   map[string]interface{}
+		passed   bool
+		failures []string
+	}{
+		{
+			name:   "single condition",
+			config: condition("encryption", "is_true", ""),
+			passed: true,
+		},
+		{
+			name:     "single failing condition",
+			config:   condition("versioning", "is_true", ""),
+			failures: []string{"versioning must be true"},
+		},
+		{
+			name: "and collects every failure",
+			config: map[string]interface{}{"and": []interface{}{
+				condition("encryption", "is_true", ""),
+				condition("versioning", "is_true", ""),
+				condition("retention", "num_greater_than_equals", "90"),
+			}},
+			failures: []string{"versioning must be true", "retention must be greater than or equal to 90, got 30"},
+		},
+		{
+			name: "and passes",
+			config: map[string]interface{}{"and": []map[string]interface{}{
+				condition("encryption", "is_true", ""),
+				condition("region", "string_match", "eu-*"),
+			}},
+			passed: true,
+		},
+		{
+			name: "or passes on any alternative",
+			config: map[string]interface{}{"or": []interface{}{
+				condition("versioning", "is_true", ""),
+				condition("region", "string_equals", "eu-de"),
+			}},
+			passed: true,
+		},
+		{
+			name: "or fails with all alternatives",
+			config: map[string]interface{}{"or": []interface{}{
+				condition("versioning", "is_true", ""),
+				condition("region", "string_equals", "us-south"),
+			}},
+			failures: []string{`none of the alternatives is met: versioning must be true; region must equal "us-south", got "eu-de"`},
+		},
+		{
+			name: "or nested in and",
+			config: map[string]interface{}{"and": []interface{}{
+				condition("encryption", "is_true", ""),
+				map[string]interface{}{"or": []interface{}{
+					condition("versioning", "is_true", ""),
+					condition("retention", "num_greater_than", "7"),
+				}},
+			}},
+			passed: true,
+		},
+		{
+			name: "failing or nested in and",
+			config: map[string]interface{}{"and": []interface{}{
+				condition("encryption", "is_false", ""),
+				map[string]interface{}{"or": []interface{}{
+					condition("versioning", "is_true", ""),
+					condition("retention", "num_greater_than", "90"),
+				}},
+			}},
+			failures: []string{
+				"encryption must be false",
+				"none of the alternatives is met: versioning must be true; retention must be greater than 90, got 30",
+			},
+		},
+		{
+			name: "and nested in or",
+			config: map[string]interface{}{"or": []interface{}{
+				map[string]interface{}{"and": []interface{}{
+					condition("versioning", "is_true", ""),
+					condition("retention", "num_greater_than", "7"),
+				}},
+				map[string]interface{}{"and": []interface{}{
+					condition("encryption", "is_true", ""),
+					condition("region", "string_not_equals", "us-south"),
+				}},
+			}},
+			passed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := sccRuleEvaluate(tc.config, attributes)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if result.Passed != tc.passed || !reflect.DeepEqual(result.Failures, tc.failures) {
+				t.Fatalf("got (%t, %q), want (%t, %q)", result.Passed, result.Failures, tc.passed, tc.failures)
+			}
+		})
+	}
+}
+
+func TestSccRuleEvaluateErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config map[string]interface{}
+		err    string
+	}{
+		{
+			name:   "condition without operator",
+			config: map[string]interface{}{"property": "region"},
+			err:    "a condition must set property and operator",
+		},
+		{
+			name: "nested unsupported operator",
+			config: map[string]interface{}{"and": []interface{}{
+				map[string]interface{}{"or": []interface{}{
+					map[string]interface{}{"property": "region", "operator": "ips_in_range", "value": "10.0.0.0/8"},
+				}},
+			}},
+			err: `unsupported operator "ips_in_range" on region`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := sccRuleEvaluate(tc.config, map[string]string{"region": "eu-de"})
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("got error %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -62,6 +62,28 @@ func getClientWithInstanceEndpoint(originalClient *secretsmanagerv2.SecretsManag
 	return newClient
 }
 
+// GetClientWithInstanceEndpoint clones the base secrets manager client for the
+// resources of other services that use the secrets of an instance. An empty
+// region or endpoint type defaults to the one of the provider.
+func GetClientWithInstanceEndpoint(originalClient *secretsmanagerv2.SecretsManagerV2, instanceId string, region string, endpointType string) (*secretsmanagerv2.SecretsManagerV2, error) {
+	baseUrl := originalClient.Service.GetServiceURL()
+	if region == "" {
+		// base url is like that : "https://<private.>secrets-manager.<region>.<rest of domain>"
+		hostParts := strings.Split(strings.Replace(baseUrl, "private.", "", 1), ".")
+		if len(hostParts) < 2 {
+			return nil, fmt.Errorf("The region of the Secrets Manager instance %s cannot be determined from %s, set the region", instanceId, baseUrl)
+		}
+		region = hostParts[1]
+	}
+	if endpointType == "" {
+		endpointType = "public"
+		if strings.Contains(baseUrl, "private.") {
+			endpointType = "private"
+		}
+	}
+	return getClientWithInstanceEndpoint(originalClient, instanceId, region, endpointType), nil
+}
+
 // Add the fields needed for building the instance endpoint to the given schema
 func AddInstanceFields(resource *schema.Resource) *schema.Resource {
 	resource.Schema["instance_id"] = &schema.Schema{
@@ -0,0 +1,488 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package transitgateway
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/directlinkv1"
+	"github.com/IBM/networking-go-sdk/transitgatewayapisv1"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/softlayer/softlayer-go/services"
+)
+
+const (
+	tgNetworkAnalysisVpcIds                = "vpc_ids"
+	tgNetworkAnalysisTgRouteReports        = "transit_gateway_route_reports"
+	tgNetworkAnalysisDlRouteReports        = "direct_link_route_reports"
+	tgNetworkAnalysisClassicSubnetIds      = "classic_subnet_ids"
+	tgNetworkAnalysisProposedCidrs         = "proposed_cidrs"
+	tgNetworkAnalysisGateway               = "gateway"
+	tgNetworkAnalysisRouteReport           = "route_report"
+	tgNetworkAnalysisName                  = "name"
+	tgNetworkAnalysisCidr                  = "cidr"
+	tgNetworkAnalysisNetwork               = "network"
+	tgNetworkAnalysisSource                = "source"
+	tgNetworkAnalysisPrefixes              = "prefixes"
+	tgNetworkAnalysisOverlaps              = "overlaps"
+	tgNetworkAnalysisOverlappingCidr       = "overlapping_cidr"
+	tgNetworkAnalysisOverlappingNetwork    = "overlapping_network"
+	tgNetworkAnalysisRelation              = "relation"
+	tgNetworkAnalysisConflict              = "conflict"
+	tgNetworkAnalysisEffectiveNetwork      = "effective_network"
+	tgNetworkAnalysisOverlapCount          = "overlap_count"
+	tgNetworkAnalysisProposedOverlapCount  = "proposed_overlap_count"
+	tgNetworkAnalysisHasOverlaps           = "has_overlaps"
+	tgNetworkAnalysisRelationIdentical     = "identical"
+	tgNetworkAnalysisRelationContains      = "contains"
+	tgNetworkAnalysisRelationWithin        = "within"
+	tgNetworkAnalysisConflictDuplicate     = "duplicate"
+	tgNetworkAnalysisConflictMoreSpecific  = "more_specific"
+	tgNetworkAnalysisProposedNetworkPrefix = "proposed:"
+)
+
+func DataSourceIBMTransitGatewayNetworkAnalysis() *schema.Resource {
+	routeReportElem := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			tgNetworkAnalysisGateway: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The gateway identifier",
+			},
+			tgNetworkAnalysisRouteReport: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The route report identifier",
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Read: dataSourceIBMTransitGatewayNetworkAnalysisRead,
+		Schema: map[string]*schema.Schema{
+			tgNetworkAnalysisVpcIds: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "VPCs whose address prefixes are analysed, in the region of the provider",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			tgNetworkAnalysisTgRouteReports: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Transit Gateway route reports whose connection routes are analysed",
+				Elem:        routeReportElem,
+			},
+			tgNetworkAnalysisDlRouteReports: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Direct Link route reports whose on-premises and virtual connection routes are analysed",
+				Elem:        routeReportElem,
+			},
+			tgNetworkAnalysisClassicSubnetIds: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Classic infrastructure subnets that are analysed",
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			tgNetworkAnalysisProposedCidrs: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "CIDRs that do not exist yet and are checked against everything else",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tgNetworkAnalysisName: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "A name for the proposed network, used to group its CIDRs",
+						},
+						tgNetworkAnalysisCidr: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The proposed CIDR",
+						},
+					},
+				},
+			},
+			tgNetworkAnalysisPrefixes: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "All the prefixes that were gathered",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tgNetworkAnalysisCidr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The prefix",
+						},
+						tgNetworkAnalysisNetwork: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The network the prefix belongs to, such as vpc:<id>, classic or directlink:<id>",
+						},
+						tgNetworkAnalysisSource: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Where the prefix was gathered from",
+						},
+					},
+				},
+			},
+			tgNetworkAnalysisOverlaps: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Prefixes of different networks that overlap",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						tgNetworkAnalysisCidr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The first prefix",
+						},
+						tgNetworkAnalysisNetwork: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The network of the first prefix",
+						},
+						tgNetworkAnalysisOverlappingCidr: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The prefix overlapping the first prefix",
+						},
+						tgNetworkAnalysisOverlappingNetwork: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The network of the overlapping prefix",
+						},
+						tgNetworkAnalysisRelation: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "How the first prefix relates to the overlapping one: identical, contains or within",
+						},
+						tgNetworkAnalysisConflict: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The routing conflict: duplicate when both networks advertise the same prefix, more_specific when the longer prefix takes over part of the shorter one",
+						},
+						tgNetworkAnalysisEffectiveNetwork: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The network traffic to the overlapping range is routed to by longest prefix match, empty for duplicates",
+						},
+					},
+				},
+			},
+			tgNetworkAnalysisOverlapCount: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of overlaps",
+			},
+			tgNetworkAnalysisProposedOverlapCount: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of overlaps involving a proposed CIDR",
+			},
+			tgNetworkAnalysisHasOverlaps: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether any overlap was found",
+			},
+		},
+	}
+}
+
+// tgNetworkPrefix is a prefix gathered for the analysis, tagged with the
+// network it belongs to so that the same network seen through different
+// services (a VPC and its transit gateway connection) is not reported as
+// overlapping itself.
+type tgNetworkPrefix struct {
+	cidr    *net.IPNet
+	network string
+	source  string
+}
+
+func dataSourceIBMTransitGatewayNetworkAnalysisRead(d *schema.ResourceData, meta interface{}) error {
+	prefixes := []tgNetworkPrefix{}
+	add := func(cidr, network, source string) error {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Invalid prefix %q from %s: %s", cidr, source, err)
+		}
+		prefixes = append(prefixes, tgNetworkPrefix{cidr: ipNet, network: network, source: source})
+		return nil
+	}
+
+	if vpcIds := d.Get(tgNetworkAnalysisVpcIds).([]interface{}); len(vpcIds) > 0 {
+		vpcClient, err := meta.(conns.ClientSession).VpcV1API()
+		if err != nil {
+			return err
+		}
+		for _, vpcId := range flex.ExpandStringList(vpcIds) {
+			start := ""
+			for {
+				listVpcAddressPrefixesOptions := &vpcv1.ListVPCAddressPrefixesOptions{}
+				listVpcAddressPrefixesOptions.SetVPCID(vpcId)
+				if start != "" {
+					listVpcAddressPrefixesOptions.Start = &start
+				}
+				addressPrefixCollection, response, err := vpcClient.ListVPCAddressPrefixes(listVpcAddressPrefixesOptions)
+				if err != nil {
+					return fmt.Errorf("[ERROR] Error listing address prefixes of VPC %s: %s\n%s", vpcId, err, response)
+				}
+				for _, addressPrefix := range addressPrefixCollection.AddressPrefixes {
+					if err := add(*addressPrefix.CIDR, "vpc:"+vpcId, fmt.Sprintf("address prefix %s of VPC %s", *addressPrefix.Name, vpcId)); err != nil {
+						return err
+					}
+				}
+				start = flex.GetNext(addressPrefixCollection.Next)
+				if start == "" {
+					break
+				}
+			}
+		}
+	}
+
+	if routeReports := d.Get(tgNetworkAnalysisTgRouteReports).([]interface{}); len(routeReports) > 0 {
+		client, err := transitgatewayClient(meta)
+		if err != nil {
+			return err
+		}
+		for _, r := range routeReports {
+			routeReportRef := r.(map[string]interface{})
+			gatewayId := routeReportRef[tgNetworkAnalysisGateway].(string)
+			routeReportId := routeReportRef[tgNetworkAnalysisRouteReport].(string)
+
+			listTransitGatewayConnectionsOptions := &transitgatewayapisv1.ListTransitGatewayConnectionsOptions{}
+			listTransitGatewayConnectionsOptions.SetTransitGatewayID(gatewayId)
+			connections, response, err := client.ListTransitGatewayConnections(listTransitGatewayConnectionsOptions)
+			if err != nil {
+				return fmt.Errorf("[ERROR] Error listing connections of transit gateway %s: %s\n%s", gatewayId, err, response)
+			}
+			networks := map[string]string{}
+			for _, connection := range connections.Connections {
+				networks[*connection.ID] = tgNetworkAnalysisNetworkKey(*connection.NetworkType, connection.NetworkID, *connection.ID)
+			}
+
+			getTransitGatewayRouteReportOptions := &transitgatewayapisv1.GetTransitGatewayRouteReportOptions{}
+			getTransitGatewayRouteReportOptions.SetTransitGatewayID(gatewayId)
+			getTransitGatewayRouteReportOptions.SetID(routeReportId)
+			routeReport, response, err := client.GetTransitGatewayRouteReport(getTransitGatewayRouteReportOptions)
+			if err != nil {
+				return fmt.Errorf("[ERROR] Error while retrieving transit gateway route report %s\n%s", err, response)
+			}
+			if routeReport.Status != nil && *routeReport.Status != "complete" {
+				return fmt.Errorf("[ERROR] Transit gateway route report %s is %s, wait for it to complete", routeReportId, *routeReport.Status)
+			}
+			for _, connection := range routeReport.Connections {
+				network, ok := networks[*connection.ID]
+				if !ok {
+					network = tgNetworkAnalysisNetworkKey(*connection.Type, nil, *connection.ID)
+				}
+				for _, route := range connection.Routes {
+					if route.Prefix == nil {
+						continue
+					}
+					if err := add(*route.Prefix, network, fmt.Sprintf("connection %s of transit gateway %s", *connection.Name, gatewayId)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	if routeReports := d.Get(tgNetworkAnalysisDlRouteReports).([]interface{}); len(routeReports) > 0 {
+		directLink, err := meta.(conns.ClientSession).DirectlinkV1API()
+		if err != nil {
+			return err
+		}
+		for _, r := range routeReports {
+			routeReportRef := r.(map[string]interface{})
+			gatewayId := routeReportRef[tgNetworkAnalysisGateway].(string)
+			routeReportId := routeReportRef[tgNetworkAnalysisRouteReport].(string)
+
+			listGatewayVirtualConnectionsOptions := &directlinkv1.ListGatewayVirtualConnectionsOptions{}
+			listGatewayVirtualConnectionsOptions.SetGatewayID(gatewayId)
+			virtualConnections, response, err := directLink.ListGatewayVirtualConnections(listGatewayVirtualConnectionsOptions)
+			if err != nil {
+				return fmt.Errorf("[ERROR] Error listing virtual connections of direct link gateway %s: %s\n%s", gatewayId, err, response)
+			}
+			networks := map[string]string{}
+			for _, virtualConnection := range virtualConnections.VirtualConnections {
+				networks[*virtualConnection.ID] = tgNetworkAnalysisNetworkKey(*virtualConnection.Type, virtualConnection.NetworkID, *virtualConnection.ID)
+			}
+
+			getGatewayRouteReportOptions := &directlinkv1.GetGatewayRouteReportOptions{GatewayID: &gatewayId, ID: &routeReportId}
+			routeReport, response, err := directLink.GetGatewayRouteReport(getGatewayRouteReportOptions)
+			if err != nil {
+				return fmt.Errorf("[ERROR] Error fetching DL Route Reports: %s\n%s", err, response)
+			}
+			if routeReport.Status != nil && *routeReport.Status != directlinkv1.RouteReport_Status_Complete {
+				return fmt.Errorf("[ERROR] Direct link route report %s is %s, wait for it to complete", routeReportId, *routeReport.Status)
+			}
+			for _, route := range routeReport.OnPremRoutes {
+				if route.Prefix == nil {
+					continue
+				}
+				if err := add(*route.Prefix, "directlink:"+gatewayId, fmt.Sprintf("on-premises routes of direct link gateway %s", gatewayId)); err != nil {
+					return err
+				}
+			}
+			for _, connection := range routeReport.VirtualConnectionRoutes {
+				network, ok := networks[core.StringNilMapper(connection.VirtualConnectionID)]
+				if !ok {
+					network = tgNetworkAnalysisNetworkKey(core.StringNilMapper(connection.VirtualConnectionType), nil, core.StringNilMapper(connection.VirtualConnectionID))
+				}
+				for _, route := range connection.Routes {
+					if route.Prefix == nil {
+						continue
+					}
+					if err := add(*route.Prefix, network, fmt.Sprintf("virtual connection %s of direct link gateway %s", core.StringNilMapper(connection.VirtualConnectionName), gatewayId)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	if subnetIds := d.Get(tgNetworkAnalysisClassicSubnetIds).([]interface{}); len(subnetIds) > 0 {
+		sess := meta.(conns.ClientSession).SoftLayerSession()
+		service := services.GetNetworkSubnetService(sess)
+		for _, subnetId := range subnetIds {
+			subnet, err := service.Id(subnetId.(int)).Mask("id,networkIdentifier,cidr").GetObject()
+			if err != nil {
+				return fmt.Errorf("[ERROR] Error retrieving classic subnet %d: %s", subnetId.(int), err)
+			}
+			cidr := fmt.Sprintf("%s/%d", *subnet.NetworkIdentifier, *subnet.Cidr)
+			if err := add(cidr, "classic", fmt.Sprintf("classic subnet %d", subnetId.(int))); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, p := range d.Get(tgNetworkAnalysisProposedCidrs).([]interface{}) {
+		proposed := p.(map[string]interface{})
+		name := proposed[tgNetworkAnalysisName].(string)
+		if err := add(proposed[tgNetworkAnalysisCidr].(string), tgNetworkAnalysisProposedNetworkPrefix+name, fmt.Sprintf("proposed network %s", name)); err != nil {
+			return err
+		}
+	}
+
+	prefixes = tgNetworkAnalysisDedup(prefixes)
+	overlaps := tgNetworkAnalysisOverlapsOf(prefixes)
+	log.Printf("[DEBUG] Network analysis gathered %d prefixes and found %d overlaps", len(prefixes), len(overlaps))
+
+	prefixList := make([]map[string]interface{}, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		prefixList = append(prefixList, map[string]interface{}{
+			tgNetworkAnalysisCidr:    prefix.cidr.String(),
+			tgNetworkAnalysisNetwork: prefix.network,
+			tgNetworkAnalysisSource:  prefix.source,
+		})
+	}
+	proposedOverlapCount := 0
+	for _, overlap := range overlaps {
+		if strings.HasPrefix(overlap[tgNetworkAnalysisNetwork].(string), tgNetworkAnalysisProposedNetworkPrefix) ||
+			strings.HasPrefix(overlap[tgNetworkAnalysisOverlappingNetwork].(string), tgNetworkAnalysisProposedNetworkPrefix) {
+			proposedOverlapCount++
+		}
+	}
+
+	d.SetId(dataSourceIBMTransitGatewayNetworkAnalysisID(d))
+	d.Set(tgNetworkAnalysisPrefixes, prefixList)
+	d.Set(tgNetworkAnalysisOverlaps, overlaps)
+	d.Set(tgNetworkAnalysisOverlapCount, len(overlaps))
+	d.Set(tgNetworkAnalysisProposedOverlapCount, proposedOverlapCount)
+	d.Set(tgNetworkAnalysisHasOverlaps, len(overlaps) > 0)
+
+	return nil
+}
+
+// tgNetworkAnalysisNetworkKey names the network behind a connection. Networks
+// identified by a CRN are keyed by the resource ID at the end of the CRN, so a
+// VPC attached to a transit gateway and the same VPC listed in vpc_ids match.
+func tgNetworkAnalysisNetworkKey(networkType string, networkId *string, connectionId string) string {
+	if networkType == "classic" {
+		return "classic"
+	}
+	if networkId != nil && *networkId != "" {
+		segments := strings.Split(*networkId, ":")
+		return networkType + ":" + segments[len(segments)-1]
+	}
+	return networkType + ":" + connectionId
+}
+
+// tgNetworkAnalysisDedup drops prefixes seen more than once for the same
+// network and sorts the rest, so the result does not depend on the order the
+// services were queried in.
+func tgNetworkAnalysisDedup(prefixes []tgNetworkPrefix) []tgNetworkPrefix {
+	seen := map[string]bool{}
+	unique := []tgNetworkPrefix{}
+	for _, prefix := range prefixes {
+		key := prefix.network + "|" + prefix.cidr.String()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, prefix)
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		if c := bytes.Compare(unique[i].cidr.IP.To16(), unique[j].cidr.IP.To16()); c != 0 {
+			return c < 0
+		}
+		iOnes, _ := unique[i].cidr.Mask.Size()
+		jOnes, _ := unique[j].cidr.Mask.Size()
+		if iOnes != jOnes {
+			return iOnes < jOnes
+		}
+		return unique[i].network < unique[j].network
+	})
+	return unique
+}
+
+func tgNetworkAnalysisOverlapsOf(prefixes []tgNetworkPrefix) []map[string]interface{} {
+	overlaps := []map[string]interface{}{}
+	for i, a := range prefixes {
+		for _, b := range prefixes[i+1:] {
+			if a.network == b.network || !(a.cidr.Contains(b.cidr.IP) || b.cidr.Contains(a.cidr.IP)) {
+				continue
+			}
+			aOnes, _ := a.cidr.Mask.Size()
+			bOnes, _ := b.cidr.Mask.Size()
+			overlap := map[string]interface{}{
+				tgNetworkAnalysisCidr:               a.cidr.String(),
+				tgNetworkAnalysisNetwork:            a.network,
+				tgNetworkAnalysisOverlappingCidr:    b.cidr.String(),
+				tgNetworkAnalysisOverlappingNetwork: b.network,
+				tgNetworkAnalysisConflict:           tgNetworkAnalysisConflictMoreSpecific,
+			}
+			switch {
+			case aOnes == bOnes:
+				overlap[tgNetworkAnalysisRelation] = tgNetworkAnalysisRelationIdentical
+				overlap[tgNetworkAnalysisConflict] = tgNetworkAnalysisConflictDuplicate
+				overlap[tgNetworkAnalysisEffectiveNetwork] = ""
+			case aOnes < bOnes:
+				overlap[tgNetworkAnalysisRelation] = tgNetworkAnalysisRelationContains
+				overlap[tgNetworkAnalysisEffectiveNetwork] = b.network
+			default:
+				overlap[tgNetworkAnalysisRelation] = tgNetworkAnalysisRelationWithin
+				overlap[tgNetworkAnalysisEffectiveNetwork] = a.network
+			}
+			overlaps = append(overlaps, overlap)
+		}
+	}
+	return overlaps
+}
+
+func dataSourceIBMTransitGatewayNetworkAnalysisID(d *schema.ResourceData) string {
+	return time.Now().UTC().String()
+}
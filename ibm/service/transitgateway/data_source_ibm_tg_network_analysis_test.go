@@ -0,0 +1,82 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package transitgateway_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMTransitGatewayNetworkAnalysisDataSource_basic(t *testing.T) {
+	gatewayname := fmt.Sprintf("gateway-name-%d", acctest.RandIntRange(10, 100))
+	vpcname := fmt.Sprintf("tg-vpc-name-%d", acctest.RandIntRange(10, 100))
+	location := fmt.Sprintf("us-south")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMTransitGatewayNetworkAnalysisDataSourceConfig(gatewayname, vpcname, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ibm_tg_network_analysis.test_tg_analysis", "prefixes.#"),
+					resource.TestCheckResourceAttr("data.ibm_tg_network_analysis.test_tg_analysis", "has_overlaps", "true"),
+					resource.TestCheckResourceAttr("data.ibm_tg_network_analysis.test_tg_analysis", "proposed_overlap_count", "1"),
+					resource.TestCheckResourceAttr("data.ibm_tg_network_analysis.test_tg_analysis", "overlaps.0.overlapping_network", "proposed:landing-zone"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMTransitGatewayNetworkAnalysisDataSourceConfig(gatewayname, vpcname, location string) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "test_tg_vpc" {
+		name = "%s"
+		address_prefix_management = "manual"
+	}
+
+	resource "ibm_is_vpc_address_prefix" "test_tg_vpc_prefix" {
+		name = "%s-prefix"
+		zone = "us-south-1"
+		vpc  = ibm_is_vpc.test_tg_vpc.id
+		cidr = "10.240.0.0/18"
+	}
+
+	resource "ibm_tg_gateway" "test_tg_gateway" {
+		name = "%s"
+		location = "%s"
+		global = true
+	}
+
+	resource "ibm_tg_connection" "test_tg_connection" {
+		gateway = ibm_tg_gateway.test_tg_gateway.id
+		network_type = "vpc"
+		name = "%s"
+		network_id = ibm_is_vpc.test_tg_vpc.resource_crn
+		depends_on = [ibm_is_vpc_address_prefix.test_tg_vpc_prefix]
+	}
+
+	resource "ibm_tg_route_report" "test_tg_route" {
+		gateway = ibm_tg_gateway.test_tg_gateway.id
+		depends_on = [ibm_tg_connection.test_tg_connection]
+	}
+
+	data "ibm_tg_network_analysis" "test_tg_analysis" {
+		vpc_ids = [ibm_is_vpc.test_tg_vpc.id]
+		transit_gateway_route_reports {
+			gateway = ibm_tg_gateway.test_tg_gateway.id
+			route_report = ibm_tg_route_report.test_tg_route.route_report_id
+		}
+		proposed_cidrs {
+			name = "landing-zone"
+			cidr = "10.240.16.0/24"
+		}
+	}
+	`, vpcname, vpcname, gatewayname, location, vpcname)
+}
@@ -0,0 +1,563 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/cos"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/ibm-cos-sdk-go/aws"
+	"github.com/IBM/ibm-cos-sdk-go/service/s3"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// flowLogObjectPrefix is the prefix of the objects that flow log
+	// collectors write to their storage bucket.
+	flowLogObjectPrefix = "ibm_vpc_flowlogs_v1/"
+
+	flowLogActionRejected = "rejected"
+)
+
+// flowLogObjectHour matches the hour partition of a flow log object key.
+var flowLogObjectHour = regexp.MustCompile(`/year=(\d{4})/month=(\d{2})/day=(\d{2})/hour=(\d{2})/`)
+
+var flowLogProtocols = map[int]string{
+	1:  "icmp",
+	6:  "tcp",
+	17: "udp",
+}
+
+func DataSourceIBMIsFlowLogAnalytics() *schema.Resource {
+	flowSummarySchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"initiator_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IP address of the initiator of the connections.",
+			},
+			"target_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IP address of the target of the connections.",
+			},
+			"target_port": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The port of the target of the connections.",
+			},
+			"protocol": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The transport protocol of the connections, such as tcp, udp or icmp.",
+			},
+			"action": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Whether the connections were accepted or rejected.",
+			},
+			"connections": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of connections.",
+			},
+			"bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of bytes that were transferred in both directions.",
+			},
+			"packets": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of packets that were transferred in both directions.",
+			},
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceIBMIsFlowLogAnalyticsRead,
+
+		Schema: map[string]*schema.Schema{
+			"flow_log": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The flow log collector identifier.",
+			},
+			"bucket_region": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The region of the storage bucket of the flow log collector.",
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "public",
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private", "direct"}),
+				Description:  "The COS endpoint type to read the flow logs with, public, private or direct.",
+			},
+			"resource_instance_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"resource_instance_id", "hmac_access_key_id"},
+				Description:  "The CRN of the COS instance of the storage bucket, to read the flow logs with IAM authentication.",
+			},
+			"hmac_access_key_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"hmac_secret_access_key"},
+				ExactlyOneOf: []string{"resource_instance_id", "hmac_access_key_id"},
+				Description:  "The HMAC access key ID to read the flow logs with.",
+			},
+			"hmac_secret_access_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"hmac_access_key_id"},
+				Description:  "The HMAC secret access key to read the flow logs with.",
+			},
+			"start_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "The start of the time window in RFC 3339 format, defaults to one hour before end_time.",
+			},
+			"end_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "The end of the time window in RFC 3339 format, defaults to the current time.",
+			},
+			"initiator_ip": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only include the connections of this initiator IP address.",
+			},
+			"target_ip": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only include the connections to this target IP address.",
+			},
+			"target_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 65535),
+				Description:  "Only include the connections to this target port.",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(1, 1000),
+				Description:  "The maximum number of entries in top_talkers and rejected_flows.",
+			},
+			"objects_read": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of flow log objects of the collector that were read.",
+			},
+			"total_connections": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of connections in the time window.",
+			},
+			"total_rejected_connections": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of rejected connections in the time window.",
+			},
+			"total_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of bytes that were transferred in the time window.",
+			},
+			"top_talkers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        flowSummarySchema,
+				Description: "The flows with the most bytes, by initiator, target, target port, protocol and action.",
+			},
+			"rejected_flows": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        flowSummarySchema,
+				Description: "The rejected flows with the most connections, by initiator, target, target port and protocol.",
+			},
+		},
+	}
+}
+
+// flowLogObject is the content of a flow log object.
+type flowLogObject struct {
+	CollectorCRN string          `json:"collector_crn"`
+	FlowLogs     []flowLogRecord `json:"flow_logs"`
+}
+
+type flowLogRecord struct {
+	StartTime            time.Time `json:"start_time"`
+	EndTime              time.Time `json:"end_time"`
+	ConnectionStartTime  string    `json:"connection_start_time"`
+	Action               string    `json:"action"`
+	InitiatorIP          string    `json:"initiator_ip"`
+	TargetIP             string    `json:"target_ip"`
+	InitiatorPort        int       `json:"initiator_port"`
+	TargetPort           int       `json:"target_port"`
+	TransportProtocol    int       `json:"transport_protocol"`
+	BytesFromInitiator   int64     `json:"bytes_from_initiator"`
+	PacketsFromInitiator int64     `json:"packets_from_initiator"`
+	BytesFromTarget      int64     `json:"bytes_from_target"`
+	PacketsFromTarget    int64     `json:"packets_from_target"`
+}
+
+type flowLogFilter struct {
+	start       time.Time
+	end         time.Time
+	initiatorIP string
+	targetIP    string
+	targetPort  int
+}
+
+type flowKey struct {
+	initiatorIP string
+	targetIP    string
+	targetPort  int
+	protocol    int
+	action      string
+}
+
+type flowSummary struct {
+	flowKey
+	connections map[string]bool
+	bytes       int64
+	packets     int64
+}
+
+// flowLogAnalytics aggregates the flow log records by flow.
+type flowLogAnalytics struct {
+	objectsRead int
+	flows       map[flowKey]*flowSummary
+}
+
+func dataSourceIBMIsFlowLogAnalyticsRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	flowLogID := d.Get("flow_log").(string)
+	getFlowLogCollectorOptions := &vpcv1.GetFlowLogCollectorOptions{
+		ID: &flowLogID,
+	}
+	flowLogCollector, response, err := sess.GetFlowLogCollectorWithContext(context, getFlowLogCollectorOptions)
+	if err != nil {
+		log.Printf("[DEBUG] GetFlowLogCollectorWithContext failed %s\n%s", err, response)
+		return diag.FromErr(fmt.Errorf("[ERROR] Error getting flow log collector (%s): %s\n%s", flowLogID, err, response))
+	}
+
+	filter := flowLogFilter{
+		end:         time.Now().UTC(),
+		initiatorIP: d.Get("initiator_ip").(string),
+		targetIP:    d.Get("target_ip").(string),
+		targetPort:  -1,
+	}
+	if v, ok := d.GetOk("end_time"); ok {
+		filter.end, _ = time.Parse(time.RFC3339, v.(string))
+	}
+	filter.start = filter.end.Add(-time.Hour)
+	if v, ok := d.GetOk("start_time"); ok {
+		filter.start, _ = time.Parse(time.RFC3339, v.(string))
+	}
+	if !filter.start.Before(filter.end) {
+		return diag.FromErr(fmt.Errorf("[ERROR] start_time %s must be before end_time %s", filter.start.Format(time.RFC3339), filter.end.Format(time.RFC3339)))
+	}
+	if v, ok := d.GetOkExists("target_port"); ok {
+		filter.targetPort = v.(int)
+	}
+
+	bucketRegion := d.Get("bucket_region").(string)
+	endpointType := d.Get("endpoint_type").(string)
+	var s3Client *s3.S3
+	if accessKeyID, ok := d.GetOk("hmac_access_key_id"); ok {
+		s3Client, err = cos.GetS3HMACClient(bucketRegion, endpointType, accessKeyID.(string), d.Get("hmac_secret_access_key").(string))
+	} else {
+		bxSession, bxErr := meta.(conns.ClientSession).BluemixSession()
+		if bxErr != nil {
+			return diag.FromErr(bxErr)
+		}
+		s3Client, err = cos.GetS3Client(bxSession, bucketRegion, endpointType, d.Get("resource_instance_id").(string))
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	prefix, segments := flowLogObjectTarget(flowLogCollector)
+	analytics, err := readFlowLogAnalytics(context, s3Client, *flowLogCollector.StorageBucket.Name, *flowLogCollector.CRN, prefix, segments, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	limit := d.Get("limit").(int)
+	topTalkers, rejectedFlows, totals := analytics.summarize(limit)
+	d.SetId(fmt.Sprintf("%s/%s/%s", flowLogID, filter.start.Format(time.RFC3339), filter.end.Format(time.RFC3339)))
+	d.Set("start_time", filter.start.Format(time.RFC3339))
+	d.Set("end_time", filter.end.Format(time.RFC3339))
+	d.Set("objects_read", analytics.objectsRead)
+	d.Set("total_connections", totals.connections)
+	d.Set("total_rejected_connections", totals.rejectedConnections)
+	d.Set("total_bytes", totals.bytes)
+	if err = d.Set("top_talkers", topTalkers); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error setting top_talkers %s", err))
+	}
+	if err = d.Set("rejected_flows", rejectedFlows); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error setting rejected_flows %s", err))
+	}
+	return nil
+}
+
+// flowLogObjectTarget returns the prefix of the object keys of the collector
+// target, and the key segments of the target that follow the prefix. The
+// object keys are
+// ibm_vpc_flowlogs_v1/account=<account>/region=<region>/vpc-id=<vpc>/subnet-id=<subnet>/endpoint-type=vnics/instance-id=<instance>/vnic-id=<network interface>/record-type=<type>/year=<year>/month=<month>/day=<day>/hour=<hour>/stream-id=<stream>/<sequence>.jsonl.gz
+func flowLogObjectTarget(collector *vpcv1.FlowLogCollector) (string, []string) {
+	// the VPC and the subnet segments lead the keys, and narrow the prefix
+	path := []string{"vpc-id=" + *collector.VPC.ID}
+	segments := []string{}
+	if target, ok := collector.Target.(*vpcv1.FlowLogCollectorTarget); ok && target.ID != nil && target.ResourceType != nil {
+		switch *target.ResourceType {
+		case "subnet":
+			path = append(path, "subnet-id="+*target.ID)
+		case "instance":
+			segments = append(segments, "/instance-id="+*target.ID+"/")
+		case "network_interface":
+			segments = append(segments, "/vnic-id="+*target.ID+"/")
+		}
+	}
+
+	// crn:v1:<cname>:<ctype>:is:<region>:a/<account>::flow-log-collector:<id>
+	crn := strings.Split(*collector.CRN, ":")
+	if len(crn) < 7 || !strings.HasPrefix(crn[6], "a/") {
+		pathSegments := make([]string, 0, len(path))
+		for _, segment := range path {
+			pathSegments = append(pathSegments, "/"+segment+"/")
+		}
+		return flowLogObjectPrefix, append(pathSegments, segments...)
+	}
+	prefix := fmt.Sprintf("%saccount=%s/region=%s/%s/", flowLogObjectPrefix, strings.TrimPrefix(crn[6], "a/"), crn[5], strings.Join(path, "/"))
+	return prefix, segments
+}
+
+// readFlowLogAnalytics reads the flow log objects of the collector in the hour
+// partitions that overlap the time window, and aggregates their records. Only
+// the objects with the prefix and the key segments of the collector target are
+// read.
+func readFlowLogAnalytics(context context.Context, s3Client *s3.S3, bucketName, collectorCRN, prefix string, segments []string, filter flowLogFilter) (*flowLogAnalytics, error) {
+	keys := []string{}
+	listInput := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucketName),
+		Prefix: aws.String(prefix),
+	}
+	err := s3Client.ListObjectsV2PagesWithContext(context, listInput, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			if flowLogObjectOfTarget(*object.Key, segments) && flowLogObjectInWindow(*object.Key, filter.start, filter.end) {
+				keys = append(keys, *object.Key)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error listing the flow logs in bucket %s: %s", bucketName, err)
+	}
+
+	analytics := &flowLogAnalytics{flows: make(map[flowKey]*flowSummary)}
+	for _, key := range keys {
+		object, err := readFlowLogObject(context, s3Client, bucketName, key)
+		if err != nil {
+			return nil, err
+		}
+		if object.CollectorCRN != collectorCRN {
+			continue
+		}
+		analytics.objectsRead++
+		for _, record := range object.FlowLogs {
+			analytics.add(record, filter)
+		}
+	}
+	return analytics, nil
+}
+
+// flowLogObjectOfTarget reports whether the object key has the segments of
+// the collector target.
+func flowLogObjectOfTarget(key string, segments []string) bool {
+	for _, segment := range segments {
+		if !strings.Contains(key, segment) {
+			return false
+		}
+	}
+	return true
+}
+
+// flowLogObjectInWindow reports whether the hour partition of the object key
+// overlaps the time window.
+func flowLogObjectInWindow(key string, start, end time.Time) bool {
+	match := flowLogObjectHour.FindStringSubmatch(key)
+	if match == nil {
+		return false
+	}
+	hour, err := time.Parse("2006010215", match[1]+match[2]+match[3]+match[4])
+	if err != nil {
+		return false
+	}
+	return hour.Before(end) && hour.Add(time.Hour).After(start)
+}
+
+func readFlowLogObject(context context.Context, s3Client *s3.S3, bucketName, key string) (*flowLogObject, error) {
+	output, err := s3Client.GetObjectWithContext(context, &s3.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error reading flow log %s: %s", key, err)
+	}
+	defer output.Body.Close()
+	content, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error reading flow log %s: %s", key, err)
+	}
+	// the objects are gzipped, unless the client already decompressed them
+	if bytes.HasPrefix(content, []byte{0x1f, 0x8b}) {
+		reader, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error decompressing flow log %s: %s", key, err)
+		}
+		if content, err = io.ReadAll(reader); err != nil {
+			return nil, fmt.Errorf("[ERROR] Error decompressing flow log %s: %s", key, err)
+		}
+	}
+	object := &flowLogObject{}
+	if err := json.Unmarshal(content, object); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error parsing flow log %s: %s", key, err)
+	}
+	return object, nil
+}
+
+func (a *flowLogAnalytics) add(record flowLogRecord, filter flowLogFilter) {
+	if !record.StartTime.Before(filter.end) || record.EndTime.Before(filter.start) {
+		return
+	}
+	if (filter.initiatorIP != "" && record.InitiatorIP != filter.initiatorIP) ||
+		(filter.targetIP != "" && record.TargetIP != filter.targetIP) ||
+		(filter.targetPort >= 0 && record.TargetPort != filter.targetPort) {
+		return
+	}
+	key := flowKey{
+		initiatorIP: record.InitiatorIP,
+		targetIP:    record.TargetIP,
+		targetPort:  record.TargetPort,
+		protocol:    record.TransportProtocol,
+		action:      record.Action,
+	}
+	summary, ok := a.flows[key]
+	if !ok {
+		summary = &flowSummary{flowKey: key, connections: make(map[string]bool)}
+		a.flows[key] = summary
+	}
+	// a long connection is reported in the records of multiple capture windows
+	summary.connections[fmt.Sprintf("%d/%s", record.InitiatorPort, record.ConnectionStartTime)] = true
+	summary.bytes += record.BytesFromInitiator + record.BytesFromTarget
+	summary.packets += record.PacketsFromInitiator + record.PacketsFromTarget
+}
+
+type flowLogTotals struct {
+	connections         int
+	rejectedConnections int
+	bytes               int64
+}
+
+// summarize returns the top talkers by bytes, the rejected flows by
+// connections and the totals.
+func (a *flowLogAnalytics) summarize(limit int) ([]map[string]interface{}, []map[string]interface{}, flowLogTotals) {
+	totals := flowLogTotals{}
+	all := make([]*flowSummary, 0, len(a.flows))
+	rejected := []*flowSummary{}
+	for _, summary := range a.flows {
+		all = append(all, summary)
+		totals.connections += len(summary.connections)
+		totals.bytes += summary.bytes
+		if summary.action == flowLogActionRejected {
+			rejected = append(rejected, summary)
+			totals.rejectedConnections += len(summary.connections)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].bytes != all[j].bytes {
+			return all[i].bytes > all[j].bytes
+		}
+		return all[i].flowKey.less(all[j].flowKey)
+	})
+	sort.Slice(rejected, func(i, j int) bool {
+		if len(rejected[i].connections) != len(rejected[j].connections) {
+			return len(rejected[i].connections) > len(rejected[j].connections)
+		}
+		return rejected[i].flowKey.less(rejected[j].flowKey)
+	})
+	return flowSummariesToMap(all, limit), flowSummariesToMap(rejected, limit), totals
+}
+
+func (k flowKey) less(other flowKey) bool {
+	if k.initiatorIP != other.initiatorIP {
+		return k.initiatorIP < other.initiatorIP
+	}
+	if k.targetIP != other.targetIP {
+		return k.targetIP < other.targetIP
+	}
+	if k.targetPort != other.targetPort {
+		return k.targetPort < other.targetPort
+	}
+	if k.protocol != other.protocol {
+		return k.protocol < other.protocol
+	}
+	return k.action < other.action
+}
+
+func flowSummariesToMap(summaries []*flowSummary, limit int) []map[string]interface{} {
+	if len(summaries) > limit {
+		summaries = summaries[:limit]
+	}
+	result := make([]map[string]interface{}, 0, len(summaries))
+	for _, summary := range summaries {
+		protocol, ok := flowLogProtocols[summary.protocol]
+		if !ok {
+			protocol = strconv.Itoa(summary.protocol)
+		}
+		result = append(result, map[string]interface{}{
+			"initiator_ip": summary.initiatorIP,
+			"target_ip":    summary.targetIP,
+			"target_port":  summary.targetPort,
+			"protocol":     protocol,
+			"action":       summary.action,
+			"connections":  len(summary.connections),
+			"bytes":        int(summary.bytes),
+			"packets":      int(summary.packets),
+		})
+	}
+	return result
+}
@@ -0,0 +1,340 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/cos"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+)
+
+func flowLogTestTime(t *testing.T, value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func flowLogTestKey(hour string) string {
+	return flowLogTestTargetKey("r006-vpc", "0717-subnet", "0717-instance", hour)
+}
+
+func flowLogTestTargetKey(vpcID, subnetID, instanceID, hour string) string {
+	return "ibm_vpc_flowlogs_v1/account=a1/region=us-south/vpc-id=" + vpcID + "/subnet-id=" + subnetID + "/endpoint-type=vnics/instance-id=" + instanceID + "/vnic-id=0717-vnic/record-type=ingress/" +
+		hour + "/stream-id=20230601T100000Z/00000001.jsonl.gz"
+}
+
+func flowLogTestCollector(crn, resourceType, id string) *vpcv1.FlowLogCollector {
+	return &vpcv1.FlowLogCollector{
+		CRN:    &crn,
+		VPC:    &vpcv1.VPCReference{ID: core.StringPtr("r006-vpc")},
+		Target: &vpcv1.FlowLogCollectorTarget{ID: &id, ResourceType: &resourceType},
+	}
+}
+
+func TestFlowLogObjectTarget(t *testing.T) {
+	collectorCRN := "crn:v1:bluemix:public:is:us-south:a/a1::flow-log-collector:r006-collector"
+	hour := "year=2023/month=06/day=01/hour=10"
+
+	testCases := []struct {
+		name         string
+		collector    *vpcv1.FlowLogCollector
+		prefix       string
+		segments     []string
+		keys         []string
+		excludedKeys []string
+	}{
+		{
+			name:         "vpc",
+			collector:    flowLogTestCollector(collectorCRN, "vpc", "r006-vpc"),
+			prefix:       "ibm_vpc_flowlogs_v1/account=a1/region=us-south/vpc-id=r006-vpc/",
+			segments:     []string{},
+			keys:         []string{flowLogTestKey(hour), flowLogTestTargetKey("r006-vpc", "0717-other", "0717-other", hour)},
+			excludedKeys: []string{flowLogTestTargetKey("r006-other", "0717-subnet", "0717-instance", hour)},
+		},
+		{
+			name:         "subnet",
+			collector:    flowLogTestCollector(collectorCRN, "subnet", "0717-subnet"),
+			prefix:       "ibm_vpc_flowlogs_v1/account=a1/region=us-south/vpc-id=r006-vpc/subnet-id=0717-subnet/",
+			segments:     []string{},
+			keys:         []string{flowLogTestKey(hour), flowLogTestTargetKey("r006-vpc", "0717-subnet", "0717-other", hour)},
+			excludedKeys: []string{flowLogTestTargetKey("r006-vpc", "0717-other", "0717-instance", hour)},
+		},
+		{
+			name:         "instance",
+			collector:    flowLogTestCollector(collectorCRN, "instance", "0717-instance"),
+			prefix:       "ibm_vpc_flowlogs_v1/account=a1/region=us-south/vpc-id=r006-vpc/",
+			segments:     []string{"/instance-id=0717-instance/"},
+			keys:         []string{flowLogTestKey(hour), flowLogTestTargetKey("r006-vpc", "0717-other", "0717-instance", hour)},
+			excludedKeys: []string{flowLogTestTargetKey("r006-vpc", "0717-subnet", "0717-other", hour)},
+		},
+		{
+			name:         "network interface",
+			collector:    flowLogTestCollector(collectorCRN, "network_interface", "0717-vnic"),
+			prefix:       "ibm_vpc_flowlogs_v1/account=a1/region=us-south/vpc-id=r006-vpc/",
+			segments:     []string{"/vnic-id=0717-vnic/"},
+			keys:         []string{flowLogTestKey(hour)},
+			excludedKeys: []string{strings.Replace(flowLogTestKey(hour), "vnic-id=0717-vnic", "vnic-id=0717-other", 1)},
+		},
+		{
+			name:         "invalid crn",
+			collector:    flowLogTestCollector("r006-collector", "subnet", "0717-subnet"),
+			prefix:       flowLogObjectPrefix,
+			segments:     []string{"/vpc-id=r006-vpc/", "/subnet-id=0717-subnet/"},
+			keys:         []string{flowLogTestKey(hour)},
+			excludedKeys: []string{flowLogTestTargetKey("r006-other", "0717-subnet", "0717-instance", hour), flowLogTestTargetKey("r006-vpc", "0717-other", "0717-instance", hour)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prefix, segments := flowLogObjectTarget(tc.collector)
+			if prefix != tc.prefix || !reflect.DeepEqual(segments, tc.segments) {
+				t.Fatalf("got prefix %q and segments %q, want %q and %q", prefix, segments, tc.prefix, tc.segments)
+			}
+			for _, key := range tc.keys {
+				if !strings.HasPrefix(key, prefix) || !flowLogObjectOfTarget(key, segments) {
+					t.Fatalf("got key %s excluded, want included", key)
+				}
+			}
+			for _, key := range tc.excludedKeys {
+				if strings.HasPrefix(key, prefix) && flowLogObjectOfTarget(key, segments) {
+					t.Fatalf("got key %s included, want excluded", key)
+				}
+			}
+		})
+	}
+}
+
+func TestFlowLogObjectInWindow(t *testing.T) {
+	start := flowLogTestTime(t, "2023-06-01T10:30:00Z")
+	end := flowLogTestTime(t, "2023-06-01T11:30:00Z")
+
+	testCases := []struct {
+		hour     string
+		inWindow bool
+	}{
+		{"year=2023/month=06/day=01/hour=09", false},
+		{"year=2023/month=06/day=01/hour=10", true},
+		{"year=2023/month=06/day=01/hour=11", true},
+		{"year=2023/month=06/day=01/hour=12", false},
+		{"year=2023/month=05/day=31/hour=10", false},
+		{"year=2023/month=13/day=01/hour=10", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.hour, func(t *testing.T) {
+			key := "ibm_vpc_flowlogs_v1/account=a1/00000001.jsonl.gz"
+			if tc.hour != "" {
+				key = flowLogTestKey(tc.hour)
+			}
+			if inWindow := flowLogObjectInWindow(key, start, end); inWindow != tc.inWindow {
+				t.Fatalf("got %t, want %t", inWindow, tc.inWindow)
+			}
+		})
+	}
+
+	// The window ends before the hour starts.
+	if flowLogObjectInWindow(flowLogTestKey("year=2023/month=06/day=01/hour=11"), start, flowLogTestTime(t, "2023-06-01T11:00:00Z")) {
+		t.Fatalf("got true for an hour that starts at the end of the window, want false")
+	}
+}
+
+// flowLogTestRecords are the records of a capture window between 10:40 and
+// 10:45, and of the next capture window for the long SSH connection.
+func flowLogTestRecords(t *testing.T) []flowLogRecord {
+	record := func(start, initiatorIP, targetIP string, initiatorPort, targetPort, protocol int, action string, bytes int64) flowLogRecord {
+		startTime := flowLogTestTime(t, start)
+		return flowLogRecord{
+			StartTime:            startTime,
+			EndTime:              startTime.Add(5 * time.Minute),
+			ConnectionStartTime:  "2023-06-01T10:40:00Z",
+			Action:               action,
+			InitiatorIP:          initiatorIP,
+			TargetIP:             targetIP,
+			InitiatorPort:        initiatorPort,
+			TargetPort:           targetPort,
+			TransportProtocol:    protocol,
+			BytesFromInitiator:   bytes,
+			PacketsFromInitiator: 1,
+			BytesFromTarget:      bytes,
+			PacketsFromTarget:    1,
+		}
+	}
+	return []flowLogRecord{
+		record("2023-06-01T10:40:00Z", "10.240.0.4", "10.240.0.5", 50000, 22, 6, "accepted", 500),
+		record("2023-06-01T10:45:00Z", "10.240.0.4", "10.240.0.5", 50000, 22, 6, "accepted", 700),
+		record("2023-06-01T10:40:00Z", "10.240.0.6", "10.240.0.5", 40000, 53, 17, "accepted", 50),
+		record("2023-06-01T10:40:00Z", "192.0.2.1", "10.240.0.5", 40001, 3389, 6, "rejected", 10),
+		record("2023-06-01T10:40:00Z", "192.0.2.1", "10.240.0.5", 40002, 3389, 6, "rejected", 10),
+		record("2023-06-01T10:40:00Z", "192.0.2.2", "10.240.0.5", 0, 0, 1, "rejected", 20),
+		record("2023-06-01T10:40:00Z", "10.240.0.4", "10.240.0.7", 50001, 80, 47, "accepted", 5),
+		// Outside of the time window.
+		record("2023-06-01T09:00:00Z", "10.240.0.4", "10.240.0.5", 50002, 22, 6, "accepted", 100000),
+	}
+}
+
+func TestFlowLogAnalyticsSummarize(t *testing.T) {
+	filter := flowLogFilter{
+		start:      flowLogTestTime(t, "2023-06-01T10:30:00Z"),
+		end:        flowLogTestTime(t, "2023-06-01T11:30:00Z"),
+		targetPort: -1,
+	}
+	analytics := &flowLogAnalytics{flows: make(map[flowKey]*flowSummary)}
+	for _, record := range flowLogTestRecords(t) {
+		analytics.add(record, filter)
+	}
+
+	topTalkers, rejectedFlows, totals := analytics.summarize(3)
+	// The SSH connection is reported twice and counted once.
+	if totals != (flowLogTotals{connections: 6, rejectedConnections: 3, bytes: 2590}) {
+		t.Fatalf("got totals %+v", totals)
+	}
+	wantTopTalkers := []map[string]interface{}{
+		{"initiator_ip": "10.240.0.4", "target_ip": "10.240.0.5", "target_port": 22, "protocol": "tcp", "action": "accepted", "connections": 1, "bytes": 2400, "packets": 4},
+		{"initiator_ip": "10.240.0.6", "target_ip": "10.240.0.5", "target_port": 53, "protocol": "udp", "action": "accepted", "connections": 1, "bytes": 100, "packets": 2},
+		{"initiator_ip": "192.0.2.1", "target_ip": "10.240.0.5", "target_port": 3389, "protocol": "tcp", "action": "rejected", "connections": 2, "bytes": 40, "packets": 4},
+	}
+	if !reflect.DeepEqual(topTalkers, wantTopTalkers) {
+		t.Fatalf("got top talkers %v, want %v", topTalkers, wantTopTalkers)
+	}
+	wantRejectedFlows := []map[string]interface{}{
+		{"initiator_ip": "192.0.2.1", "target_ip": "10.240.0.5", "target_port": 3389, "protocol": "tcp", "action": "rejected", "connections": 2, "bytes": 40, "packets": 4},
+		{"initiator_ip": "192.0.2.2", "target_ip": "10.240.0.5", "target_port": 0, "protocol": "icmp", "action": "rejected", "connections": 1, "bytes": 40, "packets": 2},
+	}
+	if !reflect.DeepEqual(rejectedFlows, wantRejectedFlows) {
+		t.Fatalf("got rejected flows %v, want %v", rejectedFlows, wantRejectedFlows)
+	}
+
+	// Protocols without a name are reported by number.
+	topTalkers, _, _ = analytics.summarize(10)
+	if protocol := topTalkers[len(topTalkers)-1]["protocol"]; protocol != "47" {
+		t.Fatalf("got protocol %v for the smallest flow, want 47", protocol)
+	}
+}
+
+func TestFlowLogAnalyticsFilter(t *testing.T) {
+	testCases := []struct {
+		name        string
+		filter      flowLogFilter
+		connections int
+	}{
+		{"initiator", flowLogFilter{initiatorIP: "192.0.2.1", targetPort: -1}, 2},
+		{"target", flowLogFilter{targetIP: "10.240.0.7", targetPort: -1}, 1},
+		{"target port", flowLogFilter{targetPort: 22}, 1},
+		{"target port 0", flowLogFilter{targetPort: 0}, 1},
+		{"initiator and target port", flowLogFilter{initiatorIP: "10.240.0.4", targetPort: 80}, 1},
+		{"no match", flowLogFilter{initiatorIP: "10.240.0.6", targetPort: 22}, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.filter.start = flowLogTestTime(t, "2023-06-01T10:30:00Z")
+			tc.filter.end = flowLogTestTime(t, "2023-06-01T11:30:00Z")
+			analytics := &flowLogAnalytics{flows: make(map[flowKey]*flowSummary)}
+			for _, record := range flowLogTestRecords(t) {
+				analytics.add(record, tc.filter)
+			}
+			if _, _, totals := analytics.summarize(10); totals.connections != tc.connections {
+				t.Fatalf("got %d connections, want %d", totals.connections, tc.connections)
+			}
+		})
+	}
+}
+
+// TestReadFlowLogAnalyticsHMAC reads flow logs with HMAC credentials from a
+// local S3 server set with IBMCLOUD_COS_ENDPOINT.
+func TestReadFlowLogAnalyticsHMAC(t *testing.T) {
+	collectorCRN := "crn:v1:bluemix:public:is:us-south:a/a1::flow-log-collector:r006-collector"
+	otherCRN := "crn:v1:bluemix:public:is:us-south:a/a1::flow-log-collector:r006-other"
+	objects := map[string]flowLogObject{
+		flowLogTestKey("year=2023/month=06/day=01/hour=10"):                                                     {CollectorCRN: collectorCRN, FlowLogs: flowLogTestRecords(t)},
+		flowLogTestKey("year=2023/month=06/day=01/hour=08"):                                                     {CollectorCRN: collectorCRN, FlowLogs: flowLogTestRecords(t)},
+		flowLogTestTargetKey("r006-vpc", "0717-other", "0717-other", "year=2023/month=06/day=01/hour=11"):       {CollectorCRN: otherCRN, FlowLogs: flowLogTestRecords(t)},
+		flowLogTestTargetKey("r006-other", "0717-subnet", "0717-instance", "year=2023/month=06/day=01/hour=11"): {CollectorCRN: otherCRN, FlowLogs: flowLogTestRecords(t)},
+	}
+	prefix, segments := flowLogObjectTarget(flowLogTestCollector(collectorCRN, "subnet", "0717-subnet"))
+
+	var authorizations []string
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/flow-logs" {
+			if r.URL.Query().Get("prefix") != prefix {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			var contents strings.Builder
+			keyCount := 0
+			for key := range objects {
+				if strings.HasPrefix(key, prefix) {
+					fmt.Fprintf(&contents, "<Contents><Key>%s</Key><Size>1</Size></Contents>", key)
+					keyCount++
+				}
+			}
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Name>flow-logs</Name><Prefix>%s</Prefix><KeyCount>%d</KeyCount><MaxKeys>1000</MaxKeys><IsTruncated>false</IsTruncated>%s</ListBucketResult>`,
+				prefix, keyCount, contents.String())
+			return
+		}
+		object, ok := objects[strings.TrimPrefix(r.URL.Path, "/flow-logs/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var content bytes.Buffer
+		writer := gzip.NewWriter(&content)
+		json.NewEncoder(writer).Encode(object)
+		writer.Close()
+		w.Write(content.Bytes())
+	}))
+	defer server.Close()
+	t.Setenv("IBMCLOUD_COS_ENDPOINT", server.URL)
+
+	s3Client, err := cos.GetS3HMACClient("us-south", "public", "access-key-id", "secret-access-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter := flowLogFilter{
+		start:      flowLogTestTime(t, "2023-06-01T10:30:00Z"),
+		end:        flowLogTestTime(t, "2023-06-01T11:30:00Z"),
+		targetPort: -1,
+	}
+	analytics, err := readFlowLogAnalytics(context.Background(), s3Client, "flow-logs", collectorCRN, prefix, segments, filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The object of hour 08 is not read, and the objects of the other
+	// collector are not listed.
+	if analytics.objectsRead != 1 {
+		t.Fatalf("got %d objects read, want 1", analytics.objectsRead)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("got requests %q, want a listing and one object", paths)
+	}
+	if _, _, totals := analytics.summarize(10); totals.connections != 6 {
+		t.Fatalf("got %d connections, want 6", totals.connections)
+	}
+	for _, authorization := range authorizations {
+		if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=access-key-id/") || !strings.Contains(authorization, "/us-south/s3/aws4_request") {
+			t.Fatalf("got authorization %q, want an HMAC signature of access-key-id in us-south", authorization)
+		}
+	}
+}
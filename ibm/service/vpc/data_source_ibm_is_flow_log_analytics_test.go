@@ -0,0 +1,44 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMIsFlowLogAnalyticsDataSourceBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheckFlowLogAnalytics(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMIsFlowLogAnalyticsDataSourceConfig(acc.IsFlowLogID, acc.IsFlowLogBucketRegion, acc.CosCRN),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ibm_is_flow_log_analytics.is_flow_log_analytics", "start_time"),
+					resource.TestCheckResourceAttrSet("data.ibm_is_flow_log_analytics.is_flow_log_analytics", "end_time"),
+					resource.TestCheckResourceAttrSet("data.ibm_is_flow_log_analytics.is_flow_log_analytics", "objects_read"),
+					resource.TestCheckResourceAttrSet("data.ibm_is_flow_log_analytics.is_flow_log_analytics", "total_connections"),
+					resource.TestCheckResourceAttrSet("data.ibm_is_flow_log_analytics.is_flow_log_analytics", "total_rejected_connections"),
+					resource.TestCheckResourceAttrSet("data.ibm_is_flow_log_analytics.is_flow_log_analytics", "top_talkers.#"),
+					resource.TestCheckResourceAttrSet("data.ibm_is_flow_log_analytics.is_flow_log_analytics", "rejected_flows.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMIsFlowLogAnalyticsDataSourceConfig(flowLogID, bucketRegion, cosCRN string) string {
+	return fmt.Sprintf(`
+	data "ibm_is_flow_log_analytics" "is_flow_log_analytics" {
+		flow_log             = "%s"
+		bucket_region        = "%s"
+		resource_instance_id = "%s"
+	}
+	`, flowLogID, bucketRegion, cosCRN)
+}
@@ -0,0 +1,792 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	isPacketPathSource          = "source"
+	isPacketPathDestination     = "destination"
+	isPacketPathVPC             = "vpc"
+	isPacketPathProtocol        = "protocol"
+	isPacketPathPort            = "port"
+	isPacketPathSourcePort      = "source_port"
+	isPacketPathICMPType        = "icmp_type"
+	isPacketPathICMPCode        = "icmp_code"
+	isPacketPathAllowed         = "allowed"
+	isPacketPathDecidingRuleIDs = "deciding_rule_ids"
+	isPacketPathSteps           = "steps"
+
+	isPacketPathEndpointIP                      = "ip"
+	isPacketPathEndpointSubnet                  = "subnet"
+	isPacketPathEndpointInstance                = "instance"
+	isPacketPathEndpointVirtualNetworkInterface = "virtual_network_interface"
+	isPacketPathEndpointSecurityGroups          = "security_groups"
+
+	// isPacketPathVNIVersion is the API version used to get virtual network
+	// interfaces, which the vpcv1 version of the provider does not cover.
+	isPacketPathVNIVersion = "2024-04-30"
+)
+
+func DataSourceIBMISPacketPath() *schema.Resource {
+	endpoint := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Description: description,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					isPacketPathEndpointIP: {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.IsIPv4Address,
+						Description:  "The IPv4 address of the endpoint. Resolved from instance or virtual_network_interface when they are set",
+					},
+					isPacketPathEndpointSubnet: {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Computed:    true,
+						Description: "The subnet of the endpoint. Resolved from ip and vpc when it is not set, the endpoint is outside the VPC when no subnet contains ip",
+					},
+					isPacketPathEndpointInstance: {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The instance of the endpoint. Its network interface in subnet is used, or its primary network interface",
+					},
+					isPacketPathEndpointVirtualNetworkInterface: {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The virtual network interface of the endpoint",
+					},
+					isPacketPathEndpointSecurityGroups: {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "The security groups of the network interface of the endpoint",
+					},
+				},
+			},
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceIBMISPacketPathRead,
+
+		Schema: map[string]*schema.Schema{
+			isPacketPathSource:      endpoint("The endpoint that opens the connection"),
+			isPacketPathDestination: endpoint("The endpoint that accepts the connection"),
+			isPacketPathVPC: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The VPC whose subnets are searched for endpoints that only have an ip",
+			},
+			isPacketPathProtocol: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"tcp", "udp", "icmp"}, false),
+				Description:  "The protocol of the connection: tcp, udp or icmp",
+			},
+			isPacketPathPort: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
+				Description:  "The destination port of a tcp or udp connection",
+			},
+			isPacketPathSourcePort: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      49152,
+				ValidateFunc: validation.IntBetween(1, 65535),
+				Description:  "The source port of a tcp or udp connection, an ephemeral port by default",
+			},
+			isPacketPathICMPType: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      8,
+				ValidateFunc: validation.IntBetween(0, 254),
+				Description:  "The type of an icmp packet, echo request by default",
+			},
+			isPacketPathICMPCode: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 255),
+				Description:  "The code of an icmp packet",
+			},
+			isPacketPathAllowed: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the connection and its response traffic are allowed",
+			},
+			isPacketPathDecidingRuleIDs: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The routes and rules that allow the connection, or the route or rule that denies it",
+			},
+			isPacketPathSteps: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The evaluation of each hop of the connection, in order",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the step",
+						},
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the evaluated resource: routing_table, security_group or network_acl",
+						},
+						"resource_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The evaluated resources",
+						},
+						"direction": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The direction of the evaluated rules: inbound or outbound",
+						},
+						"allowed": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the step allows the traffic",
+						},
+						"rule_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The route or rule that decides the step, empty when the traffic is denied because no rule matches",
+						},
+						"detail": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Explanation of the decision",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// packetPathEndpoint is an endpoint resolved to its address, subnet and
+// security groups. An endpoint without subnet is outside the VPC.
+type packetPathEndpoint struct {
+	ip             net.IP
+	subnet         *vpcv1.Subnet
+	securityGroups []string
+}
+
+// packetPathFlow is the traffic in one direction.
+type packetPathFlow struct {
+	protocol        string
+	source          net.IP
+	destination     net.IP
+	sourcePort      int64
+	destinationPort int64
+	icmpType        int64
+	icmpCode        int64
+}
+
+type packetPathStep struct {
+	name         string
+	resourceType string
+	resourceIDs  []string
+	direction    string
+	allowed      bool
+	ruleID       string
+	detail       string
+}
+
+func dataSourceIBMISPacketPathRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	protocol := d.Get(isPacketPathProtocol).(string)
+	port, hasPort := d.GetOk(isPacketPathPort)
+	if protocol != "icmp" && !hasPort {
+		return diag.FromErr(fmt.Errorf("[ERROR] port is required for protocol %s", protocol))
+	}
+
+	vpcID := d.Get(isPacketPathVPC).(string)
+	source, err := resolvePacketPathEndpoint(context, sess, vpcID, d.Get(isPacketPathSource).([]interface{})[0].(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error resolving source: %s", err))
+	}
+	destination, err := resolvePacketPathEndpoint(context, sess, vpcID, d.Get(isPacketPathDestination).([]interface{})[0].(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error resolving destination: %s", err))
+	}
+
+	request := packetPathFlow{
+		protocol:    protocol,
+		source:      source.ip,
+		destination: destination.ip,
+		icmpType:    -1,
+		icmpCode:    -1,
+	}
+	if protocol == "icmp" {
+		request.icmpType = int64(d.Get(isPacketPathICMPType).(int))
+		if code, ok := d.GetOk(isPacketPathICMPCode); ok {
+			request.icmpCode = int64(code.(int))
+		}
+	} else {
+		request.sourcePort = int64(d.Get(isPacketPathSourcePort).(int))
+		request.destinationPort = int64(port.(int))
+	}
+	response := request.response()
+
+	evaluator := &packetPathEvaluator{
+		context:        context,
+		sess:           sess,
+		securityGroups: map[string]*vpcv1.SecurityGroup{},
+		networkACLs:    map[string]*vpcv1.NetworkACL{},
+	}
+	steps, err := evaluator.evaluate(source, destination, request, response)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	allowed := true
+	decidingRuleIDs := []string{}
+	stepList := make([]map[string]interface{}, 0, len(steps))
+	for _, step := range steps {
+		if allowed && !step.allowed {
+			allowed = false
+			decidingRuleIDs = []string{}
+			if step.ruleID != "" {
+				decidingRuleIDs = append(decidingRuleIDs, step.ruleID)
+			}
+		} else if allowed && step.ruleID != "" {
+			decidingRuleIDs = append(decidingRuleIDs, step.ruleID)
+		}
+		stepList = append(stepList, map[string]interface{}{
+			"name":          step.name,
+			"resource_type": step.resourceType,
+			"resource_ids":  step.resourceIDs,
+			"direction":     step.direction,
+			"allowed":       step.allowed,
+			"rule_id":       step.ruleID,
+			"detail":        step.detail,
+		})
+	}
+
+	d.SetId(dataSourceIBMISPacketPathID())
+	d.Set(isPacketPathSource, []map[string]interface{}{source.flatten(d.Get(isPacketPathSource).([]interface{})[0].(map[string]interface{}))})
+	d.Set(isPacketPathDestination, []map[string]interface{}{destination.flatten(d.Get(isPacketPathDestination).([]interface{})[0].(map[string]interface{}))})
+	d.Set(isPacketPathAllowed, allowed)
+	d.Set(isPacketPathDecidingRuleIDs, decidingRuleIDs)
+	if err := d.Set(isPacketPathSteps, stepList); err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error setting steps: %s", err))
+	}
+	return nil
+}
+
+// dataSourceIBMISPacketPathID returns a reasonable ID for the packet path.
+func dataSourceIBMISPacketPathID() string {
+	return time.Now().UTC().String()
+}
+
+func (endpoint *packetPathEndpoint) flatten(config map[string]interface{}) map[string]interface{} {
+	m := map[string]interface{}{
+		isPacketPathEndpointIP:                      endpoint.ip.String(),
+		isPacketPathEndpointInstance:                config[isPacketPathEndpointInstance],
+		isPacketPathEndpointVirtualNetworkInterface: config[isPacketPathEndpointVirtualNetworkInterface],
+		isPacketPathEndpointSecurityGroups:          endpoint.securityGroups,
+	}
+	if endpoint.subnet != nil {
+		m[isPacketPathEndpointSubnet] = *endpoint.subnet.ID
+	}
+	return m
+}
+
+// response returns the flow of the response traffic, which stateless network
+// ACLs evaluate separately from the request.
+func (flow packetPathFlow) response() packetPathFlow {
+	response := packetPathFlow{
+		protocol:        flow.protocol,
+		source:          flow.destination,
+		destination:     flow.source,
+		sourcePort:      flow.destinationPort,
+		destinationPort: flow.sourcePort,
+		icmpType:        flow.icmpType,
+		icmpCode:        flow.icmpCode,
+	}
+	if flow.protocol == "icmp" && flow.icmpType == 8 {
+		// echo request is answered with echo reply
+		response.icmpType = 0
+		response.icmpCode = 0
+	}
+	return response
+}
+
+func (flow packetPathFlow) String() string {
+	if flow.protocol == "icmp" {
+		return fmt.Sprintf("icmp %s -> %s type %d", flow.source, flow.destination, flow.icmpType)
+	}
+	return fmt.Sprintf("%s %s:%d -> %s:%d", flow.protocol, flow.source, flow.sourcePort, flow.destination, flow.destinationPort)
+}
+
+func resolvePacketPathEndpoint(context context.Context, sess *vpcv1.VpcV1, vpcID string, config map[string]interface{}) (*packetPathEndpoint, error) {
+	endpoint := &packetPathEndpoint{securityGroups: []string{}}
+	ip, _ := config[isPacketPathEndpointIP].(string)
+	subnetID, _ := config[isPacketPathEndpointSubnet].(string)
+	instanceID, _ := config[isPacketPathEndpointInstance].(string)
+	vniID, _ := config[isPacketPathEndpointVirtualNetworkInterface].(string)
+
+	switch {
+	case instanceID != "" && vniID != "":
+		return nil, fmt.Errorf("only one of instance or virtual_network_interface can be set")
+	case instanceID != "":
+		instance, response, err := sess.GetInstanceWithContext(context, &vpcv1.GetInstanceOptions{ID: &instanceID})
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error getting instance (%s): %s\n%s", instanceID, err, response)
+		}
+		var nic *vpcv1.NetworkInterfaceInstanceContextReference
+		for i := range instance.NetworkInterfaces {
+			candidate := &instance.NetworkInterfaces[i]
+			if (subnetID == "" && *candidate.ID == *instance.PrimaryNetworkInterface.ID) || (subnetID != "" && *candidate.Subnet.ID == subnetID) {
+				nic = candidate
+				break
+			}
+		}
+		if nic == nil {
+			return nil, fmt.Errorf("instance %s has no network interface in subnet %s", instanceID, subnetID)
+		}
+		networkInterface, response, err := sess.GetInstanceNetworkInterfaceWithContext(context, &vpcv1.GetInstanceNetworkInterfaceOptions{
+			InstanceID: &instanceID,
+			ID:         nic.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error getting instance (%s) network interface (%s): %s\n%s", instanceID, *nic.ID, err, response)
+		}
+		ip = *nic.PrimaryIP.Address
+		subnetID = *nic.Subnet.ID
+		for _, sg := range networkInterface.SecurityGroups {
+			endpoint.securityGroups = append(endpoint.securityGroups, *sg.ID)
+		}
+	case vniID != "":
+		vni, err := getPacketPathVirtualNetworkInterface(context, sess, vniID)
+		if err != nil {
+			return nil, err
+		}
+		ip = vni.PrimaryIP.Address
+		subnetID = vni.Subnet.ID
+		for _, sg := range vni.SecurityGroups {
+			endpoint.securityGroups = append(endpoint.securityGroups, sg.ID)
+		}
+	case ip == "":
+		return nil, fmt.Errorf("one of ip, instance or virtual_network_interface must be set")
+	}
+	endpoint.ip = net.ParseIP(ip).To4()
+	if endpoint.ip == nil {
+		return nil, fmt.Errorf("%q is not an IPv4 address", ip)
+	}
+
+	if subnetID != "" {
+		subnet, response, err := sess.GetSubnetWithContext(context, &vpcv1.GetSubnetOptions{ID: &subnetID})
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error getting subnet (%s): %s\n%s", subnetID, err, response)
+		}
+		endpoint.subnet = subnet
+		return endpoint, nil
+	}
+	if vpcID == "" {
+		return endpoint, nil
+	}
+
+	start := ""
+	for {
+		listSubnetsOptions := &vpcv1.ListSubnetsOptions{}
+		if start != "" {
+			listSubnetsOptions.Start = &start
+		}
+		subnets, response, err := sess.ListSubnetsWithContext(context, listSubnetsOptions)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error fetching subnets %s\n%s", err, response)
+		}
+		for i := range subnets.Subnets {
+			subnet := &subnets.Subnets[i]
+			if subnet.VPC == nil || *subnet.VPC.ID != vpcID || subnet.Ipv4CIDRBlock == nil {
+				continue
+			}
+			if _, cidr, err := net.ParseCIDR(*subnet.Ipv4CIDRBlock); err == nil && cidr.Contains(endpoint.ip) {
+				endpoint.subnet = subnet
+				return endpoint, nil
+			}
+		}
+		start = flex.GetNext(subnets.Next)
+		if start == "" {
+			break
+		}
+	}
+	log.Printf("[DEBUG] No subnet of VPC %s contains %s, the endpoint is outside the VPC", vpcID, ip)
+	return endpoint, nil
+}
+
+type packetPathVirtualNetworkInterface struct {
+	PrimaryIP struct {
+		Address string `json:"address"`
+	} `json:"primary_ip"`
+	Subnet struct {
+		ID string `json:"id"`
+	} `json:"subnet"`
+	SecurityGroups []struct {
+		ID string `json:"id"`
+	} `json:"security_groups"`
+}
+
+func getPacketPathVirtualNetworkInterface(context context.Context, sess *vpcv1.VpcV1, id string) (*packetPathVirtualNetworkInterface, error) {
+	builder := core.NewRequestBuilder(core.GET)
+	builder = builder.WithContext(context)
+	_, err := builder.ResolveRequestURL(sess.Service.Options.URL, `/virtual_network_interfaces/{id}`, map[string]string{"id": id})
+	if err != nil {
+		return nil, err
+	}
+	builder.AddHeader("Accept", "application/json")
+	builder.AddQuery("version", isPacketPathVNIVersion)
+	builder.AddQuery("generation", "2")
+	request, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	vni := &packetPathVirtualNetworkInterface{}
+	response, err := sess.Service.Request(request, vni)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting virtual network interface (%s): %s\n%s", id, err, response)
+	}
+	return vni, nil
+}
+
+type packetPathEvaluator struct {
+	context        context.Context
+	sess           *vpcv1.VpcV1
+	securityGroups map[string]*vpcv1.SecurityGroup
+	networkACLs    map[string]*vpcv1.NetworkACL
+}
+
+// evaluate follows the request from source to destination and the response
+// back. Security groups are stateful, so only the request is evaluated
+// against them. Network ACLs are stateless and only apply to traffic that
+// leaves or enters a subnet, so both directions are evaluated when the
+// endpoints are in different subnets.
+func (e *packetPathEvaluator) evaluate(source, destination *packetPathEndpoint, request, response packetPathFlow) ([]packetPathStep, error) {
+	steps := []packetPathStep{}
+	crossesSubnets := source.subnet == nil || destination.subnet == nil || *source.subnet.ID != *destination.subnet.ID
+
+	if source.subnet != nil && crossesSubnets {
+		step, err := e.route(source.subnet, request.destination)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	if len(source.securityGroups) > 0 {
+		step, err := e.securityGroupRules(source.securityGroups, "source_security_groups", "outbound", request, destination)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	if source.subnet != nil && crossesSubnets {
+		step, err := e.networkACL(source.subnet, "source_network_acl_request", "outbound", request)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	if destination.subnet != nil && crossesSubnets {
+		step, err := e.networkACL(destination.subnet, "destination_network_acl_request", "inbound", request)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	if len(destination.securityGroups) > 0 {
+		step, err := e.securityGroupRules(destination.securityGroups, "destination_security_groups", "inbound", request, source)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	if destination.subnet != nil && crossesSubnets {
+		step, err := e.networkACL(destination.subnet, "destination_network_acl_response", "outbound", response)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	if source.subnet != nil && crossesSubnets {
+		step, err := e.networkACL(source.subnet, "source_network_acl_response", "inbound", response)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// route finds the route of the routing table of the subnet that applies to
+// destination: the longest prefix in the zone of the subnet, then the lowest
+// priority value.
+func (e *packetPathEvaluator) route(subnet *vpcv1.Subnet, destination net.IP) (packetPathStep, error) {
+	step := packetPathStep{
+		name:         "source_routing_table",
+		resourceType: "routing_table",
+		resourceIDs:  []string{},
+		allowed:      true,
+	}
+	if subnet.RoutingTable == nil || subnet.VPC == nil {
+		step.detail = "the subnet has no routing table, system routing applies"
+		return step, nil
+	}
+	step.resourceIDs = append(step.resourceIDs, *subnet.RoutingTable.ID)
+
+	var match *vpcv1.Route
+	matchPrefix := -1
+	start := ""
+	for {
+		options := &vpcv1.ListVPCRoutingTableRoutesOptions{
+			VPCID:          subnet.VPC.ID,
+			RoutingTableID: subnet.RoutingTable.ID,
+		}
+		if start != "" {
+			options.Start = &start
+		}
+		routes, response, err := e.sess.ListVPCRoutingTableRoutesWithContext(e.context, options)
+		if err != nil {
+			return step, fmt.Errorf("[ERROR] Error listing routes of routing table (%s): %s\n%s", *subnet.RoutingTable.ID, err, response)
+		}
+		for i := range routes.Routes {
+			route := &routes.Routes[i]
+			if route.Zone != nil && subnet.Zone != nil && *route.Zone.Name != *subnet.Zone.Name {
+				continue
+			}
+			_, cidr, err := net.ParseCIDR(*route.Destination)
+			if err != nil || !cidr.Contains(destination) {
+				continue
+			}
+			prefix, _ := cidr.Mask.Size()
+			if prefix > matchPrefix || (prefix == matchPrefix && packetPathRoutePriority(route) < packetPathRoutePriority(match)) {
+				match = route
+				matchPrefix = prefix
+			}
+		}
+		start = flex.GetNext(routes.Next)
+		if start == "" {
+			break
+		}
+	}
+
+	if match == nil {
+		step.detail = fmt.Sprintf("no route of the routing table matches %s, system routing applies", destination)
+		return step, nil
+	}
+	step.ruleID = *match.ID
+	switch *match.Action {
+	case "drop":
+		step.allowed = false
+		step.detail = fmt.Sprintf("route %s (%s) drops traffic to %s", *match.Name, *match.Destination, destination)
+	case "deliver":
+		nextHop := ""
+		if hop, ok := match.NextHop.(*vpcv1.RouteNextHop); ok && hop.Address != nil {
+			nextHop = *hop.Address
+		}
+		step.detail = fmt.Sprintf("route %s (%s) delivers traffic to next hop %s, the next hop is expected to forward it to the destination", *match.Name, *match.Destination, nextHop)
+	default:
+		step.detail = fmt.Sprintf("route %s (%s) %s traffic to system routing", *match.Name, *match.Destination, *match.Action)
+	}
+	return step, nil
+}
+
+// packetPathRoutePriority returns the priority of the route, routes without a
+// priority have the default priority of the API.
+func packetPathRoutePriority(route *vpcv1.Route) int64 {
+	if route.Priority == nil {
+		return 2
+	}
+	return *route.Priority
+}
+
+// securityGroupRules evaluates the rules of the security groups in direction.
+// Security group rules only allow traffic, the traffic is allowed when any
+// rule of any of the security groups matches.
+func (e *packetPathEvaluator) securityGroupRules(ids []string, name, direction string, flow packetPathFlow, peer *packetPathEndpoint) (packetPathStep, error) {
+	step := packetPathStep{
+		name:         name,
+		resourceType: "security_group",
+		resourceIDs:  ids,
+		direction:    direction,
+	}
+	peerIP := flow.destination
+	if direction == "inbound" {
+		peerIP = flow.source
+	}
+	for _, id := range ids {
+		group, err := e.securityGroup(id)
+		if err != nil {
+			return step, err
+		}
+		for _, ruleIntf := range group.Rules {
+			rule, ruleID, ok := flattenSecurityGroupInlineRule(ruleIntf)
+			if !ok || rule.direction != direction || !packetPathSecurityGroupRuleMatches(rule, flow, peerIP, peer) {
+				continue
+			}
+			step.allowed = true
+			step.ruleID = ruleID
+			step.detail = fmt.Sprintf("rule %s of security group %s allows %s", ruleID, *group.Name, flow)
+			return step, nil
+		}
+	}
+	step.detail = fmt.Sprintf("no %s rule of the security groups allows %s", direction, flow)
+	return step, nil
+}
+
+func packetPathSecurityGroupRuleMatches(rule securityGroupInlineRule, flow packetPathFlow, peerIP net.IP, peer *packetPathEndpoint) bool {
+	if rule.ipVersion != "ipv4" {
+		return false
+	}
+	if rule.protocol != "all" && rule.protocol != flow.protocol {
+		return false
+	}
+	if rule.protocol == "icmp" {
+		if (rule.icmpType != -1 && rule.icmpType != flow.icmpType) || (rule.icmpCode != -1 && rule.icmpCode != flow.icmpCode) {
+			return false
+		}
+	}
+	if rule.protocol == "tcp" || rule.protocol == "udp" {
+		if flow.destinationPort < rule.portMin || flow.destinationPort > rule.portMax {
+			return false
+		}
+	}
+	if _, cidr, err := net.ParseCIDR(rule.remote); err == nil {
+		return cidr.Contains(peerIP)
+	}
+	if ip := net.ParseIP(rule.remote); ip != nil {
+		return ip.Equal(peerIP)
+	}
+	for _, sg := range peer.securityGroups {
+		if sg == rule.remote {
+			return true
+		}
+	}
+	return false
+}
+
+// networkACL evaluates the rules of the network ACL of the subnet in
+// direction. The rules are ordered, the first rule that matches decides, and
+// traffic that no rule matches is denied.
+func (e *packetPathEvaluator) networkACL(subnet *vpcv1.Subnet, name, direction string, flow packetPathFlow) (packetPathStep, error) {
+	step := packetPathStep{
+		name:         name,
+		resourceType: "network_acl",
+		resourceIDs:  []string{},
+		direction:    direction,
+	}
+	if subnet.NetworkACL == nil {
+		step.allowed = true
+		step.detail = "the subnet has no network ACL"
+		return step, nil
+	}
+	aclID := *subnet.NetworkACL.ID
+	step.resourceIDs = append(step.resourceIDs, aclID)
+	acl, ok := e.networkACLs[aclID]
+	if !ok {
+		var response *core.DetailedResponse
+		var err error
+		acl, response, err = e.sess.GetNetworkACLWithContext(e.context, &vpcv1.GetNetworkACLOptions{ID: &aclID})
+		if err != nil {
+			return step, fmt.Errorf("[ERROR] Error getting Network ACL (%s): %s\n%s", aclID, err, response)
+		}
+		e.networkACLs[aclID] = acl
+	}
+	for _, ruleIntf := range acl.Rules {
+		ruleID, action, matches := packetPathNetworkACLRuleMatches(ruleIntf, direction, flow)
+		if !matches {
+			continue
+		}
+		step.allowed = action == "allow"
+		step.ruleID = ruleID
+		verb := "allows"
+		if !step.allowed {
+			verb = "denies"
+		}
+		step.detail = fmt.Sprintf("rule %s of network ACL %s %s %s", ruleID, *acl.Name, verb, flow)
+		return step, nil
+	}
+	step.detail = fmt.Sprintf("no %s rule of network ACL %s matches %s", direction, *acl.Name, flow)
+	return step, nil
+}
+
+func packetPathNetworkACLRuleMatches(ruleIntf vpcv1.NetworkACLRuleItemIntf, direction string, flow packetPathFlow) (string, string, bool) {
+	var id, action, ruleDirection, ipVersion, protocol, source, destination string
+	switch rule := ruleIntf.(type) {
+	case *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAll:
+		id, action, ruleDirection, ipVersion, protocol, source, destination = *rule.ID, *rule.Action, *rule.Direction, *rule.IPVersion, *rule.Protocol, *rule.Source, *rule.Destination
+	case *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolIcmp:
+		id, action, ruleDirection, ipVersion, protocol, source, destination = *rule.ID, *rule.Action, *rule.Direction, *rule.IPVersion, *rule.Protocol, *rule.Source, *rule.Destination
+		if rule.Type != nil && *rule.Type != flow.icmpType {
+			return id, action, false
+		}
+		if rule.Code != nil && *rule.Code != flow.icmpCode {
+			return id, action, false
+		}
+	case *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolTcpudp:
+		id, action, ruleDirection, ipVersion, protocol, source, destination = *rule.ID, *rule.Action, *rule.Direction, *rule.IPVersion, *rule.Protocol, *rule.Source, *rule.Destination
+		if flow.sourcePort < *rule.SourcePortMin || flow.sourcePort > *rule.SourcePortMax {
+			return id, action, false
+		}
+		if flow.destinationPort < *rule.DestinationPortMin || flow.destinationPort > *rule.DestinationPortMax {
+			return id, action, false
+		}
+	default:
+		return "", "", false
+	}
+	if ruleDirection != direction || strings.ToLower(ipVersion) != "ipv4" {
+		return id, action, false
+	}
+	if protocol != "all" && protocol != flow.protocol {
+		return id, action, false
+	}
+	if _, cidr, err := net.ParseCIDR(source); err != nil || !cidr.Contains(flow.source) {
+		return id, action, false
+	}
+	if _, cidr, err := net.ParseCIDR(destination); err != nil || !cidr.Contains(flow.destination) {
+		return id, action, false
+	}
+	return id, action, true
+}
+
+func (e *packetPathEvaluator) securityGroup(id string) (*vpcv1.SecurityGroup, error) {
+	if group, ok := e.securityGroups[id]; ok {
+		return group, nil
+	}
+	group, response, err := e.sess.GetSecurityGroupWithContext(e.context, &vpcv1.GetSecurityGroupOptions{ID: &id})
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error getting Security Group (%s): %s\n%s", id, err, response)
+	}
+	e.securityGroups[id] = group
+	return group, nil
+}
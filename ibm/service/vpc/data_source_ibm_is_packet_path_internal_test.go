@@ -0,0 +1,201 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+)
+
+func TestPacketPathSecurityGroupRuleMatches(t *testing.T) {
+	tcp := packetPathFlow{protocol: "tcp", source: net.ParseIP("10.240.0.4"), destination: net.ParseIP("10.240.64.5"), sourcePort: 40000, destinationPort: 443}
+	ping := packetPathFlow{protocol: "icmp", source: net.ParseIP("10.240.0.4"), destination: net.ParseIP("10.240.64.5"), icmpType: 8}
+	peer := &packetPathEndpoint{ip: net.ParseIP("10.240.0.4"), securityGroups: []string{"sg-web"}}
+
+	testCases := []struct {
+		name    string
+		rule    securityGroupInlineRule
+		flow    packetPathFlow
+		matches bool
+	}{
+		{name: "cidr", rule: securityGroupInlineRule{ipVersion: "ipv4", protocol: "tcp", remote: "10.240.0.0/24", portMin: 443, portMax: 443}, flow: tcp, matches: true},
+		{name: "other cidr", rule: securityGroupInlineRule{ipVersion: "ipv4", protocol: "tcp", remote: "10.240.1.0/24", portMin: 443, portMax: 443}, flow: tcp},
+		{name: "address", rule: securityGroupInlineRule{ipVersion: "ipv4", protocol: "all", remote: "10.240.0.4"}, flow: tcp, matches: true},
+		{name: "security group", rule: securityGroupInlineRule{ipVersion: "ipv4", protocol: "all", remote: "sg-web"}, flow: tcp, matches: true},
+		{name: "other security group", rule: securityGroupInlineRule{ipVersion: "ipv4", protocol: "all", remote: "sg-db"}, flow: tcp},
+		{name: "port range", rule: securityGroupInlineRule{ipVersion: "ipv4", protocol: "tcp", remote: "0.0.0.0/0", portMin: 80, portMax: 8080}, flow: tcp, matches: true},
+		{name: "other port", rule: securityGroupInlineRule{ipVersion: "ipv4", protocol: "tcp", remote: "0.0.0.0/0", portMin: 22, portMax: 22}, flow: tcp},
+		{name: "other protocol", rule: securityGroupInlineRule{ipVersion: "ipv4", protocol: "udp", remote: "0.0.0.0/0", portMin: 1, portMax: 65535}, flow: tcp},
+		{name: "any icmp", rule: securityGroupInlineRule{ipVersion: "ipv4", protocol: "icmp", remote: "0.0.0.0/0", icmpType: -1, icmpCode: -1}, flow: ping, matches: true},
+		{name: "icmp type", rule: securityGroupInlineRule{ipVersion: "ipv4", protocol: "icmp", remote: "0.0.0.0/0", icmpType: 8, icmpCode: -1}, flow: ping, matches: true},
+		{name: "other icmp type", rule: securityGroupInlineRule{ipVersion: "ipv4", protocol: "icmp", remote: "0.0.0.0/0", icmpType: 3, icmpCode: -1}, flow: ping},
+		{name: "ipv6", rule: securityGroupInlineRule{ipVersion: "ipv6", protocol: "all", remote: "::/0"}, flow: tcp},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if matches := packetPathSecurityGroupRuleMatches(tc.rule, tc.flow, tc.flow.source, peer); matches != tc.matches {
+				t.Fatalf("got %t, want %t", matches, tc.matches)
+			}
+		})
+	}
+}
+
+func TestPacketPathNetworkACLRuleMatches(t *testing.T) {
+	tcp := packetPathFlow{protocol: "tcp", source: net.ParseIP("10.240.0.4"), destination: net.ParseIP("10.240.64.5"), sourcePort: 40000, destinationPort: 443}
+	ping := packetPathFlow{protocol: "icmp", source: net.ParseIP("10.240.0.4"), destination: net.ParseIP("10.240.64.5"), icmpType: 8}
+	all := func(id, action, direction, source, destination string) vpcv1.NetworkACLRuleItemIntf {
+		return &vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAll{
+			ID: core.StringPtr(id), Action: core.StringPtr(action), Direction: core.StringPtr(direction), IPVersion: core.StringPtr("ipv4"),
+			Protocol: core.StringPtr("all"), Source: core.StringPtr(source), Destination: core.StringPtr(destination),
+		}
+	}
+	tcpudp := func(id, protocol string, sourcePortMin, sourcePortMax, destinationPortMin, destinationPortMax int64) vpcv1.NetworkACLRuleItemIntf {
+		return &vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolTcpudp{
+			ID: core.StringPtr(id), Action: core.StringPtr("allow"), Direction: core.StringPtr("outbound"), IPVersion: core.StringPtr("ipv4"),
+			Protocol: core.StringPtr(protocol), Source: core.StringPtr("0.0.0.0/0"), Destination: core.StringPtr("0.0.0.0/0"),
+			SourcePortMin: core.Int64Ptr(sourcePortMin), SourcePortMax: core.Int64Ptr(sourcePortMax),
+			DestinationPortMin: core.Int64Ptr(destinationPortMin), DestinationPortMax: core.Int64Ptr(destinationPortMax),
+		}
+	}
+	icmp := func(id string, icmpType *int64) vpcv1.NetworkACLRuleItemIntf {
+		return &vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolIcmp{
+			ID: core.StringPtr(id), Action: core.StringPtr("allow"), Direction: core.StringPtr("outbound"), IPVersion: core.StringPtr("ipv4"),
+			Protocol: core.StringPtr("icmp"), Source: core.StringPtr("0.0.0.0/0"), Destination: core.StringPtr("0.0.0.0/0"), Type: icmpType,
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		rule    vpcv1.NetworkACLRuleItemIntf
+		flow    packetPathFlow
+		action  string
+		matches bool
+	}{
+		{name: "all", rule: all("r1", "allow", "outbound", "10.240.0.0/24", "10.240.64.0/24"), flow: tcp, action: "allow", matches: true},
+		{name: "deny", rule: all("r1", "deny", "outbound", "0.0.0.0/0", "0.0.0.0/0"), flow: tcp, action: "deny", matches: true},
+		{name: "other direction", rule: all("r1", "allow", "inbound", "0.0.0.0/0", "0.0.0.0/0"), flow: tcp, action: "allow"},
+		{name: "other source", rule: all("r1", "allow", "outbound", "10.240.1.0/24", "0.0.0.0/0"), flow: tcp, action: "allow"},
+		{name: "other destination", rule: all("r1", "allow", "outbound", "0.0.0.0/0", "10.240.65.0/24"), flow: tcp, action: "allow"},
+		{name: "ports", rule: tcpudp("r1", "tcp", 1024, 65535, 443, 443), flow: tcp, action: "allow", matches: true},
+		{name: "other source port", rule: tcpudp("r1", "tcp", 1, 1023, 443, 443), flow: tcp, action: "allow"},
+		{name: "other destination port", rule: tcpudp("r1", "tcp", 1, 65535, 80, 80), flow: tcp, action: "allow"},
+		{name: "other protocol", rule: tcpudp("r1", "udp", 1, 65535, 1, 65535), flow: tcp, action: "allow"},
+		{name: "any icmp", rule: icmp("r1", nil), flow: ping, action: "allow", matches: true},
+		{name: "icmp type", rule: icmp("r1", core.Int64Ptr(8)), flow: ping, action: "allow", matches: true},
+		{name: "other icmp type", rule: icmp("r1", core.Int64Ptr(0)), flow: ping, action: "allow"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			id, action, matches := packetPathNetworkACLRuleMatches(tc.rule, "outbound", tc.flow)
+			if id != "r1" || action != tc.action || matches != tc.matches {
+				t.Fatalf("got (%q, %q, %t), want (\"r1\", %q, %t)", id, action, matches, tc.action, tc.matches)
+			}
+		})
+	}
+}
+
+func TestPacketPathEvaluate(t *testing.T) {
+	// The outbound rule of the source security group allows the request and
+	// its response, as security groups are stateful. The network ACL of the
+	// source subnet allows the request out but denies the response in, as
+	// network ACLs are stateless.
+	securityGroup := func(id, direction string) *vpcv1.SecurityGroup {
+		return &vpcv1.SecurityGroup{
+			ID:   core.StringPtr(id),
+			Name: core.StringPtr(id),
+			Rules: []vpcv1.SecurityGroupRuleIntf{
+				&vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll{
+					ID: core.StringPtr(id + "-rule"), Direction: core.StringPtr(direction), IPVersion: core.StringPtr("ipv4"), Protocol: core.StringPtr("all"),
+				},
+			},
+		}
+	}
+	networkACL := func(id string, inbound string) *vpcv1.NetworkACL {
+		rule := func(ruleID, action, direction string) vpcv1.NetworkACLRuleItemIntf {
+			return &vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAll{
+				ID: core.StringPtr(ruleID), Action: core.StringPtr(action), Direction: core.StringPtr(direction), IPVersion: core.StringPtr("ipv4"),
+				Protocol: core.StringPtr("all"), Source: core.StringPtr("0.0.0.0/0"), Destination: core.StringPtr("0.0.0.0/0"),
+			}
+		}
+		return &vpcv1.NetworkACL{
+			ID:    core.StringPtr(id),
+			Name:  core.StringPtr(id),
+			Rules: []vpcv1.NetworkACLRuleItemIntf{rule(id+"-out", "allow", "outbound"), rule(id+"-in", inbound, "inbound")},
+		}
+	}
+	subnet := func(id, aclID string) *vpcv1.Subnet {
+		return &vpcv1.Subnet{ID: core.StringPtr(id), NetworkACL: &vpcv1.NetworkACLReference{ID: core.StringPtr(aclID)}}
+	}
+	e := &packetPathEvaluator{
+		securityGroups: map[string]*vpcv1.SecurityGroup{
+			"sg-source":      securityGroup("sg-source", "outbound"),
+			"sg-destination": securityGroup("sg-destination", "inbound"),
+		},
+		networkACLs: map[string]*vpcv1.NetworkACL{
+			"acl-source":      networkACL("acl-source", "deny"),
+			"acl-destination": networkACL("acl-destination", "allow"),
+		},
+	}
+	source := &packetPathEndpoint{ip: net.ParseIP("10.240.0.4"), subnet: subnet("subnet-source", "acl-source"), securityGroups: []string{"sg-source"}}
+	destination := &packetPathEndpoint{ip: net.ParseIP("10.240.64.5"), subnet: subnet("subnet-destination", "acl-destination"), securityGroups: []string{"sg-destination"}}
+	request := packetPathFlow{protocol: "tcp", source: source.ip, destination: destination.ip, sourcePort: 40000, destinationPort: 443}
+
+	steps, err := e.evaluate(source, destination, request, request.response())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := map[string]string{}
+	names := []string{}
+	for _, step := range steps {
+		names = append(names, step.name)
+		got[step.name] = step.ruleID
+		if step.allowed != (step.name != "source_network_acl_response") {
+			t.Errorf("got step %s allowed %t: %s", step.name, step.allowed, step.detail)
+		}
+	}
+	want := []string{
+		"source_routing_table",
+		"source_security_groups",
+		"source_network_acl_request",
+		"destination_network_acl_request",
+		"destination_security_groups",
+		"destination_network_acl_response",
+		"source_network_acl_response",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("got steps %v, want %v", names, want)
+	}
+	if got["source_network_acl_response"] != "acl-source-in" || got["destination_network_acl_response"] != "acl-destination-out" {
+		t.Fatalf("got rules %v, want the response evaluated against the opposite direction of the network ACLs", got)
+	}
+
+	// Within one subnet, neither the routing table nor the network ACL apply.
+	destination.subnet = source.subnet
+	steps, err = e.evaluate(source, destination, request, request.response())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	names = []string{}
+	for _, step := range steps {
+		names = append(names, step.name)
+	}
+	if !reflect.DeepEqual(names, []string{"source_security_groups", "destination_security_groups"}) {
+		t.Fatalf("got steps %v, want only the security groups", names)
+	}
+}
+
+func TestPacketPathRoutePriority(t *testing.T) {
+	if priority := packetPathRoutePriority(&vpcv1.Route{}); priority != 2 {
+		t.Fatalf("got priority %d for a route without priority, want 2", priority)
+	}
+	if priority := packetPathRoutePriority(&vpcv1.Route{Priority: core.Int64Ptr(0)}); priority != 0 {
+		t.Fatalf("got priority %d, want 0", priority)
+	}
+}
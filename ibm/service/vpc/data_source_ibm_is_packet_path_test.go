@@ -0,0 +1,118 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMISPacketPathDataSource_basic(t *testing.T) {
+	vpcname := fmt.Sprintf("tf-path-vpc-%d", acctest.RandIntRange(10, 100))
+	subnetname := fmt.Sprintf("tf-path-subnet-%d", acctest.RandIntRange(10, 100))
+	aclname := fmt.Sprintf("tf-path-acl-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISPacketPathDataSourceConfig(vpcname, subnetname, aclname),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ibm_is_packet_path.allowed", "allowed", "true"),
+					resource.TestCheckResourceAttr("data.ibm_is_packet_path.allowed", "steps.#", "5"),
+					resource.TestCheckResourceAttr("data.ibm_is_packet_path.allowed", "steps.0.name", "source_routing_table"),
+					resource.TestCheckResourceAttr("data.ibm_is_packet_path.denied", "allowed", "false"),
+					resource.TestCheckResourceAttr("data.ibm_is_packet_path.denied", "steps.2.name", "destination_network_acl_request"),
+					resource.TestCheckResourceAttr("data.ibm_is_packet_path.denied", "steps.2.allowed", "false"),
+					resource.TestCheckResourceAttr("data.ibm_is_packet_path.denied", "deciding_rule_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMISPacketPathDataSourceConfig(vpcname, subnetname, aclname string) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "testacc_vpc" {
+		name = "%[1]s"
+	}
+
+	resource "ibm_is_network_acl" "testacc_acl" {
+		name = "%[3]s"
+		vpc  = ibm_is_vpc.testacc_vpc.id
+		rules {
+			name        = "deny-ssh"
+			action      = "deny"
+			source      = "0.0.0.0/0"
+			destination = "0.0.0.0/0"
+			direction   = "inbound"
+			tcp {
+				port_min = 22
+				port_max = 22
+			}
+		}
+		rules {
+			name        = "allow-inbound"
+			action      = "allow"
+			source      = "0.0.0.0/0"
+			destination = "0.0.0.0/0"
+			direction   = "inbound"
+		}
+		rules {
+			name        = "allow-outbound"
+			action      = "allow"
+			source      = "0.0.0.0/0"
+			destination = "0.0.0.0/0"
+			direction   = "outbound"
+		}
+	}
+
+	resource "ibm_is_subnet" "testacc_subnet_app" {
+		name                     = "%[2]s-app"
+		vpc                      = ibm_is_vpc.testacc_vpc.id
+		zone                     = "%[4]s"
+		total_ipv4_address_count = 16
+	}
+
+	resource "ibm_is_subnet" "testacc_subnet_db" {
+		name                     = "%[2]s-db"
+		vpc                      = ibm_is_vpc.testacc_vpc.id
+		zone                     = "%[4]s"
+		total_ipv4_address_count = 16
+		network_acl              = ibm_is_network_acl.testacc_acl.id
+	}
+
+	data "ibm_is_packet_path" "allowed" {
+		source {
+			ip     = cidrhost(ibm_is_subnet.testacc_subnet_app.ipv4_cidr_block, 4)
+			subnet = ibm_is_subnet.testacc_subnet_app.id
+		}
+		destination {
+			ip = cidrhost(ibm_is_subnet.testacc_subnet_db.ipv4_cidr_block, 4)
+		}
+		vpc      = ibm_is_vpc.testacc_vpc.id
+		protocol = "tcp"
+		port     = 5432
+	}
+
+	data "ibm_is_packet_path" "denied" {
+		source {
+			ip     = cidrhost(ibm_is_subnet.testacc_subnet_app.ipv4_cidr_block, 4)
+			subnet = ibm_is_subnet.testacc_subnet_app.id
+		}
+		destination {
+			ip     = cidrhost(ibm_is_subnet.testacc_subnet_db.ipv4_cidr_block, 4)
+			subnet = ibm_is_subnet.testacc_subnet_db.id
+		}
+		protocol = "tcp"
+		port     = 22
+	}
+	`, vpcname, subnetname, aclname, acc.ISZoneName)
+}
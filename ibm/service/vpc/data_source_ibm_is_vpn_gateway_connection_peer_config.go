@@ -0,0 +1,812 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	isVPNPeerConfigVendorStrongSwan = "strongswan"
+	isVPNPeerConfigVendorLibreswan  = "libreswan"
+	isVPNPeerConfigVendorCiscoASA   = "cisco_asa"
+	isVPNPeerConfigVendorJuniperSRX = "juniper_srx"
+	isVPNPeerConfigVendorPaloAlto   = "palo_alto"
+	isVPNPeerConfigVendorVyOS       = "vyos"
+
+	isVPNPeerConfigDisabled = "disabled"
+)
+
+// vpnPeerConfigDefaults are the policies that the peer configuration uses when
+// the connection has no IKE or IPsec policy, and the VPN gateway negotiates
+// the policies with the peer.
+var vpnPeerConfigDefaults = struct {
+	ikeVersion, dhGroup, ikeLifetime, espLifetime int64
+	ikeEncryption, ikeIntegrity                   string
+	espEncryption, espIntegrity, pfs              string
+}{
+	ikeVersion:    2,
+	ikeEncryption: "aes256",
+	ikeIntegrity:  "sha256",
+	dhGroup:       14,
+	ikeLifetime:   28800,
+	espEncryption: "aes256",
+	espIntegrity:  "sha256",
+	pfs:           "group_14",
+	espLifetime:   3600,
+}
+
+// vpnPeerVendor maps the IKE and IPsec policy values of the connection to the
+// values of a vendor, a value that is missing is not supported by the vendor.
+type vpnPeerVendor struct {
+	template      string
+	iface         string
+	ikeEncryption map[string]string
+	ikeIntegrity  map[string]string
+	espEncryption map[string]string
+	espIntegrity  map[string]string
+	dhGroups      map[int64]string
+	// check reports combinations of supported values that are not supported
+	check func(c *vpnPeerConfig) error
+}
+
+var strongSwanDHGroups = map[int64]string{
+	2: "modp1024", 5: "modp1536", 14: "modp2048", 15: "modp3072", 16: "modp4096", 17: "modp6144", 18: "modp8192",
+	19: "ecp256", 20: "ecp384", 21: "ecp521", 22: "modp1024s160", 23: "modp2048s224", 24: "modp2048s256", 31: "curve25519",
+}
+
+var vpnPeerVendors = map[string]*vpnPeerVendor{
+	isVPNPeerConfigVendorStrongSwan: {
+		template:      vpnPeerConfigStrongSwanTemplate,
+		ikeEncryption: map[string]string{"aes128": "aes128", "aes192": "aes192", "aes256": "aes256", "triple_des": "3des"},
+		ikeIntegrity:  map[string]string{"md5": "md5", "sha1": "sha1", "sha256": "sha256", "sha384": "sha384", "sha512": "sha512"},
+		espEncryption: map[string]string{"aes128": "aes128", "aes192": "aes192", "aes256": "aes256", "triple_des": "3des", "aes128gcm16": "aes128gcm16", "aes192gcm16": "aes192gcm16", "aes256gcm16": "aes256gcm16"},
+		espIntegrity:  map[string]string{"md5": "md5", "sha1": "sha1", "sha256": "sha256", "sha384": "sha384", "sha512": "sha512"},
+		dhGroups:      strongSwanDHGroups,
+	},
+	isVPNPeerConfigVendorLibreswan: {
+		template:      vpnPeerConfigLibreswanTemplate,
+		ikeEncryption: map[string]string{"aes128": "aes128", "aes192": "aes192", "aes256": "aes256", "triple_des": "3des"},
+		ikeIntegrity:  map[string]string{"sha1": "sha1", "sha256": "sha2_256", "sha384": "sha2_384", "sha512": "sha2_512"},
+		espEncryption: map[string]string{"aes128": "aes128", "aes192": "aes192", "aes256": "aes256", "triple_des": "3des", "aes128gcm16": "aes_gcm128", "aes192gcm16": "aes_gcm192", "aes256gcm16": "aes_gcm256"},
+		espIntegrity:  map[string]string{"sha1": "sha1", "sha256": "sha2_256", "sha384": "sha2_384", "sha512": "sha2_512"},
+		dhGroups: map[int64]string{
+			5: "modp1536", 14: "modp2048", 15: "modp3072", 16: "modp4096", 17: "modp6144", 18: "modp8192",
+			19: "dh19", 20: "dh20", 21: "dh21", 31: "dh31",
+		},
+	},
+	isVPNPeerConfigVendorCiscoASA: {
+		template:      vpnPeerConfigCiscoASATemplate,
+		iface:         "outside",
+		ikeEncryption: map[string]string{"aes128": "aes", "aes192": "aes-192", "aes256": "aes-256", "triple_des": "3des"},
+		ikeIntegrity:  map[string]string{"md5": "md5", "sha1": "sha", "sha256": "sha256", "sha384": "sha384", "sha512": "sha512"},
+		espEncryption: map[string]string{"aes128": "aes", "aes192": "aes-192", "aes256": "aes-256", "triple_des": "3des", "aes128gcm16": "aes-gcm", "aes192gcm16": "aes-gcm-192", "aes256gcm16": "aes-gcm-256"},
+		espIntegrity:  map[string]string{"md5": "md5", "sha1": "sha", "sha256": "sha256", "sha384": "sha384", "sha512": "sha512"},
+		dhGroups:      map[int64]string{14: "14", 15: "15", 16: "16", 19: "19", 20: "20", 21: "21", 31: "31"},
+		check: func(c *vpnPeerConfig) error {
+			if c.IKEVersion != 1 {
+				return nil
+			}
+			if c.IKE.Integrity != "md5" && c.IKE.Integrity != "sha" {
+				return fmt.Errorf("IKEv1 policies only support the md5 and sha1 authentication algorithms")
+			}
+			if c.IKE.DHGroup != "14" && c.IKE.DHGroup != "15" && c.IKE.DHGroup != "16" {
+				return fmt.Errorf("IKEv1 policies only support the Diffie-Hellman groups 14, 15 and 16")
+			}
+			if c.ESP.Integrity == "" {
+				return fmt.Errorf("IKEv1 transform sets do not support AES-GCM encryption")
+			}
+			return nil
+		},
+	},
+	isVPNPeerConfigVendorJuniperSRX: {
+		template:      vpnPeerConfigJuniperSRXTemplate,
+		iface:         "ge-0/0/0.0",
+		ikeEncryption: map[string]string{"aes128": "aes-128-cbc", "aes192": "aes-192-cbc", "aes256": "aes-256-cbc", "triple_des": "3des-cbc"},
+		ikeIntegrity:  map[string]string{"md5": "md5", "sha1": "sha1", "sha256": "sha-256", "sha384": "sha-384"},
+		espEncryption: map[string]string{"aes128": "aes-128-cbc", "aes192": "aes-192-cbc", "aes256": "aes-256-cbc", "triple_des": "3des-cbc", "aes128gcm16": "aes-128-gcm", "aes192gcm16": "aes-192-gcm", "aes256gcm16": "aes-256-gcm"},
+		espIntegrity:  map[string]string{"md5": "hmac-md5-96", "sha1": "hmac-sha1-96", "sha256": "hmac-sha-256-128", "sha384": "hmac-sha-384", "sha512": "hmac-sha-512"},
+		dhGroups:      map[int64]string{2: "group2", 5: "group5", 14: "group14", 15: "group15", 16: "group16", 19: "group19", 20: "group20", 21: "group21", 24: "group24"},
+	},
+	isVPNPeerConfigVendorPaloAlto: {
+		template:      vpnPeerConfigPaloAltoTemplate,
+		iface:         "ethernet1/1",
+		ikeEncryption: map[string]string{"aes128": "aes-128-cbc", "aes192": "aes-192-cbc", "aes256": "aes-256-cbc", "triple_des": "3des"},
+		ikeIntegrity:  map[string]string{"md5": "md5", "sha1": "sha1", "sha256": "sha256", "sha384": "sha384", "sha512": "sha512"},
+		espEncryption: map[string]string{"aes128": "aes-128-cbc", "aes192": "aes-192-cbc", "aes256": "aes-256-cbc", "triple_des": "3des", "aes128gcm16": "aes-128-gcm", "aes256gcm16": "aes-256-gcm"},
+		espIntegrity:  map[string]string{"md5": "md5", "sha1": "sha1", "sha256": "sha256", "sha384": "sha384", "sha512": "sha512"},
+		dhGroups:      map[int64]string{2: "group2", 5: "group5", 14: "group14", 15: "group15", 16: "group16", 19: "group19", 20: "group20", 21: "group21"},
+	},
+	isVPNPeerConfigVendorVyOS: {
+		template:      vpnPeerConfigVyOSTemplate,
+		iface:         "eth0",
+		ikeEncryption: map[string]string{"aes128": "aes128", "aes192": "aes192", "aes256": "aes256", "triple_des": "3des"},
+		ikeIntegrity:  map[string]string{"md5": "md5", "sha1": "sha1", "sha256": "sha256", "sha384": "sha384", "sha512": "sha512"},
+		espEncryption: map[string]string{"aes128": "aes128", "aes192": "aes192", "aes256": "aes256", "triple_des": "3des", "aes128gcm16": "aes128gcm128", "aes192gcm16": "aes192gcm128", "aes256gcm16": "aes256gcm128"},
+		espIntegrity:  map[string]string{"md5": "md5", "sha1": "sha1", "sha256": "sha256", "sha384": "sha384", "sha512": "sha512"},
+		dhGroups:      map[int64]string{2: "2", 5: "5", 14: "14", 15: "15", 16: "16", 17: "17", 18: "18", 19: "19", 20: "20", 21: "21", 22: "22", 23: "23", 24: "24", 31: "31"},
+	},
+}
+
+// vpnPeerConfig is the configuration of the peer, the local side is the peer
+// and the remote side is the VPN gateway.
+type vpnPeerConfig struct {
+	Name        string
+	Mode        string
+	IKEVersion  int64
+	PeerAddress string
+	Interface   string
+	PSK         string
+	Tunnels     []vpnPeerTunnel
+	LocalCIDRs  []string
+	RemoteCIDRs []string
+	IKE         vpnPeerProposal
+	ESP         vpnPeerProposal
+	DPD         vpnPeerDPD
+}
+
+type vpnPeerTunnel struct {
+	Index          int
+	GatewayAddress string
+}
+
+type vpnPeerProposal struct {
+	Encryption string
+	// Integrity is empty for AES-GCM encryption
+	Integrity string
+	// DHGroup is empty when PFS is disabled
+	DHGroup  string
+	Lifetime int64
+}
+
+type vpnPeerDPD struct {
+	// Action is empty when dead peer detection is disabled
+	Action   string
+	Interval int64
+	Timeout  int64
+}
+
+func (c *vpnPeerConfig) PolicyMode() bool {
+	return c.Mode == "policy"
+}
+
+// DPDThreshold returns the number of missed dead peer detection intervals
+// after which the VPN gateway is considered dead.
+func (c *vpnPeerConfig) DPDThreshold() int64 {
+	if c.DPD.Interval == 0 || c.DPD.Timeout < c.DPD.Interval {
+		return 1
+	}
+	return c.DPD.Timeout / c.DPD.Interval
+}
+
+// CIDRPairs returns the pairs of local and remote CIDRs.
+func (c *vpnPeerConfig) CIDRPairs() [][2]string {
+	pairs := [][2]string{}
+	for _, local := range c.LocalCIDRs {
+		for _, remote := range c.RemoteCIDRs {
+			pairs = append(pairs, [2]string{local, remote})
+		}
+	}
+	return pairs
+}
+
+func DataSourceIBMISVPNGatewayConnectionPeerConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMIsVPNGatewayConnectionPeerConfigRead,
+
+		Schema: map[string]*schema.Schema{
+			"vpn_gateway": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The VPN gateway identifier.",
+			},
+			"vpn_gateway_connection": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The VPN gateway connection identifier.",
+			},
+			"vendor": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{
+					isVPNPeerConfigVendorStrongSwan, isVPNPeerConfigVendorLibreswan, isVPNPeerConfigVendorCiscoASA,
+					isVPNPeerConfigVendorJuniperSRX, isVPNPeerConfigVendorPaloAlto, isVPNPeerConfigVendorVyOS,
+				}),
+				Description: "The vendor of the peer, strongswan, libreswan, cisco_asa, juniper_srx, palo_alto or vyos.",
+			},
+			"psk_reference": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "<PRESHARED_KEY>",
+				Description: "The value that is rendered in place of the preshared key, such as a reference to a secret. The preshared key of the connection is not rendered.",
+			},
+			"interface": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The external interface of the peer, defaults to outside for cisco_asa, ge-0/0/0.0 for juniper_srx, ethernet1/1 for palo_alto and eth0 for vyos.",
+			},
+			"rendered": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The configuration of the peer.",
+			},
+			"mode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The mode of the connection, policy or route.",
+			},
+			"ike_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The IKE protocol version.",
+			},
+			"gateway_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The public IP addresses of the VPN gateway that the peer connects to.",
+			},
+		},
+	}
+}
+
+func dataSourceIBMIsVPNGatewayConnectionPeerConfigRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	gatewayID := d.Get("vpn_gateway").(string)
+	connectionID := d.Get("vpn_gateway_connection").(string)
+	getVPNGatewayConnectionOptions := &vpcv1.GetVPNGatewayConnectionOptions{
+		VPNGatewayID: &gatewayID,
+		ID:           &connectionID,
+	}
+	connectionIntf, response, err := sess.GetVPNGatewayConnectionWithContext(context, getVPNGatewayConnectionOptions)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error Getting Vpn Gateway Connection (%s): %s\n%s", connectionID, err, response))
+	}
+	connection := connectionIntf.(*vpcv1.VPNGatewayConnection)
+
+	getVPNGatewayOptions := &vpcv1.GetVPNGatewayOptions{
+		ID: &gatewayID,
+	}
+	gatewayIntf, response, err := sess.GetVPNGatewayWithContext(context, getVPNGatewayOptions)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("[ERROR] Error Getting Vpn Gateway (%s): %s\n%s", gatewayID, err, response))
+	}
+	gateway := gatewayIntf.(*vpcv1.VPNGateway)
+
+	policies := vpnPeerPolicies{
+		ikeVersion:    vpnPeerConfigDefaults.ikeVersion,
+		ikeEncryption: vpnPeerConfigDefaults.ikeEncryption,
+		ikeIntegrity:  vpnPeerConfigDefaults.ikeIntegrity,
+		dhGroup:       vpnPeerConfigDefaults.dhGroup,
+		ikeLifetime:   vpnPeerConfigDefaults.ikeLifetime,
+		espEncryption: vpnPeerConfigDefaults.espEncryption,
+		espIntegrity:  vpnPeerConfigDefaults.espIntegrity,
+		pfs:           vpnPeerConfigDefaults.pfs,
+		espLifetime:   vpnPeerConfigDefaults.espLifetime,
+	}
+	if connection.IkePolicy != nil {
+		ikePolicy, response, err := sess.GetIkePolicyWithContext(context, &vpcv1.GetIkePolicyOptions{ID: connection.IkePolicy.ID})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error getting IKE Policy (%s): %s\n%s", *connection.IkePolicy.ID, err, response))
+		}
+		policies.ikeVersion = *ikePolicy.IkeVersion
+		policies.ikeEncryption = *ikePolicy.EncryptionAlgorithm
+		policies.ikeIntegrity = *ikePolicy.AuthenticationAlgorithm
+		policies.dhGroup = *ikePolicy.DhGroup
+		policies.ikeLifetime = *ikePolicy.KeyLifetime
+	}
+	if connection.IpsecPolicy != nil {
+		ipsecPolicy, response, err := sess.GetIpsecPolicyWithContext(context, &vpcv1.GetIpsecPolicyOptions{ID: connection.IpsecPolicy.ID})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] Error getting IPSEC Policy (%s): %s\n%s", *connection.IpsecPolicy.ID, err, response))
+		}
+		policies.espEncryption = *ipsecPolicy.EncryptionAlgorithm
+		policies.espIntegrity = *ipsecPolicy.AuthenticationAlgorithm
+		policies.pfs = *ipsecPolicy.Pfs
+		policies.espLifetime = *ipsecPolicy.KeyLifetime
+	}
+
+	config := &vpnPeerConfig{
+		Name:        vpnPeerConfigName(*connection.Name),
+		Mode:        *connection.Mode,
+		IKEVersion:  policies.ikeVersion,
+		PeerAddress: *connection.PeerAddress,
+		PSK:         d.Get("psk_reference").(string),
+		LocalCIDRs:  connection.PeerCIDRs,
+		RemoteCIDRs: connection.LocalCIDRs,
+	}
+	if connection.DeadPeerDetection != nil && *connection.DeadPeerDetection.Action != "none" {
+		config.DPD = vpnPeerDPD{
+			Action:   *connection.DeadPeerDetection.Action,
+			Interval: *connection.DeadPeerDetection.Interval,
+			Timeout:  *connection.DeadPeerDetection.Timeout,
+		}
+	}
+	if config.PolicyMode() {
+		// the peer connects to the active member of a policy mode gateway
+		for _, member := range gateway.Members {
+			if member.PublicIP != nil && member.Role != nil && *member.Role == "active" {
+				config.Tunnels = append(config.Tunnels, vpnPeerTunnel{Index: 1, GatewayAddress: *member.PublicIP.Address})
+			}
+		}
+	} else {
+		for i, tunnel := range connection.Tunnels {
+			if tunnel.PublicIP != nil {
+				config.Tunnels = append(config.Tunnels, vpnPeerTunnel{Index: i + 1, GatewayAddress: *tunnel.PublicIP.Address})
+			}
+		}
+	}
+	if len(config.Tunnels) == 0 {
+		return diag.FromErr(fmt.Errorf("[ERROR] The VPN gateway (%s) has no public IP address for the connection (%s), wait for the VPN gateway to be available", gatewayID, connectionID))
+	}
+
+	vendorName := d.Get("vendor").(string)
+	rendered, err := renderVPNPeerConfig(vendorName, d.Get("interface").(string), config, policies)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	gatewayAddresses := make([]string, 0, len(config.Tunnels))
+	for _, tunnel := range config.Tunnels {
+		gatewayAddresses = append(gatewayAddresses, tunnel.GatewayAddress)
+	}
+	checksum := sha256.Sum256([]byte(rendered))
+	d.SetId(fmt.Sprintf("%s/%s/%s", gatewayID, connectionID, hex.EncodeToString(checksum[:8])))
+	d.Set("rendered", rendered)
+	d.Set("mode", config.Mode)
+	d.Set("ike_version", config.IKEVersion)
+	d.Set("gateway_addresses", gatewayAddresses)
+	return nil
+}
+
+// vpnPeerPolicies are the IKE and IPsec policy values of the connection.
+type vpnPeerPolicies struct {
+	ikeVersion, dhGroup, ikeLifetime, espLifetime int64
+	ikeEncryption, ikeIntegrity                   string
+	espEncryption, espIntegrity, pfs              string
+}
+
+// renderVPNPeerConfig maps the policies to the values of the vendor, and
+// renders the configuration of the vendor.
+func renderVPNPeerConfig(vendorName, iface string, config *vpnPeerConfig, policies vpnPeerPolicies) (string, error) {
+	vendor := vpnPeerVendors[vendorName]
+	unsupported := func(setting string, value interface{}) error {
+		return fmt.Errorf("[ERROR] %s does not support the %s %v of the connection", vendorName, setting, value)
+	}
+
+	var ok bool
+	if config.IKE.Encryption, ok = vendor.ikeEncryption[policies.ikeEncryption]; !ok {
+		return "", unsupported("IKE encryption algorithm", policies.ikeEncryption)
+	}
+	if config.IKE.Integrity, ok = vendor.ikeIntegrity[policies.ikeIntegrity]; !ok {
+		return "", unsupported("IKE authentication algorithm", policies.ikeIntegrity)
+	}
+	if config.IKE.DHGroup, ok = vendor.dhGroups[policies.dhGroup]; !ok {
+		return "", unsupported("IKE Diffie-Hellman group", policies.dhGroup)
+	}
+	config.IKE.Lifetime = policies.ikeLifetime
+	if config.ESP.Encryption, ok = vendor.espEncryption[policies.espEncryption]; !ok {
+		return "", unsupported("IPsec encryption algorithm", policies.espEncryption)
+	}
+	if policies.espIntegrity != isVPNPeerConfigDisabled {
+		if config.ESP.Integrity, ok = vendor.espIntegrity[policies.espIntegrity]; !ok {
+			return "", unsupported("IPsec authentication algorithm", policies.espIntegrity)
+		}
+	}
+	if policies.pfs != isVPNPeerConfigDisabled {
+		var group int64
+		fmt.Sscanf(policies.pfs, "group_%d", &group)
+		if config.ESP.DHGroup, ok = vendor.dhGroups[group]; !ok {
+			return "", unsupported("IPsec PFS group", policies.pfs)
+		}
+	}
+	config.ESP.Lifetime = policies.espLifetime
+	if vendor.check != nil {
+		if err := vendor.check(config); err != nil {
+			return "", fmt.Errorf("[ERROR] %s does not support the policies of the connection: %s", vendorName, err)
+		}
+	}
+
+	config.Interface = vendor.iface
+	if iface != "" {
+		config.Interface = iface
+	}
+
+	tmpl, err := template.New(vendorName).Funcs(vpnPeerConfigFuncs).Parse(vendor.template)
+	if err != nil {
+		return "", err
+	}
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, config); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+var vpnPeerConfigInvalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9-]+`)
+
+// vpnPeerConfigName derives the names of the peer objects from the connection
+// name, within the name length limits of the vendors.
+func vpnPeerConfigName(name string) string {
+	name = vpnPeerConfigInvalidNameChars.ReplaceAllString(name, "-")
+	if len(name) > 20 {
+		name = name[:20]
+	}
+	return strings.Trim(name, "-")
+}
+
+var vpnPeerConfigFuncs = template.FuncMap{
+	"join": strings.Join,
+	// mask returns the address and netmask of a CIDR
+	"mask": func(cidr string) string {
+		ip, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return cidr
+		}
+		return ip.String() + " " + net.IP(network.Mask).String()
+	},
+	"add": func(a, b int) int {
+		return a + b
+	},
+	// clamp limits a value to the range of a vendor
+	"clamp": func(value, min, max int64) int64 {
+		if value < min {
+			return min
+		}
+		if value > max {
+			return max
+		}
+		return value
+	},
+}
+
+const vpnPeerConfigStrongSwanTemplate = `# /etc/ipsec.conf
+{{- if not .PolicyMode}}
+# Route the VPC address prefixes through the vti interfaces, and set
+# install_routes = no in the charon section of /etc/strongswan.conf:
+{{- range .Tunnels}}
+#   ip tunnel add vti{{.Index}} local {{$.PeerAddress}} remote {{.GatewayAddress}} mode vti key {{.Index}}
+#   sysctl -w net.ipv4.conf.vti{{.Index}}.disable_policy=1
+#   ip link set vti{{.Index}} up
+#   ip route add <VPC address prefix> dev vti{{.Index}}
+{{- end}}
+{{- end}}
+
+conn {{.Name}}-common
+  type=tunnel
+  authby=secret
+  auto=ignore
+  keyexchange=ikev{{.IKEVersion}}
+  left=%defaultroute
+  leftid={{.PeerAddress}}
+  ike={{.IKE.Encryption}}-{{.IKE.Integrity}}-{{.IKE.DHGroup}}!
+  esp={{.ESP.Encryption}}{{with .ESP.Integrity}}-{{.}}{{end}}{{with .ESP.DHGroup}}-{{.}}{{end}}!
+  ikelifetime={{.IKE.Lifetime}}s
+  lifetime={{.ESP.Lifetime}}s
+{{- with .DPD.Action}}
+  dpdaction={{.}}
+  dpddelay={{$.DPD.Interval}}s
+  dpdtimeout={{$.DPD.Timeout}}s
+{{- end}}
+{{range $tunnel := .Tunnels}}
+{{- if $.PolicyMode}}
+{{- if eq $.IKEVersion 1}}
+{{- range $i, $pair := $.CIDRPairs}}
+conn {{$.Name}}-{{add $i 1}}
+  also={{$.Name}}-common
+  auto=start
+  right={{$tunnel.GatewayAddress}}
+  leftsubnet={{index $pair 0}}
+  rightsubnet={{index $pair 1}}
+{{end}}
+{{- else}}
+conn {{$.Name}}
+  also={{$.Name}}-common
+  auto=start
+  right={{$tunnel.GatewayAddress}}
+  leftsubnet={{join $.LocalCIDRs ","}}
+  rightsubnet={{join $.RemoteCIDRs ","}}
+{{end}}
+{{- else}}
+conn {{$.Name}}-tunnel{{$tunnel.Index}}
+  also={{$.Name}}-common
+  auto=start
+  right={{$tunnel.GatewayAddress}}
+  leftsubnet=0.0.0.0/0
+  rightsubnet=0.0.0.0/0
+  mark={{$tunnel.Index}}
+{{end}}
+{{- end}}
+# /etc/ipsec.secrets
+{{- range .Tunnels}}
+{{$.PeerAddress}} {{.GatewayAddress}} : PSK "{{$.PSK}}"
+{{- end}}
+`
+
+const vpnPeerConfigLibreswanTemplate = `# /etc/ipsec.d/{{.Name}}.conf
+{{- if not .PolicyMode}}
+# Route the VPC address prefixes through the vti interfaces:
+{{- range .Tunnels}}
+#   ip route add <VPC address prefix> dev vti{{.Index}}
+{{- end}}
+{{- end}}
+{{range .Tunnels}}
+conn {{$.Name}}{{if not $.PolicyMode}}-tunnel{{.Index}}{{end}}
+  type=tunnel
+  authby=secret
+  auto=start
+  ikev2={{if eq $.IKEVersion 2}}yes{{else}}no{{end}}
+  left=%defaultroute
+  leftid={{$.PeerAddress}}
+  right={{.GatewayAddress}}
+{{- if $.PolicyMode}}
+{{- if eq (len $.LocalCIDRs) 1}}
+  leftsubnet={{index $.LocalCIDRs 0}}
+{{- else}}
+  leftsubnets={ {{- join $.LocalCIDRs " " -}} }
+{{- end}}
+{{- if eq (len $.RemoteCIDRs) 1}}
+  rightsubnet={{index $.RemoteCIDRs 0}}
+{{- else}}
+  rightsubnets={ {{- join $.RemoteCIDRs " " -}} }
+{{- end}}
+{{- else}}
+  leftsubnet=0.0.0.0/0
+  rightsubnet=0.0.0.0/0
+  mark={{.Index}}/0xffffffff
+  vti-interface=vti{{.Index}}
+  vti-routing=no
+{{- end}}
+  ike={{$.IKE.Encryption}}-{{$.IKE.Integrity}}-{{$.IKE.DHGroup}}
+  esp={{$.ESP.Encryption}}-{{with $.ESP.Integrity}}{{.}}{{else}}null{{end}}{{with $.ESP.DHGroup}}-{{.}}{{end}}
+  pfs={{if $.ESP.DHGroup}}yes{{else}}no{{end}}
+  ikelifetime={{$.IKE.Lifetime}}s
+  salifetime={{$.ESP.Lifetime}}s
+{{- with $.DPD.Action}}
+  dpdaction={{.}}
+  dpddelay={{$.DPD.Interval}}
+  dpdtimeout={{$.DPD.Timeout}}
+{{- end}}
+{{end}}
+# /etc/ipsec.d/{{.Name}}.secrets
+{{- range .Tunnels}}
+{{$.PeerAddress}} {{.GatewayAddress}} : PSK "{{$.PSK}}"
+{{- end}}
+`
+
+const vpnPeerConfigCiscoASATemplate = `! Cisco ASA
+{{- if eq .IKEVersion 2}}
+crypto ikev2 policy 10
+ encryption {{.IKE.Encryption}}
+ integrity {{.IKE.Integrity}}
+ group {{.IKE.DHGroup}}
+ prf {{.IKE.Integrity}}
+ lifetime seconds {{.IKE.Lifetime}}
+crypto ikev2 enable {{.Interface}}
+!
+crypto ipsec ikev2 ipsec-proposal {{.Name}}
+ protocol esp encryption {{.ESP.Encryption}}
+ protocol esp integrity {{with .ESP.Integrity}}{{if eq . "sha"}}sha-1{{else if eq . "md5"}}md5{{else}}sha-{{slice . 3}}{{end}}{{else}}null{{end}}
+{{- else}}
+crypto ikev1 policy 10
+ authentication pre-share
+ encryption {{.IKE.Encryption}}
+ hash {{.IKE.Integrity}}
+ group {{.IKE.DHGroup}}
+ lifetime {{.IKE.Lifetime}}
+crypto ikev1 enable {{.Interface}}
+!
+crypto ipsec ikev1 transform-set {{.Name}} esp-{{.ESP.Encryption}} esp-{{.ESP.Integrity}}-hmac
+{{- end}}
+!
+{{- if .PolicyMode}}
+object-group network {{.Name}}-local
+{{- range .LocalCIDRs}}
+ network-object {{mask .}}
+{{- end}}
+object-group network {{.Name}}-remote
+{{- range .RemoteCIDRs}}
+ network-object {{mask .}}
+{{- end}}
+access-list {{.Name}}-acl extended permit ip object-group {{.Name}}-local object-group {{.Name}}-remote
+!
+{{- range .Tunnels}}
+crypto map {{$.Name}}-map 10 match address {{$.Name}}-acl
+crypto map {{$.Name}}-map 10 set peer {{.GatewayAddress}}
+crypto map {{$.Name}}-map 10 set ikev{{$.IKEVersion}} {{if eq $.IKEVersion 2}}ipsec-proposal{{else}}transform-set{{end}} {{$.Name}}
+{{- with $.ESP.DHGroup}}
+crypto map {{$.Name}}-map 10 set pfs group{{.}}
+{{- end}}
+crypto map {{$.Name}}-map 10 set security-association lifetime seconds {{$.ESP.Lifetime}}
+crypto map {{$.Name}}-map interface {{$.Interface}}
+{{- end}}
+{{- else}}
+crypto ipsec profile {{.Name}}
+ set ikev{{.IKEVersion}} {{if eq .IKEVersion 2}}ipsec-proposal{{else}}transform-set{{end}} {{.Name}}
+{{- with .ESP.DHGroup}}
+ set pfs group{{.}}
+{{- end}}
+ set security-association lifetime seconds {{.ESP.Lifetime}}
+!
+! Route the VPC address prefixes through the tunnel interfaces:
+{{- range .Tunnels}}
+!   route {{$.Name}}-{{.Index}} <VPC address prefix> <netmask> 169.254.{{.Index}}.2
+{{- end}}
+{{- range .Tunnels}}
+interface Tunnel{{.Index}}
+ nameif {{$.Name}}-{{.Index}}
+ ip address 169.254.{{.Index}}.1 255.255.255.252
+ tunnel source interface {{$.Interface}}
+ tunnel destination {{.GatewayAddress}}
+ tunnel mode ipsec ipv4
+ tunnel protection ipsec profile {{$.Name}}
+{{- end}}
+{{- end}}
+!
+{{- range .Tunnels}}
+tunnel-group {{.GatewayAddress}} type ipsec-l2l
+tunnel-group {{.GatewayAddress}} ipsec-attributes
+{{- if eq $.IKEVersion 2}}
+ ikev2 remote-authentication pre-shared-key {{$.PSK}}
+ ikev2 local-authentication pre-shared-key {{$.PSK}}
+{{- else}}
+ ikev1 pre-shared-key {{$.PSK}}
+{{- end}}
+{{- if $.DPD.Action}}
+ isakmp keepalive threshold {{clamp $.DPD.Interval 10 3600}} retry {{clamp $.DPDThreshold 2 10}}
+{{- else}}
+ isakmp keepalive disable
+{{- end}}
+{{- end}}
+`
+
+const vpnPeerConfigJuniperSRXTemplate = `# Juniper SRX
+set security ike proposal {{.Name}}-ike authentication-method pre-shared-keys
+set security ike proposal {{.Name}}-ike dh-group {{.IKE.DHGroup}}
+set security ike proposal {{.Name}}-ike authentication-algorithm {{.IKE.Integrity}}
+set security ike proposal {{.Name}}-ike encryption-algorithm {{.IKE.Encryption}}
+set security ike proposal {{.Name}}-ike lifetime-seconds {{.IKE.Lifetime}}
+set security ike policy {{.Name}}-ike proposals {{.Name}}-ike
+{{- if eq .IKEVersion 1}}
+set security ike policy {{.Name}}-ike mode main
+{{- end}}
+set security ike policy {{.Name}}-ike pre-shared-key ascii-text "{{.PSK}}"
+set security ipsec proposal {{.Name}}-ipsec protocol esp
+{{- with .ESP.Integrity}}
+set security ipsec proposal {{$.Name}}-ipsec authentication-algorithm {{.}}
+{{- end}}
+set security ipsec proposal {{.Name}}-ipsec encryption-algorithm {{.ESP.Encryption}}
+set security ipsec proposal {{.Name}}-ipsec lifetime-seconds {{.ESP.Lifetime}}
+{{- with .ESP.DHGroup}}
+set security ipsec policy {{$.Name}}-ipsec perfect-forward-secrecy keys {{.}}
+{{- end}}
+set security ipsec policy {{.Name}}-ipsec proposals {{.Name}}-ipsec
+{{- range $tunnel := .Tunnels}}
+set interfaces st0 unit {{.Index}} family inet
+set security zones security-zone vpn interfaces st0.{{.Index}}
+set security ike gateway {{$.Name}}-gw{{.Index}} ike-policy {{$.Name}}-ike
+set security ike gateway {{$.Name}}-gw{{.Index}} address {{.GatewayAddress}}
+set security ike gateway {{$.Name}}-gw{{.Index}} external-interface {{$.Interface}}
+set security ike gateway {{$.Name}}-gw{{.Index}} local-identity inet {{$.PeerAddress}}
+set security ike gateway {{$.Name}}-gw{{.Index}} version {{if eq $.IKEVersion 2}}v2-only{{else}}v1-only{{end}}
+{{- if $.DPD.Action}}
+set security ike gateway {{$.Name}}-gw{{.Index}} dead-peer-detection interval {{$.DPD.Interval}}
+set security ike gateway {{$.Name}}-gw{{.Index}} dead-peer-detection threshold {{$.DPDThreshold}}
+{{- end}}
+set security ipsec vpn {{$.Name}}-vpn{{.Index}} bind-interface st0.{{.Index}}
+set security ipsec vpn {{$.Name}}-vpn{{.Index}} ike gateway {{$.Name}}-gw{{.Index}}
+set security ipsec vpn {{$.Name}}-vpn{{.Index}} ike ipsec-policy {{$.Name}}-ipsec
+set security ipsec vpn {{$.Name}}-vpn{{.Index}} establish-tunnels immediately
+{{- if $.PolicyMode}}
+{{- range $i, $pair := $.CIDRPairs}}
+set security ipsec vpn {{$.Name}}-vpn{{$tunnel.Index}} traffic-selector ts{{add $i 1}} local-ip {{index $pair 0}}
+set security ipsec vpn {{$.Name}}-vpn{{$tunnel.Index}} traffic-selector ts{{add $i 1}} remote-ip {{index $pair 1}}
+{{- end}}
+{{- else}}
+# set routing-options static route <VPC address prefix> next-hop st0.{{.Index}}
+{{- end}}
+{{- end}}
+`
+
+const vpnPeerConfigPaloAltoTemplate = `# Palo Alto Networks PAN-OS
+set network ike crypto-profiles ike-crypto-profiles {{.Name}}-ike encryption {{.IKE.Encryption}}
+set network ike crypto-profiles ike-crypto-profiles {{.Name}}-ike hash {{.IKE.Integrity}}
+set network ike crypto-profiles ike-crypto-profiles {{.Name}}-ike dh-group {{.IKE.DHGroup}}
+set network ike crypto-profiles ike-crypto-profiles {{.Name}}-ike lifetime seconds {{.IKE.Lifetime}}
+set network ike crypto-profiles ipsec-crypto-profiles {{.Name}}-ipsec esp encryption {{.ESP.Encryption}}
+set network ike crypto-profiles ipsec-crypto-profiles {{.Name}}-ipsec esp authentication {{with .ESP.Integrity}}{{.}}{{else}}none{{end}}
+set network ike crypto-profiles ipsec-crypto-profiles {{.Name}}-ipsec dh-group {{with .ESP.DHGroup}}{{.}}{{else}}no-pfs{{end}}
+set network ike crypto-profiles ipsec-crypto-profiles {{.Name}}-ipsec lifetime seconds {{.ESP.Lifetime}}
+{{- range $tunnel := .Tunnels}}
+set network ike gateway {{$.Name}}-gw{{.Index}} authentication pre-shared-key key "{{$.PSK}}"
+set network ike gateway {{$.Name}}-gw{{.Index}} protocol version ikev{{$.IKEVersion}}
+set network ike gateway {{$.Name}}-gw{{.Index}} protocol ikev{{$.IKEVersion}} ike-crypto-profile {{$.Name}}-ike
+{{- if eq $.IKEVersion 1}}
+set network ike gateway {{$.Name}}-gw{{.Index}} protocol ikev1 exchange-mode main
+{{- end}}
+{{- if $.DPD.Action}}
+set network ike gateway {{$.Name}}-gw{{.Index}} protocol ikev{{$.IKEVersion}} dpd enable yes
+set network ike gateway {{$.Name}}-gw{{.Index}} protocol ikev{{$.IKEVersion}} dpd interval {{$.DPD.Interval}}
+{{- if eq $.IKEVersion 1}}
+set network ike gateway {{$.Name}}-gw{{.Index}} protocol ikev1 dpd retry {{$.DPDThreshold}}
+{{- end}}
+{{- end}}
+set network ike gateway {{$.Name}}-gw{{.Index}} local-address interface {{$.Interface}}
+set network ike gateway {{$.Name}}-gw{{.Index}} local-id type ipaddr id {{$.PeerAddress}}
+set network ike gateway {{$.Name}}-gw{{.Index}} peer-address ip {{.GatewayAddress}}
+set network interface tunnel units tunnel.{{.Index}}
+set network tunnel ipsec {{$.Name}}-tunnel{{.Index}} auto-key ike-gateway {{$.Name}}-gw{{.Index}}
+set network tunnel ipsec {{$.Name}}-tunnel{{.Index}} auto-key ipsec-crypto-profile {{$.Name}}-ipsec
+set network tunnel ipsec {{$.Name}}-tunnel{{.Index}} tunnel-interface tunnel.{{.Index}}
+{{- if $.PolicyMode}}
+{{- range $i, $pair := $.CIDRPairs}}
+set network tunnel ipsec {{$.Name}}-tunnel{{$tunnel.Index}} auto-key proxy-id pid{{add $i 1}} local {{index $pair 0}} remote {{index $pair 1}} protocol any
+{{- end}}
+{{- range $i, $cidr := $.RemoteCIDRs}}
+set network virtual-router default routing-table ip static-route {{$.Name}}-{{$tunnel.Index}}-{{add $i 1}} interface tunnel.{{$tunnel.Index}} destination {{$cidr}}
+{{- end}}
+{{- else}}
+# set network virtual-router default routing-table ip static-route <name> interface tunnel.{{.Index}} destination <VPC address prefix>
+{{- end}}
+{{- end}}
+# Add tunnel.N to a security zone, and allow the traffic in the security policies.
+`
+
+const vpnPeerConfigVyOSTemplate = `# VyOS 1.3
+set vpn ipsec ipsec-interfaces interface {{.Interface}}
+set vpn ipsec ike-group {{.Name}}-ike key-exchange ikev{{.IKEVersion}}
+set vpn ipsec ike-group {{.Name}}-ike lifetime {{.IKE.Lifetime}}
+set vpn ipsec ike-group {{.Name}}-ike proposal 1 dh-group {{.IKE.DHGroup}}
+set vpn ipsec ike-group {{.Name}}-ike proposal 1 encryption {{.IKE.Encryption}}
+set vpn ipsec ike-group {{.Name}}-ike proposal 1 hash {{.IKE.Integrity}}
+{{- with .DPD.Action}}
+set vpn ipsec ike-group {{$.Name}}-ike dead-peer-detection action {{.}}
+set vpn ipsec ike-group {{$.Name}}-ike dead-peer-detection interval {{$.DPD.Interval}}
+set vpn ipsec ike-group {{$.Name}}-ike dead-peer-detection timeout {{$.DPD.Timeout}}
+{{- end}}
+set vpn ipsec esp-group {{.Name}}-esp mode tunnel
+set vpn ipsec esp-group {{.Name}}-esp lifetime {{.ESP.Lifetime}}
+set vpn ipsec esp-group {{.Name}}-esp pfs {{with .ESP.DHGroup}}dh-group{{.}}{{else}}disable{{end}}
+set vpn ipsec esp-group {{.Name}}-esp proposal 1 encryption {{.ESP.Encryption}}
+{{- with .ESP.Integrity}}
+set vpn ipsec esp-group {{$.Name}}-esp proposal 1 hash {{.}}
+{{- end}}
+{{- range $tunnel := .Tunnels}}
+set vpn ipsec site-to-site peer {{.GatewayAddress}} authentication mode pre-shared-secret
+set vpn ipsec site-to-site peer {{.GatewayAddress}} authentication pre-shared-secret '{{$.PSK}}'
+set vpn ipsec site-to-site peer {{.GatewayAddress}} authentication id {{$.PeerAddress}}
+set vpn ipsec site-to-site peer {{.GatewayAddress}} connection-type initiate
+set vpn ipsec site-to-site peer {{.GatewayAddress}} ike-group {{$.Name}}-ike
+set vpn ipsec site-to-site peer {{.GatewayAddress}} default-esp-group {{$.Name}}-esp
+set vpn ipsec site-to-site peer {{.GatewayAddress}} local-address {{$.PeerAddress}}
+{{- if $.PolicyMode}}
+{{- range $i, $pair := $.CIDRPairs}}
+set vpn ipsec site-to-site peer {{$tunnel.GatewayAddress}} tunnel {{add $i 1}} local prefix {{index $pair 0}}
+set vpn ipsec site-to-site peer {{$tunnel.GatewayAddress}} tunnel {{add $i 1}} remote prefix {{index $pair 1}}
+{{- end}}
+{{- else}}
+set interfaces vti vti{{.Index}}
+set vpn ipsec site-to-site peer {{.GatewayAddress}} vti bind vti{{.Index}}
+set vpn ipsec site-to-site peer {{.GatewayAddress}} vti esp-group {{$.Name}}-esp
+# set protocols static interface-route <VPC address prefix> next-hop-interface vti{{.Index}}
+{{- end}}
+{{- end}}
+`
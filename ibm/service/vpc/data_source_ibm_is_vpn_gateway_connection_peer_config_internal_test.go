@@ -0,0 +1,460 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func testVPNPeerPolicies(ikeVersion int64) vpnPeerPolicies {
+	return vpnPeerPolicies{
+		ikeVersion:    ikeVersion,
+		ikeEncryption: vpnPeerConfigDefaults.ikeEncryption,
+		ikeIntegrity:  vpnPeerConfigDefaults.ikeIntegrity,
+		dhGroup:       vpnPeerConfigDefaults.dhGroup,
+		ikeLifetime:   vpnPeerConfigDefaults.ikeLifetime,
+		espEncryption: vpnPeerConfigDefaults.espEncryption,
+		espIntegrity:  vpnPeerConfigDefaults.espIntegrity,
+		pfs:           vpnPeerConfigDefaults.pfs,
+		espLifetime:   vpnPeerConfigDefaults.espLifetime,
+	}
+}
+
+// testVPNPeerConfig returns the configuration of a connection with two peer
+// CIDRs, the active member of a policy mode gateway, and the two tunnels of a
+// route mode gateway.
+func testVPNPeerConfig(mode string, ikeVersion int64) *vpnPeerConfig {
+	config := &vpnPeerConfig{
+		Name:        "conn-1",
+		Mode:        mode,
+		IKEVersion:  ikeVersion,
+		PeerAddress: "203.0.113.10",
+		PSK:         "secret",
+		LocalCIDRs:  []string{"10.0.0.0/24", "10.0.1.0/24"},
+		RemoteCIDRs: []string{"10.240.0.0/24"},
+		DPD:         vpnPeerDPD{Action: "restart", Interval: 30, Timeout: 120},
+		Tunnels:     []vpnPeerTunnel{{Index: 1, GatewayAddress: "198.51.100.1"}},
+	}
+	if mode != "policy" {
+		config.Tunnels = append(config.Tunnels, vpnPeerTunnel{Index: 2, GatewayAddress: "198.51.100.2"})
+	}
+	return config
+}
+
+func TestRenderVPNPeerConfig(t *testing.T) {
+	// Cisco ASA IKEv1 policies only support the md5 and sha1 algorithms
+	sha1 := func(ikeVersion int64) vpnPeerPolicies {
+		policies := testVPNPeerPolicies(ikeVersion)
+		policies.ikeIntegrity = "sha1"
+		policies.espIntegrity = "sha1"
+		return policies
+	}
+	gcm := testVPNPeerPolicies(2)
+	gcm.espEncryption = "aes256gcm16"
+	gcm.espIntegrity = isVPNPeerConfigDisabled
+	gcm.pfs = isVPNPeerConfigDisabled
+
+	common := map[string][]string{
+		isVPNPeerConfigVendorStrongSwan: {
+			"ikelifetime=28800s",
+			"lifetime=3600s",
+			"dpdaction=restart\n  dpddelay=30s\n  dpdtimeout=120s",
+			`203.0.113.10 198.51.100.1 : PSK "secret"`,
+		},
+		isVPNPeerConfigVendorLibreswan: {
+			"leftid=203.0.113.10\n  right=198.51.100.1",
+			"dpdaction=restart\n  dpddelay=30\n  dpdtimeout=120",
+			`203.0.113.10 198.51.100.1 : PSK "secret"`,
+		},
+		isVPNPeerConfigVendorCiscoASA: {
+			"tunnel-group 198.51.100.1 type ipsec-l2l",
+			"isakmp keepalive threshold 30 retry 4",
+		},
+		isVPNPeerConfigVendorJuniperSRX: {
+			"set security ike policy conn-1-ike pre-shared-key ascii-text \"secret\"",
+			"set security ike gateway conn-1-gw1 external-interface ge-0/0/0.0",
+			"set security ike gateway conn-1-gw1 local-identity inet 203.0.113.10",
+			"set security ike gateway conn-1-gw1 dead-peer-detection threshold 4",
+		},
+		isVPNPeerConfigVendorPaloAlto: {
+			"set network ike gateway conn-1-gw1 authentication pre-shared-key key \"secret\"",
+			"set network ike gateway conn-1-gw1 local-address interface ethernet1/1",
+			"set network ike gateway conn-1-gw1 local-id type ipaddr id 203.0.113.10",
+		},
+		isVPNPeerConfigVendorVyOS: {
+			"set vpn ipsec ipsec-interfaces interface eth0",
+			"set vpn ipsec ike-group conn-1-ike dead-peer-detection action restart",
+			"set vpn ipsec site-to-site peer 198.51.100.1 authentication pre-shared-secret 'secret'",
+		},
+	}
+
+	testCases := []struct {
+		vendor     string
+		mode       string
+		ikeVersion int64
+		policies   vpnPeerPolicies
+		want       []string
+	}{
+		{
+			vendor: isVPNPeerConfigVendorStrongSwan, mode: "policy", ikeVersion: 1,
+			want: []string{
+				"keyexchange=ikev1",
+				"ike=aes256-sha256-modp2048!",
+				"esp=aes256-sha256-modp2048!",
+				"conn conn-1-2\n  also=conn-1-common\n  auto=start\n  right=198.51.100.1\n  leftsubnet=10.0.1.0/24\n  rightsubnet=10.240.0.0/24",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorStrongSwan, mode: "policy", ikeVersion: 2,
+			want: []string{
+				"keyexchange=ikev2",
+				"conn conn-1\n  also=conn-1-common\n  auto=start\n  right=198.51.100.1\n  leftsubnet=10.0.0.0/24,10.0.1.0/24\n  rightsubnet=10.240.0.0/24",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorStrongSwan, mode: "route", ikeVersion: 1,
+			want: []string{
+				"keyexchange=ikev1",
+				"ip tunnel add vti2 local 203.0.113.10 remote 198.51.100.2 mode vti key 2",
+				"conn conn-1-tunnel2\n  also=conn-1-common\n  auto=start\n  right=198.51.100.2\n  leftsubnet=0.0.0.0/0\n  rightsubnet=0.0.0.0/0\n  mark=2",
+				`203.0.113.10 198.51.100.2 : PSK "secret"`,
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorStrongSwan, mode: "route", ikeVersion: 2,
+			want: []string{
+				"keyexchange=ikev2",
+				"conn conn-1-tunnel1\n",
+				"mark=1\n",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorStrongSwan, mode: "policy", ikeVersion: 2, policies: gcm,
+			want: []string{"esp=aes256gcm16!"},
+		},
+		{
+			vendor: isVPNPeerConfigVendorLibreswan, mode: "policy", ikeVersion: 1,
+			want: []string{
+				"conn conn-1\n",
+				"ikev2=no",
+				"leftsubnets={10.0.0.0/24 10.0.1.0/24}",
+				"rightsubnet=10.240.0.0/24",
+				"ike=aes256-sha2_256-modp2048",
+				"esp=aes256-sha2_256-modp2048",
+				"pfs=yes",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorLibreswan, mode: "policy", ikeVersion: 2,
+			want: []string{"conn conn-1\n", "ikev2=yes", "leftsubnets={10.0.0.0/24 10.0.1.0/24}"},
+		},
+		{
+			vendor: isVPNPeerConfigVendorLibreswan, mode: "route", ikeVersion: 1,
+			want: []string{
+				"ikev2=no",
+				"conn conn-1-tunnel2\n",
+				"right=198.51.100.2\n  leftsubnet=0.0.0.0/0\n  rightsubnet=0.0.0.0/0\n  mark=2/0xffffffff\n  vti-interface=vti2",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorLibreswan, mode: "route", ikeVersion: 2,
+			want: []string{"ikev2=yes", "conn conn-1-tunnel1\n", "vti-interface=vti1"},
+		},
+		{
+			vendor: isVPNPeerConfigVendorLibreswan, mode: "policy", ikeVersion: 2, policies: gcm,
+			want: []string{"esp=aes_gcm256-null\n", "pfs=no"},
+		},
+		{
+			vendor: isVPNPeerConfigVendorCiscoASA, mode: "policy", ikeVersion: 1, policies: sha1(1),
+			want: []string{
+				"crypto ikev1 policy 10\n authentication pre-share\n encryption aes-256\n hash sha\n group 14\n lifetime 28800\ncrypto ikev1 enable outside",
+				"crypto ipsec ikev1 transform-set conn-1 esp-aes-256 esp-sha-hmac",
+				"object-group network conn-1-local\n network-object 10.0.0.0 255.255.255.0\n network-object 10.0.1.0 255.255.255.0",
+				"access-list conn-1-acl extended permit ip object-group conn-1-local object-group conn-1-remote",
+				"crypto map conn-1-map 10 set peer 198.51.100.1",
+				"crypto map conn-1-map 10 set ikev1 transform-set conn-1",
+				"crypto map conn-1-map 10 set pfs group14",
+				"crypto map conn-1-map interface outside",
+				" ikev1 pre-shared-key secret",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorCiscoASA, mode: "policy", ikeVersion: 2, policies: sha1(2),
+			want: []string{
+				"crypto ikev2 policy 10\n encryption aes-256\n integrity sha\n group 14\n prf sha\n lifetime seconds 28800\ncrypto ikev2 enable outside",
+				"crypto ipsec ikev2 ipsec-proposal conn-1\n protocol esp encryption aes-256\n protocol esp integrity sha-1",
+				"crypto map conn-1-map 10 set ikev2 ipsec-proposal conn-1",
+				" ikev2 remote-authentication pre-shared-key secret",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorCiscoASA, mode: "route", ikeVersion: 1, policies: sha1(1),
+			want: []string{
+				"crypto ipsec profile conn-1\n set ikev1 transform-set conn-1\n set pfs group14\n set security-association lifetime seconds 3600",
+				"interface Tunnel2\n nameif conn-1-2\n ip address 169.254.2.1 255.255.255.252\n tunnel source interface outside\n tunnel destination 198.51.100.2",
+				"tunnel-group 198.51.100.2 ipsec-attributes\n ikev1 pre-shared-key secret",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorCiscoASA, mode: "route", ikeVersion: 2,
+			want: []string{
+				"integrity sha256\n group 14\n prf sha256",
+				"protocol esp integrity sha-256",
+				"crypto ipsec profile conn-1\n set ikev2 ipsec-proposal conn-1",
+				"interface Tunnel1\n",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorCiscoASA, mode: "policy", ikeVersion: 2, policies: gcm,
+			want: []string{"protocol esp encryption aes-gcm-256\n protocol esp integrity null"},
+		},
+		{
+			vendor: isVPNPeerConfigVendorJuniperSRX, mode: "policy", ikeVersion: 1,
+			want: []string{
+				"set security ike proposal conn-1-ike dh-group group14",
+				"set security ike proposal conn-1-ike authentication-algorithm sha-256",
+				"set security ike proposal conn-1-ike encryption-algorithm aes-256-cbc",
+				"set security ike policy conn-1-ike mode main",
+				"set security ipsec proposal conn-1-ipsec authentication-algorithm hmac-sha-256-128",
+				"set security ipsec policy conn-1-ipsec perfect-forward-secrecy keys group14",
+				"set security ike gateway conn-1-gw1 version v1-only",
+				"set security ipsec vpn conn-1-vpn1 traffic-selector ts2 local-ip 10.0.1.0/24",
+				"set security ipsec vpn conn-1-vpn1 traffic-selector ts2 remote-ip 10.240.0.0/24",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorJuniperSRX, mode: "policy", ikeVersion: 2,
+			want: []string{
+				"set security ike gateway conn-1-gw1 version v2-only",
+				"set security ipsec vpn conn-1-vpn1 traffic-selector ts1 local-ip 10.0.0.0/24",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorJuniperSRX, mode: "route", ikeVersion: 1,
+			want: []string{
+				"set security ike policy conn-1-ike mode main",
+				"set security ike gateway conn-1-gw2 address 198.51.100.2",
+				"set security ipsec vpn conn-1-vpn2 bind-interface st0.2",
+				"next-hop st0.2",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorJuniperSRX, mode: "route", ikeVersion: 2,
+			want: []string{"set security ike gateway conn-1-gw2 version v2-only", "next-hop st0.1"},
+		},
+		{
+			vendor: isVPNPeerConfigVendorJuniperSRX, mode: "policy", ikeVersion: 2, policies: gcm,
+			want: []string{"set security ipsec proposal conn-1-ipsec encryption-algorithm aes-256-gcm"},
+		},
+		{
+			vendor: isVPNPeerConfigVendorPaloAlto, mode: "policy", ikeVersion: 1,
+			want: []string{
+				"ike-crypto-profiles conn-1-ike encryption aes-256-cbc",
+				"ike-crypto-profiles conn-1-ike hash sha256",
+				"ike-crypto-profiles conn-1-ike dh-group group14",
+				"ipsec-crypto-profiles conn-1-ipsec esp authentication sha256",
+				"ipsec-crypto-profiles conn-1-ipsec dh-group group14",
+				"set network ike gateway conn-1-gw1 protocol version ikev1",
+				"set network ike gateway conn-1-gw1 protocol ikev1 exchange-mode main",
+				"set network ike gateway conn-1-gw1 protocol ikev1 dpd retry 4",
+				"set network tunnel ipsec conn-1-tunnel1 auto-key proxy-id pid2 local 10.0.1.0/24 remote 10.240.0.0/24 protocol any",
+				"static-route conn-1-1-1 interface tunnel.1 destination 10.240.0.0/24",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorPaloAlto, mode: "policy", ikeVersion: 2,
+			want: []string{
+				"set network ike gateway conn-1-gw1 protocol version ikev2",
+				"set network ike gateway conn-1-gw1 protocol ikev2 dpd interval 30",
+				"auto-key proxy-id pid1 local 10.0.0.0/24 remote 10.240.0.0/24 protocol any",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorPaloAlto, mode: "route", ikeVersion: 1,
+			want: []string{
+				"set network ike gateway conn-1-gw2 protocol version ikev1",
+				"set network ike gateway conn-1-gw2 peer-address ip 198.51.100.2",
+				"set network tunnel ipsec conn-1-tunnel2 tunnel-interface tunnel.2",
+				"interface tunnel.2 destination <VPC address prefix>",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorPaloAlto, mode: "route", ikeVersion: 2,
+			want: []string{"set network ike gateway conn-1-gw1 protocol version ikev2", "interface tunnel.1 destination <VPC address prefix>"},
+		},
+		{
+			vendor: isVPNPeerConfigVendorPaloAlto, mode: "policy", ikeVersion: 2, policies: gcm,
+			want: []string{"esp encryption aes-256-gcm", "esp authentication none", "conn-1-ipsec dh-group no-pfs"},
+		},
+		{
+			vendor: isVPNPeerConfigVendorVyOS, mode: "policy", ikeVersion: 1,
+			want: []string{
+				"set vpn ipsec ike-group conn-1-ike key-exchange ikev1",
+				"set vpn ipsec ike-group conn-1-ike proposal 1 dh-group 14",
+				"set vpn ipsec esp-group conn-1-esp pfs dh-group14",
+				"set vpn ipsec esp-group conn-1-esp proposal 1 hash sha256",
+				"set vpn ipsec site-to-site peer 198.51.100.1 tunnel 2 local prefix 10.0.1.0/24",
+				"set vpn ipsec site-to-site peer 198.51.100.1 tunnel 2 remote prefix 10.240.0.0/24",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorVyOS, mode: "policy", ikeVersion: 2,
+			want: []string{"set vpn ipsec ike-group conn-1-ike key-exchange ikev2", "peer 198.51.100.1 tunnel 1 local prefix 10.0.0.0/24"},
+		},
+		{
+			vendor: isVPNPeerConfigVendorVyOS, mode: "route", ikeVersion: 1,
+			want: []string{
+				"set vpn ipsec ike-group conn-1-ike key-exchange ikev1",
+				"set interfaces vti vti2",
+				"set vpn ipsec site-to-site peer 198.51.100.2 vti bind vti2",
+			},
+		},
+		{
+			vendor: isVPNPeerConfigVendorVyOS, mode: "route", ikeVersion: 2,
+			want: []string{"set vpn ipsec ike-group conn-1-ike key-exchange ikev2", "set vpn ipsec site-to-site peer 198.51.100.1 vti bind vti1"},
+		},
+		{
+			vendor: isVPNPeerConfigVendorVyOS, mode: "policy", ikeVersion: 2, policies: gcm,
+			want: []string{"set vpn ipsec esp-group conn-1-esp pfs disable", "set vpn ipsec esp-group conn-1-esp proposal 1 encryption aes256gcm128"},
+		},
+	}
+
+	for _, tc := range testCases {
+		name := fmt.Sprintf("%s/%s/ikev%d", tc.vendor, tc.mode, tc.ikeVersion)
+		if tc.policies == gcm {
+			name += "/gcm"
+		}
+		t.Run(name, func(t *testing.T) {
+			policies := tc.policies
+			if policies == (vpnPeerPolicies{}) {
+				policies = testVPNPeerPolicies(tc.ikeVersion)
+			}
+			rendered, err := renderVPNPeerConfig(tc.vendor, "", testVPNPeerConfig(tc.mode, tc.ikeVersion), policies)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for _, want := range append(common[tc.vendor], tc.want...) {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("got configuration without %q:\n%s", want, rendered)
+				}
+			}
+			// a policy mode peer connects to the active member only, and a
+			// route mode peer routes the traffic instead of selecting it
+			if tc.mode == "policy" && strings.Contains(rendered, "198.51.100.2") {
+				t.Errorf("got policy mode configuration with the second tunnel:\n%s", rendered)
+			}
+			if tc.mode == "route" && strings.Contains(rendered, "10.0.1.0") {
+				t.Errorf("got route mode configuration with the peer CIDRs:\n%s", rendered)
+			}
+		})
+	}
+}
+
+func TestRenderVPNPeerConfigInterface(t *testing.T) {
+	rendered, err := renderVPNPeerConfig(isVPNPeerConfigVendorCiscoASA, "wan", testVPNPeerConfig("route", 2), testVPNPeerPolicies(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(rendered, "crypto ikev2 enable wan") || !strings.Contains(rendered, "tunnel source interface wan") {
+		t.Fatalf("got configuration without the interface wan:\n%s", rendered)
+	}
+}
+
+func TestRenderVPNPeerConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		vendor     string
+		ikeVersion int64
+		policies   func(p *vpnPeerPolicies)
+		err        string
+	}{
+		{
+			name:       "cisco asa ikev1 sha256",
+			vendor:     isVPNPeerConfigVendorCiscoASA,
+			ikeVersion: 1,
+			policies:   func(p *vpnPeerPolicies) {},
+			err:        "cisco_asa does not support the policies of the connection: IKEv1 policies only support the md5 and sha1 authentication algorithms",
+		},
+		{
+			name:       "cisco asa ikev1 dh group 19",
+			vendor:     isVPNPeerConfigVendorCiscoASA,
+			ikeVersion: 1,
+			policies:   func(p *vpnPeerPolicies) { p.ikeIntegrity = "sha1"; p.dhGroup = 19 },
+			err:        "IKEv1 policies only support the Diffie-Hellman groups 14, 15 and 16",
+		},
+		{
+			name:       "cisco asa ikev1 aes-gcm",
+			vendor:     isVPNPeerConfigVendorCiscoASA,
+			ikeVersion: 1,
+			policies: func(p *vpnPeerPolicies) {
+				p.ikeIntegrity = "sha1"
+				p.espEncryption = "aes256gcm16"
+				p.espIntegrity = isVPNPeerConfigDisabled
+			},
+			err: "IKEv1 transform sets do not support AES-GCM encryption",
+		},
+		{
+			name:       "cisco asa dh group 5",
+			vendor:     isVPNPeerConfigVendorCiscoASA,
+			ikeVersion: 2,
+			policies:   func(p *vpnPeerPolicies) { p.dhGroup = 5 },
+			err:        "cisco_asa does not support the IKE Diffie-Hellman group 5 of the connection",
+		},
+		{
+			name:       "libreswan md5",
+			vendor:     isVPNPeerConfigVendorLibreswan,
+			ikeVersion: 2,
+			policies:   func(p *vpnPeerPolicies) { p.ikeIntegrity = "md5" },
+			err:        "libreswan does not support the IKE authentication algorithm md5 of the connection",
+		},
+		{
+			name:       "libreswan esp md5",
+			vendor:     isVPNPeerConfigVendorLibreswan,
+			ikeVersion: 2,
+			policies:   func(p *vpnPeerPolicies) { p.espIntegrity = "md5" },
+			err:        "libreswan does not support the IPsec authentication algorithm md5 of the connection",
+		},
+		{
+			name:       "libreswan pfs group 2",
+			vendor:     isVPNPeerConfigVendorLibreswan,
+			ikeVersion: 2,
+			policies:   func(p *vpnPeerPolicies) { p.pfs = "group_2" },
+			err:        "libreswan does not support the IPsec PFS group group_2 of the connection",
+		},
+		{
+			name:       "juniper srx sha512",
+			vendor:     isVPNPeerConfigVendorJuniperSRX,
+			ikeVersion: 2,
+			policies:   func(p *vpnPeerPolicies) { p.ikeIntegrity = "sha512" },
+			err:        "juniper_srx does not support the IKE authentication algorithm sha512 of the connection",
+		},
+		{
+			name:       "palo alto aes192gcm16",
+			vendor:     isVPNPeerConfigVendorPaloAlto,
+			ikeVersion: 2,
+			policies:   func(p *vpnPeerPolicies) { p.espEncryption = "aes192gcm16"; p.espIntegrity = isVPNPeerConfigDisabled },
+			err:        "palo_alto does not support the IPsec encryption algorithm aes192gcm16 of the connection",
+		},
+		{
+			name:       "vyos ike encryption",
+			vendor:     isVPNPeerConfigVendorVyOS,
+			ikeVersion: 2,
+			policies:   func(p *vpnPeerPolicies) { p.ikeEncryption = "aes512" },
+			err:        "vyos does not support the IKE encryption algorithm aes512 of the connection",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policies := testVPNPeerPolicies(tc.ikeVersion)
+			tc.policies(&policies)
+			_, err := renderVPNPeerConfig(tc.vendor, "", testVPNPeerConfig("policy", tc.ikeVersion), policies)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("got error %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
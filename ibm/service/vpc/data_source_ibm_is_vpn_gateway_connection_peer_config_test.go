@@ -0,0 +1,97 @@
+// Copyright IBM Corp. 2023 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMIsVPNGatewayConnectionPeerConfigDataSourceBasic(t *testing.T) {
+	vpcname := fmt.Sprintf("tfvpnuat-vpc-%d", acctest.RandIntRange(100, 200))
+	subnetname := fmt.Sprintf("tfvpnuat-subnet-%d", acctest.RandIntRange(100, 200))
+	vpngwname := fmt.Sprintf("tfvpnuat-vpngw-%d", acctest.RandIntRange(100, 200))
+	name := fmt.Sprintf("tfvpnuat-createname-%d", acctest.RandIntRange(100, 200))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMIsVPNGatewayConnectionPeerConfigDataSourceConfig(vpcname, subnetname, vpngwname, name, "sha256", "strongswan"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.example", "mode", "policy"),
+					resource.TestCheckResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.example", "ike_version", "2"),
+					resource.TestCheckResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.example", "gateway_addresses.#", "1"),
+					resource.TestMatchResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.example", "rendered", regexp.MustCompile(`ike=aes256-sha256-modp2048!`)),
+					resource.TestMatchResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.example", "rendered", regexp.MustCompile(`leftsubnet=192.168.0.0/24`)),
+					resource.TestMatchResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.example", "rendered", regexp.MustCompile(`PSK "<PRESHARED_KEY>"`)),
+				),
+			},
+			{
+				Config: testAccCheckIBMIsVPNGatewayConnectionPeerConfigDataSourceConfig(vpcname, subnetname, vpngwname, name, "sha256", "juniper_srx"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.example", "rendered", regexp.MustCompile(`authentication-algorithm sha-256`)),
+					resource.TestMatchResourceAttr("data.ibm_is_vpn_gateway_connection_peer_config.example", "rendered", regexp.MustCompile(`traffic-selector ts1 local-ip 192.168.0.0/24`)),
+				),
+			},
+			{
+				Config:      testAccCheckIBMIsVPNGatewayConnectionPeerConfigDataSourceConfig(vpcname, subnetname, vpngwname, name, "md5", "libreswan"),
+				ExpectError: regexp.MustCompile(`libreswan does not support the IKE authentication algorithm md5`),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMIsVPNGatewayConnectionPeerConfigDataSourceConfig(vpc, subnet, vpngwname, name, authenticationAlgorithm, vendor string) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "example" {
+		name = "%s"
+	}
+	resource "ibm_is_subnet" "example" {
+		name            = "%s"
+		vpc             = ibm_is_vpc.example.id
+		zone            = "%s"
+		ipv4_cidr_block = "%s"
+	}
+	resource "ibm_is_vpn_gateway" "example" {
+		name   = "%s"
+		subnet = ibm_is_subnet.example.id
+		mode   = "policy"
+	}
+	resource "ibm_is_ike_policy" "example" {
+		name                     = "%[6]s-ike"
+		authentication_algorithm = "%[7]s"
+		encryption_algorithm     = "aes256"
+		dh_group                 = 14
+		ike_version              = 2
+	}
+	resource "ibm_is_ipsec_policy" "example" {
+		name                     = "%[6]s-ipsec"
+		authentication_algorithm = "sha256"
+		encryption_algorithm     = "aes256"
+		pfs                      = "group_14"
+	}
+	resource "ibm_is_vpn_gateway_connection" "example" {
+		name          = "%[6]s"
+		vpn_gateway   = ibm_is_vpn_gateway.example.id
+		peer_address  = "1.2.3.4"
+		local_cidrs   = [ibm_is_subnet.example.ipv4_cidr_block]
+		peer_cidrs    = ["192.168.0.0/24"]
+		preshared_key = "VPNDemoPassword"
+		ike_policy    = ibm_is_ike_policy.example.id
+		ipsec_policy  = ibm_is_ipsec_policy.example.id
+	}
+	data "ibm_is_vpn_gateway_connection_peer_config" "example" {
+		vpn_gateway            = ibm_is_vpn_gateway.example.id
+		vpn_gateway_connection = ibm_is_vpn_gateway_connection.example.gateway_connection
+		vendor                 = "%[8]s"
+	}
+	`, vpc, subnet, acc.ISZoneName, acc.ISCIDR, vpngwname, name, authenticationAlgorithm, vendor)
+}
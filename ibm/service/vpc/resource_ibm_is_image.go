@@ -5,13 +5,23 @@ package vpc
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/cos"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/ibm-cos-sdk-go/aws"
+	"github.com/IBM/ibm-cos-sdk-go/service/s3"
+	"github.com/IBM/ibm-cos-sdk-go/service/s3/s3manager"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -36,11 +46,18 @@ const (
 	isImageCheckSum         = "checksum"
 	IsImageCRN              = "crn"
 
+	isImageSourceFile        = "source_file"
+	isImageSourceFileSha256  = "source_file_sha256"
+	isImageSourceFileVersion = "source_file_version"
+	isImageCosStaging        = "cos_staging"
+
 	isImageProvisioning     = "provisioning"
 	isImageProvisioningDone = "done"
 	isImageDeleting         = "deleting"
 	isImageDeleted          = "done"
 
+	isImageUploadPartSize = 64 * 1024 * 1024
+
 	isImageAccessTags    = "access_tags"
 	isImageUserTagType   = "user"
 	isImageAccessTagType = "access"
@@ -71,6 +88,10 @@ func ResourceIBMISImage() *schema.Resource {
 				func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
 					return flex.ResourceValidateAccessTags(diff, v)
 				}),
+			customdiff.Sequence(
+				func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+					return resourceIBMISImageSourceFileCustomizeDiff(diff)
+				}),
 		),
 
 		Schema: map[string]*schema.Schema{
@@ -80,10 +101,80 @@ func ResourceIBMISImage() *schema.Resource {
 				Computed:         true,
 				DiffSuppressFunc: flex.ApplyOnce,
 				RequiredWith:     []string{isImageOperatingSystem},
-				ExactlyOneOf:     []string{isImageHref, isImageVolume},
+				ExactlyOneOf:     []string{isImageHref, isImageVolume, isImageSourceFile},
 				Description:      "Image Href value",
 			},
 
+			isImageSourceFile: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{isImageOperatingSystem, isImageCosStaging},
+				ExactlyOneOf: []string{isImageHref, isImageVolume, isImageSourceFile},
+				Description:  "The path of a local qcow2 or vhd image file that is uploaded to the cos_staging bucket to create the image",
+			},
+
+			isImageSourceFileSha256: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				RequiredWith: []string{isImageSourceFile},
+				Description:  "The SHA256 checksum of the local source_file. When set, the uploaded file is verified against it and a change of the checksum forces a new image",
+			},
+
+			isImageSourceFileVersion: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The size and modification time of the local source_file when it was uploaded",
+			},
+
+			isImageCosStaging: {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				RequiredWith: []string{isImageSourceFile},
+				Description:  "The COS bucket the source_file is uploaded to before the image is created",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_instance_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The CRN of the COS instance of the bucket",
+						},
+						"bucket_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the bucket",
+						},
+						"bucket_region": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The region of the bucket",
+						},
+						"endpoint_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "public",
+							ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private", "direct"}),
+							Description:  "COS endpoint type: public, private, direct",
+						},
+						"key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The object key of the uploaded file, defaults to the base name of source_file",
+						},
+						"delete_staging_object": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Delete the uploaded object once the image is available",
+						},
+					},
+				},
+			},
+
 			isImageName: {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -114,12 +205,12 @@ func ResourceIBMISImage() *schema.Resource {
 			},
 
 			isImageOperatingSystem: {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				RequiredWith: []string{isImageHref},
-				Computed:     true,
-				Description:  "Image Operating system",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{isImageVolume},
+				Computed:      true,
+				Description:   "Image Operating system",
 			},
 
 			isImageEncryption: {
@@ -155,7 +246,7 @@ func ResourceIBMISImage() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
-				ExactlyOneOf: []string{isImageHref, isImageVolume},
+				ExactlyOneOf: []string{isImageHref, isImageVolume, isImageSourceFile},
 				Description:  "Image volume id",
 			},
 
@@ -267,6 +358,11 @@ func resourceIBMISImageCreate(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return err
 		}
+	} else if sourceFile, ok := d.GetOk(isImageSourceFile); ok {
+		err := imgCreateBySourceFile(d, meta, sourceFile.(string), name, operatingSystem)
+		if err != nil {
+			return err
+		}
 	} else {
 		err := imgCreateByFile(d, meta, href, name, operatingSystem)
 		if err != nil {
@@ -340,6 +436,163 @@ func imgCreateByFile(d *schema.ResourceData, meta interface{}, href, name, opera
 	}
 	return nil
 }
+
+// imgCreateBySourceFile uploads the local source_file to the cos_staging
+// bucket, creates the image from the uploaded object and verifies the checksum
+// of the image against the checksum of the file.
+func imgCreateBySourceFile(d *schema.ResourceData, meta interface{}, sourceFile, name, operatingSystem string) error {
+	staging := d.Get(isImageCosStaging).([]interface{})[0].(map[string]interface{})
+	bucketName := staging["bucket_name"].(string)
+	bucketRegion := staging["bucket_region"].(string)
+	key := staging["key"].(string)
+	if key == "" {
+		key = filepath.Base(sourceFile)
+	}
+
+	bxSession, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil {
+		return err
+	}
+	s3Client, err := cos.GetS3Client(bxSession, bucketRegion, staging["endpoint_type"].(string), staging["resource_instance_id"].(string))
+	if err != nil {
+		return err
+	}
+	checksum, version, err := imgUploadSourceFile(s3Client, sourceFile, bucketName, key)
+	if err != nil {
+		return err
+	}
+	if expected, ok := d.GetOk(isImageSourceFileSha256); ok && expected.(string) != checksum {
+		return fmt.Errorf("[ERROR] source_file %s has the SHA256 checksum %s, not the source_file_sha256 %s, the staging object %s of bucket %s is kept", sourceFile, checksum, expected.(string), key, bucketName)
+	}
+	d.Set(isImageSourceFileSha256, checksum)
+	d.Set(isImageSourceFileVersion, version)
+
+	href := fmt.Sprintf("cos://%s/%s/%s", bucketRegion, bucketName, key)
+	err = imgCreateByFile(d, meta, href, name, operatingSystem)
+	if err != nil {
+		return err
+	}
+
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+	getImageOptions := &vpcv1.GetImageOptions{
+		ID: core.StringPtr(d.Id()),
+	}
+	image, response, err := sess.GetImage(getImageOptions)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error Getting Image (%s): %s\n%s", d.Id(), err, response)
+	}
+	if *image.Status != "available" {
+		return fmt.Errorf("[ERROR] Image (%s) created from %s is %s, the staging object is kept", d.Id(), href, *image.Status)
+	}
+	if image.File != nil && image.File.Checksums != nil && image.File.Checksums.Sha256 != nil && *image.File.Checksums.Sha256 != checksum {
+		return fmt.Errorf("[ERROR] Image (%s) checksum %s does not match the SHA256 checksum %s of %s", d.Id(), *image.File.Checksums.Sha256, checksum, sourceFile)
+	}
+
+	if staging["delete_staging_object"].(bool) {
+		deleteObjectInput := &s3.DeleteObjectInput{
+			Bucket: aws.String(bucketName),
+			Key:    aws.String(key),
+		}
+		_, err = s3Client.DeleteObject(deleteObjectInput)
+		if err != nil {
+			log.Printf(
+				"[WARN] Error deleting the staging object %s of resource vpc Image (%s): %s", href, d.Id(), err)
+		}
+	}
+	return nil
+}
+
+// imgUploadSourceFile uploads the file to the bucket with a multipart upload
+// and returns its SHA256 checksum, computed while the file is read for the
+// upload, and its version. The size of the uploaded object is verified
+// against the size of the file.
+func imgUploadSourceFile(s3Client *s3.S3, sourceFile, bucketName, key string) (string, string, error) {
+	file, err := os.Open(sourceFile)
+	if err != nil {
+		return "", "", fmt.Errorf("[ERROR] Error opening source_file %s: %s", sourceFile, err)
+	}
+	defer file.Close()
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return "", "", fmt.Errorf("[ERROR] Error reading source_file %s: %s", sourceFile, err)
+	}
+
+	log.Printf("[INFO] Uploading %s (%d bytes) to bucket %s as %s", sourceFile, fileInfo.Size(), bucketName, key)
+	uploader := s3manager.NewUploaderWithClient(s3Client, func(u *s3manager.Uploader) {
+		u.PartSize = isImageUploadPartSize
+	})
+	// The parts are read in order from the tee, so the hash covers the file
+	// as it was uploaded.
+	hash := sha256.New()
+	uploadInput := &s3manager.UploadInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+		Body:   io.TeeReader(file, hash),
+	}
+	if _, err = uploader.Upload(uploadInput); err != nil {
+		return "", "", fmt.Errorf("[ERROR] Error uploading source_file %s to bucket %s: %s", sourceFile, bucketName, err)
+	}
+
+	headObjectInput := &s3.HeadObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	}
+	object, err := s3Client.HeadObject(headObjectInput)
+	if err != nil {
+		return "", "", fmt.Errorf("[ERROR] Error reading object %s of bucket %s: %s", key, bucketName, err)
+	}
+	if aws.Int64Value(object.ContentLength) != fileInfo.Size() {
+		return "", "", fmt.Errorf("[ERROR] Object %s of bucket %s has %d bytes, source_file %s has %d bytes", key, bucketName, aws.Int64Value(object.ContentLength), sourceFile, fileInfo.Size())
+	}
+	return hex.EncodeToString(hash.Sum(nil)), imgSourceFileVersion(fileInfo), nil
+}
+
+// imgSourceFileVersion identifies the content of the source_file by its size
+// and modification time, which is cheap to compare on every plan.
+func imgSourceFileVersion(fileInfo os.FileInfo) string {
+	return fmt.Sprintf("%d-%d", fileInfo.Size(), fileInfo.ModTime().UnixNano())
+}
+
+// resourceIBMISImageSourceFileCustomizeDiff replaces the image when the
+// source_file changes. A source_file_sha256 in the configuration identifies
+// the content, otherwise the size and modification time of the file do. A
+// source_file that no longer exists after the image is created is ignored.
+func resourceIBMISImageSourceFileCustomizeDiff(diff *schema.ResourceDiff) error {
+	if !diff.NewValueKnown(isImageSourceFile) {
+		return nil
+	}
+	sourceFile := diff.Get(isImageSourceFile).(string)
+	if sourceFile == "" {
+		return nil
+	}
+	if raw := diff.GetRawConfig(); !raw.IsNull() && !raw.GetAttr(isImageSourceFileSha256).IsNull() {
+		return nil
+	}
+	fileInfo, err := os.Stat(sourceFile)
+	if err != nil {
+		if diff.Id() != "" && os.IsNotExist(err) {
+			log.Printf("[DEBUG] source_file %s of resource vpc Image (%s) does not exist", sourceFile, diff.Id())
+			return nil
+		}
+		return fmt.Errorf("[ERROR] Error reading source_file %s: %s", sourceFile, err)
+	}
+	// The version is only known for images created from the source_file.
+	version := imgSourceFileVersion(fileInfo)
+	if old := diff.Get(isImageSourceFileVersion).(string); diff.Id() == "" || old == "" || old == version {
+		return nil
+	}
+	if err = diff.SetNew(isImageSourceFileVersion, version); err != nil {
+		return err
+	}
+	if err = diff.SetNewComputed(isImageSourceFileSha256); err != nil {
+		return err
+	}
+	return diff.ForceNew(isImageSourceFileVersion)
+}
+
 func imgCreateByVolume(d *schema.ResourceData, meta interface{}, name, volume string) error {
 	sess, err := vpcClient(meta)
 	if err != nil {
@@ -106,6 +106,33 @@ func TestAccIBMISImage_encrypted(t *testing.T) {
 		},
 	})
 }
+func TestAccIBMISImage_sourceFile(t *testing.T) {
+	var image string
+	name := fmt.Sprintf("tfimg-name-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheckImageSourceFile(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: checkImageDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISImageSourceFileConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISImageExists("ibm_is_image.isExampleImageSourceFile", image),
+					resource.TestCheckResourceAttr(
+						"ibm_is_image.isExampleImageSourceFile", "name", name),
+					resource.TestCheckResourceAttr(
+						"ibm_is_image.isExampleImageSourceFile", "status", "available"),
+					resource.TestCheckResourceAttrPair(
+						"ibm_is_image.isExampleImageSourceFile", "checksum", "ibm_is_image.isExampleImageSourceFile", "source_file_sha256"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_is_image.isExampleImageSourceFile", "source_file_version"),
+				),
+			},
+		},
+	})
+}
+
 func checkImageDestroy(s *terraform.State) error {
 
 	sess, _ := acc.TestAccProvider.Meta().(conns.ClientSession).VpcV1API()
@@ -235,3 +262,19 @@ func testAccCheckIBMISImageEncryptedConfig(name string) string {
 		}
 		`, acc.IsImageEncryptedDataKey, acc.IsImageEncryptionKey, acc.Image_cos_url_encrypted, name, acc.Image_operating_system)
 }
+
+func testAccCheckIBMISImageSourceFileConfig(name string) string {
+	return fmt.Sprintf(`
+		resource "ibm_is_image" "isExampleImageSourceFile" {
+			source_file = "%s"
+			name = "%s"
+			operating_system = "%s"
+			cos_staging {
+				resource_instance_id = "%s"
+				bucket_name = "%s"
+				bucket_region = "%s"
+				delete_staging_object = true
+			}
+		}
+	`, acc.Image_source_file, name, acc.Image_operating_system, acc.CosCRN, acc.IsCosBucketName, acc.RegionName)
+}
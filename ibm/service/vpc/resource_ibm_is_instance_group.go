@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -29,6 +30,14 @@ const (
 	isInstanceGroupAccessTags    = "access_tags"
 	isInstanceGroupUserTagType   = "user"
 	isInstanceGroupAccessTagType = "access"
+
+	isInstanceGroupUpdatePolicy                        = "update_policy"
+	isInstanceGroupUpdatePolicyBatchSize               = "batch_size"
+	isInstanceGroupUpdatePolicyMaxUnavailable          = "max_unavailable"
+	isInstanceGroupUpdatePolicyWaitForPoolMemberHealth = "wait_for_pool_member_health"
+	isInstanceGroupUpdatePolicyPauseSeconds            = "pause_seconds"
+	isInstanceGroupRolloutReplacing                    = "replacing"
+	isInstanceGroupRolloutReplaced                     = "replaced"
 )
 
 func ResourceIBMISInstanceGroup() *schema.Resource {
@@ -165,6 +174,44 @@ func ResourceIBMISInstanceGroup() *schema.Resource {
 				Set:         flex.ResourceIBMVPCHash,
 				Description: "List of access management tags",
 			},
+
+			isInstanceGroupUpdatePolicy: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Replace the existing memberships in batches when the instance template changes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						isInstanceGroupUpdatePolicyBatchSize: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "The number of memberships that are replaced at a time",
+						},
+						isInstanceGroupUpdatePolicyMaxUnavailable: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "The maximum number of memberships that can be unavailable during the replacement",
+						},
+						isInstanceGroupUpdatePolicyWaitForPoolMemberHealth: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Wait for the load balancer pool members of the new memberships to be healthy before the next batch",
+						},
+						isInstanceGroupUpdatePolicyPauseSeconds: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "The number of seconds to pause between batches",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -391,6 +438,18 @@ func resourceIBMISInstanceGroupUpdate(d *schema.ResourceData, meta interface{})
 			return healthError
 		}
 	}
+
+	if d.HasChange("instance_template") {
+		if v, ok := d.GetOk(isInstanceGroupUpdatePolicy); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+			err = replaceInstanceGroupMemberships(sess, d.Id(), d.Get("instance_template").(string), v.([]interface{})[0].(map[string]interface{}), d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				// Keep the old instance template in state, so that the next
+				// apply resumes the replacement
+				d.Partial(true)
+				return err
+			}
+		}
+	}
 	return resourceIBMISInstanceGroupRead(d, meta)
 }
 
@@ -411,7 +470,25 @@ func resourceIBMISInstanceGroupRead(d *schema.ResourceData, meta interface{}) er
 		return fmt.Errorf("[ERROR] Error Getting InstanceGroup: %s\n%s", err, response)
 	}
 	d.Set("name", *instanceGroup.Name)
-	d.Set("instance_template", *instanceGroup.InstanceTemplate.ID)
+	instanceTemplate := *instanceGroup.InstanceTemplate.ID
+	if v, ok := d.GetOk(isInstanceGroupUpdatePolicy); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		// Memberships left from a replacement that did not complete are shown
+		// as a change of instance_template, so that the next apply resumes it
+		rollout := &instanceGroupRollout{
+			sess:               sess,
+			instanceGroupID:    instanceGroupID,
+			instanceTemplateID: instanceTemplate,
+		}
+		memberships, err := rollout.listMemberships()
+		if err != nil {
+			return err
+		}
+		if stale, _ := rollout.staleMemberships(memberships, nil); len(stale) > 0 {
+			log.Printf("[WARN] %d memberships of instance group (%s) are not created from instance template (%s)", len(stale), instanceGroupID, instanceTemplate)
+			instanceTemplate = *stale[0].InstanceTemplate.ID
+		}
+	}
+	d.Set("instance_template", instanceTemplate)
 	d.Set("instances", *instanceGroup.MembershipCount)
 	d.Set("instance_count", *instanceGroup.MembershipCount)
 	d.Set("resource_group", *instanceGroup.ResourceGroup.ID)
@@ -613,3 +690,259 @@ func waitForInstanceGroupDelete(d *schema.ResourceData, meta interface{}) (inter
 	return healthStateConf.WaitForState()
 
 }
+
+// instanceGroupRollout replaces the memberships of an instance group that were
+// created from another instance template than the current one.
+type instanceGroupRollout struct {
+	sess               *vpcv1.VpcV1
+	instanceGroupID    string
+	instanceTemplateID string
+	loadBalancerID     string
+	poolID             string
+}
+
+// replaceInstanceGroupMemberships deletes the stale memberships in batches,
+// the instance group recreates them from the current instance template. A
+// batch is only deleted when the replacements of the previous batch are
+// healthy, and it is capped so that no more than max_unavailable memberships
+// are unavailable. Stale memberships that are already unavailable are
+// replaced first, as replacing them does not reduce the capacity.
+func replaceInstanceGroupMemberships(sess *vpcv1.VpcV1, instanceGroupID, instanceTemplateID string, updatePolicy map[string]interface{}, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	batchSize := updatePolicy[isInstanceGroupUpdatePolicyBatchSize].(int)
+	maxUnavailable := updatePolicy[isInstanceGroupUpdatePolicyMaxUnavailable].(int)
+	pause := time.Duration(updatePolicy[isInstanceGroupUpdatePolicyPauseSeconds].(int)) * time.Second
+
+	rollout := &instanceGroupRollout{
+		sess:               sess,
+		instanceGroupID:    instanceGroupID,
+		instanceTemplateID: instanceTemplateID,
+	}
+	if updatePolicy[isInstanceGroupUpdatePolicyWaitForPoolMemberHealth].(bool) {
+		getInstanceGroupOptions := vpcv1.GetInstanceGroupOptions{ID: &instanceGroupID}
+		instanceGroup, response, err := sess.GetInstanceGroup(&getInstanceGroupOptions)
+		if err != nil || instanceGroup == nil {
+			return fmt.Errorf("[ERROR] Error Getting InstanceGroup: %s\n%s", err, response)
+		}
+		if instanceGroup.LoadBalancerPool != nil {
+			// The sixth component is the Load Balancer ID
+			rollout.loadBalancerID = strings.Split(*instanceGroup.LoadBalancerPool.Href, "/")[5]
+			rollout.poolID = *instanceGroup.LoadBalancerPool.ID
+		}
+	}
+
+	for {
+		memberships, err := rollout.listMemberships()
+		if err != nil {
+			return err
+		}
+		available, err := rollout.availability(memberships)
+		if err != nil {
+			return err
+		}
+		stale, staleUnavailable := rollout.staleMemberships(memberships, available)
+		if len(stale) == 0 {
+			log.Printf("[INFO] All memberships of instance group (%s) use instance template (%s)", instanceGroupID, instanceTemplateID)
+			return nil
+		}
+		// Memberships that are being deleted are no longer part of the
+		// instance group, they are neither waited for nor unavailable.
+		active := activeInstanceGroupMemberships(memberships)
+		unavailable := active - len(available)
+		count := instanceGroupRolloutBatchSize(len(stale), staleUnavailable, unavailable, batchSize, maxUnavailable)
+		if count <= 0 {
+			log.Printf("[INFO] %d memberships of instance group (%s) are unavailable, waiting before the next batch", unavailable, instanceGroupID)
+			if _, err = rollout.waitForAvailable(nil, active, maxUnavailable-1, time.Until(deadline)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		deleted := make(map[string]bool, count)
+		for _, membership := range stale[:count] {
+			log.Printf("[INFO] Replacing membership (%s) of instance group (%s) created from instance template (%s)", *membership.ID, instanceGroupID, *membership.InstanceTemplate.ID)
+			deleteInstanceGroupMembershipOptions := vpcv1.DeleteInstanceGroupMembershipOptions{
+				ID:              membership.ID,
+				InstanceGroupID: &instanceGroupID,
+			}
+			response, err := sess.DeleteInstanceGroupMembership(&deleteInstanceGroupMembershipOptions)
+			if err != nil && (response == nil || response.StatusCode != 404) {
+				return fmt.Errorf("[ERROR] Error Deleting the InstanceGroup Membership (%s): %s\n%s", *membership.ID, err, response)
+			}
+			deleted[*membership.ID] = true
+		}
+		if _, err = rollout.waitForAvailable(deleted, active, 0, time.Until(deadline)); err != nil {
+			return err
+		}
+		if pause > 0 && len(stale) > count {
+			log.Printf("[INFO] Pausing %s before the next batch of instance group (%s)", pause, instanceGroupID)
+			time.Sleep(pause)
+		}
+	}
+}
+
+// instanceGroupRolloutBatchSize returns the number of stale memberships that
+// are replaced in the next batch, at most batchSize. The unavailable ones are
+// replaced in any case, the available ones only as long as no more than
+// maxUnavailable memberships are unavailable.
+func instanceGroupRolloutBatchSize(stale, staleUnavailable, unavailable, batchSize, maxUnavailable int) int {
+	count := staleUnavailable
+	if maxUnavailable > unavailable {
+		count += maxUnavailable - unavailable
+	}
+	if batchSize < count {
+		count = batchSize
+	}
+	if stale < count {
+		count = stale
+	}
+	return count
+}
+
+func (r *instanceGroupRollout) listMemberships() ([]vpcv1.InstanceGroupMembership, error) {
+	start := ""
+	allrecs := []vpcv1.InstanceGroupMembership{}
+	for {
+		listInstanceGroupMembershipsOptions := vpcv1.ListInstanceGroupMembershipsOptions{
+			InstanceGroupID: &r.instanceGroupID,
+		}
+		if start != "" {
+			listInstanceGroupMembershipsOptions.Start = &start
+		}
+		instanceGroupMembershipCollection, response, err := r.sess.ListInstanceGroupMemberships(&listInstanceGroupMembershipsOptions)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Error Getting InstanceGroup Membership Collection %s\n%s", err, response)
+		}
+		start = flex.GetNext(instanceGroupMembershipCollection.Next)
+		allrecs = append(allrecs, instanceGroupMembershipCollection.Memberships...)
+		if start == "" {
+			break
+		}
+	}
+	return allrecs, nil
+}
+
+// activeInstanceGroupMemberships returns the number of memberships that are
+// not being deleted.
+func activeInstanceGroupMemberships(memberships []vpcv1.InstanceGroupMembership) int {
+	active := 0
+	for _, membership := range memberships {
+		if *membership.Status != vpcv1.InstanceGroupMembershipStatusDeletingConst {
+			active++
+		}
+	}
+	return active
+}
+
+// staleMemberships returns the memberships created from another instance
+// template, the unavailable ones first, and the number of unavailable ones.
+func (r *instanceGroupRollout) staleMemberships(memberships []vpcv1.InstanceGroupMembership, available map[string]bool) ([]vpcv1.InstanceGroupMembership, int) {
+	stale := []vpcv1.InstanceGroupMembership{}
+	staleAvailable := []vpcv1.InstanceGroupMembership{}
+	for _, membership := range memberships {
+		if *membership.Status == vpcv1.InstanceGroupMembershipStatusDeletingConst {
+			continue
+		}
+		if membership.InstanceTemplate == nil || *membership.InstanceTemplate.ID == r.instanceTemplateID {
+			continue
+		}
+		if available[*membership.ID] {
+			staleAvailable = append(staleAvailable, membership)
+		} else {
+			stale = append(stale, membership)
+		}
+	}
+	return append(stale, staleAvailable...), len(stale)
+}
+
+// membershipAvailable reports whether the membership is healthy, and its load
+// balancer pool member is healthy when the rollout waits for it.
+func (r *instanceGroupRollout) membershipAvailable(membership vpcv1.InstanceGroupMembership) (bool, error) {
+	if *membership.Status != vpcv1.InstanceGroupMembershipStatusHealthyConst {
+		return false, nil
+	}
+	if r.poolID == "" {
+		return true, nil
+	}
+	if membership.PoolMember == nil {
+		return false, nil
+	}
+	getLoadBalancerPoolMemberOptions := &vpcv1.GetLoadBalancerPoolMemberOptions{
+		LoadBalancerID: &r.loadBalancerID,
+		PoolID:         &r.poolID,
+		ID:             membership.PoolMember.ID,
+	}
+	poolMember, response, err := r.sess.GetLoadBalancerPoolMember(getLoadBalancerPoolMemberOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("[ERROR] Error Getting Load Balancer Pool Member (%s): %s\n%s", *membership.PoolMember.ID, err, response)
+	}
+	return *poolMember.ProvisioningStatus == "active" && *poolMember.Health == "ok", nil
+}
+
+// availability returns the IDs of the available memberships.
+func (r *instanceGroupRollout) availability(memberships []vpcv1.InstanceGroupMembership) (map[string]bool, error) {
+	available := make(map[string]bool, len(memberships))
+	for _, membership := range memberships {
+		ok, err := r.membershipAvailable(membership)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			available[*membership.ID] = true
+		}
+	}
+	return available, nil
+}
+
+// waitForAvailable waits until the deleted memberships are gone, the instance
+// group has at least count memberships again and no more than maxUnavailable
+// of the memberships created from the current instance template are
+// unavailable. A replacement that fails stops the rollout.
+func (r *instanceGroupRollout) waitForAvailable(deleted map[string]bool, count, maxUnavailable int, timeout time.Duration) (interface{}, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{isInstanceGroupRolloutReplacing},
+		Target:  []string{isInstanceGroupRolloutReplaced},
+		Refresh: func() (interface{}, string, error) {
+			memberships, err := r.listMemberships()
+			if err != nil {
+				return nil, "", err
+			}
+			for _, membership := range memberships {
+				if deleted[*membership.ID] {
+					return memberships, isInstanceGroupRolloutReplacing, nil
+				}
+				if *membership.Status == vpcv1.InstanceGroupMembershipStatusFailedConst && membership.InstanceTemplate != nil && *membership.InstanceTemplate.ID == r.instanceTemplateID {
+					return nil, "", fmt.Errorf("[ERROR] Membership (%s) of instance group (%s) created from instance template (%s) failed", *membership.ID, r.instanceGroupID, r.instanceTemplateID)
+				}
+			}
+			current := activeInstanceGroupMemberships(memberships)
+			if current < count {
+				return memberships, isInstanceGroupRolloutReplacing, nil
+			}
+			available, err := r.availability(memberships)
+			if err != nil {
+				return nil, "", err
+			}
+			unavailable := 0
+			for _, membership := range memberships {
+				if membership.InstanceTemplate != nil && *membership.InstanceTemplate.ID == r.instanceTemplateID && !available[*membership.ID] {
+					unavailable++
+				}
+			}
+			log.Printf("[DEBUG] Instance group (%s) has %d memberships, %d from instance template (%s) unavailable", r.instanceGroupID, current, unavailable, r.instanceTemplateID)
+			if unavailable > maxUnavailable {
+				return memberships, isInstanceGroupRolloutReplacing, nil
+			}
+			return memberships, isInstanceGroupRolloutReplaced, nil
+		},
+		Timeout:      timeout,
+		Delay:        20 * time.Second,
+		MinTimeout:   5 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
@@ -0,0 +1,77 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+)
+
+func TestInstanceGroupRolloutBatchSize(t *testing.T) {
+	testCases := []struct {
+		name             string
+		stale            int
+		staleUnavailable int
+		unavailable      int
+		batchSize        int
+		maxUnavailable   int
+		want             int
+	}{
+		{name: "one at a time", stale: 4, batchSize: 1, maxUnavailable: 1, want: 1},
+		{name: "capped by max unavailable", stale: 4, batchSize: 3, maxUnavailable: 2, want: 2},
+		{name: "capped by batch size", stale: 4, batchSize: 2, maxUnavailable: 3, want: 2},
+		{name: "capped by stale memberships", stale: 1, batchSize: 3, maxUnavailable: 3, want: 1},
+		{name: "already unavailable", stale: 4, unavailable: 1, batchSize: 3, maxUnavailable: 2, want: 1},
+		{name: "no capacity left", stale: 4, unavailable: 2, batchSize: 3, maxUnavailable: 2, want: 0},
+		{name: "unavailable stale memberships", stale: 4, staleUnavailable: 2, unavailable: 2, batchSize: 3, maxUnavailable: 1, want: 2},
+		{name: "unavailable stale memberships capped by batch size", stale: 4, staleUnavailable: 3, unavailable: 3, batchSize: 2, maxUnavailable: 1, want: 2},
+		{name: "unavailable stale memberships and capacity", stale: 4, staleUnavailable: 1, unavailable: 1, batchSize: 3, maxUnavailable: 2, want: 2},
+		{name: "no max unavailable", stale: 4, batchSize: 2, maxUnavailable: 0, want: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := instanceGroupRolloutBatchSize(tc.stale, tc.staleUnavailable, tc.unavailable, tc.batchSize, tc.maxUnavailable)
+			if got != tc.want {
+				t.Fatalf("got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInstanceGroupStaleMemberships(t *testing.T) {
+	membership := func(id, status, instanceTemplateID string) vpcv1.InstanceGroupMembership {
+		return vpcv1.InstanceGroupMembership{
+			ID:               core.StringPtr(id),
+			Status:           core.StringPtr(status),
+			InstanceTemplate: &vpcv1.InstanceTemplateReference{ID: core.StringPtr(instanceTemplateID)},
+		}
+	}
+	memberships := []vpcv1.InstanceGroupMembership{
+		membership("current", vpcv1.InstanceGroupMembershipStatusHealthyConst, "new"),
+		membership("available", vpcv1.InstanceGroupMembershipStatusHealthyConst, "old"),
+		membership("unhealthy", vpcv1.InstanceGroupMembershipStatusUnhealthyConst, "old"),
+		membership("deleting", vpcv1.InstanceGroupMembershipStatusDeletingConst, "old"),
+		membership("pending", vpcv1.InstanceGroupMembershipStatusPendingConst, "new"),
+	}
+	rollout := &instanceGroupRollout{instanceTemplateID: "new"}
+
+	stale, staleUnavailable := rollout.staleMemberships(memberships, map[string]bool{"current": true, "available": true})
+	ids := []string{}
+	for _, membership := range stale {
+		ids = append(ids, *membership.ID)
+	}
+	// The unavailable memberships come first, the ones being deleted are not
+	// stale anymore
+	if !reflect.DeepEqual(ids, []string{"unhealthy", "available"}) || staleUnavailable != 1 {
+		t.Fatalf("got stale memberships %v with %d unavailable, want [unhealthy available] with 1 unavailable", ids, staleUnavailable)
+	}
+
+	if active := activeInstanceGroupMemberships(memberships); active != 4 {
+		t.Fatalf("got %d active memberships, want 4", active)
+	}
+}
@@ -7,12 +7,16 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"reflect"
+	"strings"
 	"time"
 
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -23,6 +27,7 @@ const (
 	isSecurityGroupName          = "name"
 	isSecurityGroupVPC           = "vpc"
 	isSecurityGroupRules         = "rules"
+	isSecurityGroupRule          = "rule"
 	isSecurityGroupResourceGroup = "resource_group"
 	isSecurityGroupTags          = "tags"
 	isSecurityGroupAccessTags    = "access_tags"
@@ -49,6 +54,10 @@ func ResourceIBMISSecurityGroup() *schema.Resource {
 				func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
 					return flex.ResourceValidateAccessTags(diff, v)
 				}),
+			customdiff.Sequence(
+				func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+					return resourceIBMISSecurityGroupRuleCustomizeDiff(diff)
+				}),
 		),
 
 		Timeouts: &schema.ResourceTimeout{
@@ -105,6 +114,16 @@ func ResourceIBMISSecurityGroup() *schema.Resource {
 				},
 			},
 
+			isSecurityGroupRule: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Set:         resourceIBMISSecurityGroupRuleHash,
+				Description: "Authoritative set of the rules of the security group. When set, rules that are not in the set are removed from the security group",
+				Elem: &schema.Resource{
+					Schema: makeIBMISSecurityGroupInlineRuleSchema(),
+				},
+			},
+
 			isSecurityGroupResourceGroup: {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -200,6 +219,12 @@ func resourceIBMISSecurityGroupCreate(d *schema.ResourceData, meta interface{})
 		name = nm.(string)
 		createSecurityGroupOptions.Name = &name
 	}
+	if rules, ok := d.GetOk(isSecurityGroupRule); ok {
+		for _, ruleIntf := range rules.(*schema.Set).List() {
+			rule := expandSecurityGroupInlineRule(ruleIntf.(map[string]interface{}))
+			createSecurityGroupOptions.Rules = append(createSecurityGroupOptions.Rules, rule.prototype())
+		}
+	}
 	sg, response, err := sess.CreateSecurityGroup(createSecurityGroupOptions)
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error while creating Security Group %s\n%s", err, response)
@@ -347,6 +372,19 @@ func resourceIBMISSecurityGroupRead(d *schema.ResourceData, meta interface{}) er
 		}
 	}
 	d.Set(isSecurityGroupRules, rules)
+	// The inline rules are only refreshed when they are managed, so that the
+	// rules of ibm_is_security_group_rule resources do not show as drift.
+	if _, ok := d.GetOk(isSecurityGroupRule); ok {
+		inlineRules := make([]interface{}, 0, len(group.Rules))
+		for _, rule := range group.Rules {
+			if inlineRule, _, ok := flattenSecurityGroupInlineRule(rule); ok {
+				inlineRules = append(inlineRules, inlineRule.flatten())
+			}
+		}
+		if err := d.Set(isSecurityGroupRule, inlineRules); err != nil {
+			return fmt.Errorf("[ERROR] Error setting Security Group rule: %s", err)
+		}
+	}
 	d.SetId(*group.ID)
 	if group.ResourceGroup != nil {
 		d.Set(isSecurityGroupResourceGroup, group.ResourceGroup.ID)
@@ -387,6 +425,13 @@ func resourceIBMISSecurityGroupUpdate(d *schema.ResourceData, meta interface{})
 				"Error on update of Security Group (%s) access tags: %s", d.Id(), err)
 		}
 	}
+	if d.HasChange(isSecurityGroupRule) {
+		oldRules, newRules := d.GetChange(isSecurityGroupRule)
+		err := resourceIBMISSecurityGroupReconcileRules(sess, id, oldRules.(*schema.Set), newRules.(*schema.Set))
+		if err != nil {
+			return err
+		}
+	}
 	if d.HasChange(isSecurityGroupName) {
 		name = d.Get(isSecurityGroupName).(string)
 		hasChanged = true
@@ -646,3 +691,370 @@ func isSgRefreshFunc(client *vpcv1.VpcV1, sgId string, groups []vpcv1.SecurityGr
 		return allrecs, "deleting", nil
 	}
 }
+
+func makeIBMISSecurityGroupInlineRuleSchema() map[string]*schema.Schema {
+	ports := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					isSecurityGroupRulePortMin: {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      1,
+						ValidateFunc: validate.InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRulePortMin),
+					},
+					isSecurityGroupRulePortMax: {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      65535,
+						ValidateFunc: validate.InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRulePortMax),
+					},
+				},
+			},
+		}
+	}
+	tcp := ports()
+	tcp.Description = "protocol=tcp"
+	udp := ports()
+	udp.Description = "protocol=udp"
+
+	return map[string]*schema.Schema{
+		isSecurityGroupRuleDirection: {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Direction of traffic to enforce, either inbound or outbound",
+			ValidateFunc: validate.InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRuleDirection),
+		},
+		isSecurityGroupRuleIPVersion: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      isSecurityGroupRuleIPVersionDefault,
+			Description:  "IP version: ipv4",
+			ValidateFunc: validate.InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRuleIPVersion),
+		},
+		isSecurityGroupRuleRemote: {
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressSecurityGroupRuleRemoteDiff,
+			Description:      "Security group id: an IP address, a CIDR block, or a single security group identifier. All addresses when not set",
+		},
+		isSecurityGroupRuleProtocolICMP: {
+			Type:        schema.TypeList,
+			MaxItems:    1,
+			Optional:    true,
+			Description: "protocol=icmp",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					// -1 stands for an unset type or code, as 0 is a valid
+					// value of both.
+					isSecurityGroupRuleType: {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      -1,
+						ValidateFunc: validate.InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRuleType),
+					},
+					isSecurityGroupRuleCode: {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      -1,
+						ValidateFunc: validate.InvokeValidator("ibm_is_security_group_rule", isSecurityGroupRuleCode),
+					},
+				},
+			},
+		},
+		isSecurityGroupRuleProtocolTCP: tcp,
+		isSecurityGroupRuleProtocolUDP: udp,
+	}
+}
+
+// securityGroupInlineRule is the normalized form of a security group rule.
+// Unused int64 fields are set to -1, and a rule without remote has the
+// 0.0.0.0/0 remote that the API reports for it.
+type securityGroupInlineRule struct {
+	direction string
+	ipVersion string
+	protocol  string
+	remote    string
+	icmpType  int64
+	icmpCode  int64
+	portMin   int64
+	portMax   int64
+}
+
+func (rule securityGroupInlineRule) key() string {
+	return fmt.Sprintf("%s/%s/%s/%s/%d/%d/%d/%d", rule.direction, rule.ipVersion, rule.protocol, rule.remote,
+		rule.icmpType, rule.icmpCode, rule.portMin, rule.portMax)
+}
+
+func (rule securityGroupInlineRule) prototype() *vpcv1.SecurityGroupRulePrototype {
+	prototype := &vpcv1.SecurityGroupRulePrototype{
+		Direction: core.StringPtr(rule.direction),
+		IPVersion: core.StringPtr(rule.ipVersion),
+		Protocol:  core.StringPtr(rule.protocol),
+	}
+	address, cidr, id, _ := inferRemoteSecurityGroup(rule.remote)
+	if address != "" {
+		prototype.Remote = &vpcv1.SecurityGroupRuleRemotePrototype{Address: core.StringPtr(address)}
+	} else if cidr != "" {
+		prototype.Remote = &vpcv1.SecurityGroupRuleRemotePrototype{CIDRBlock: core.StringPtr(cidr)}
+	} else {
+		prototype.Remote = &vpcv1.SecurityGroupRuleRemotePrototype{ID: core.StringPtr(id)}
+	}
+	if rule.icmpType != -1 {
+		prototype.Type = core.Int64Ptr(rule.icmpType)
+	}
+	if rule.icmpCode != -1 {
+		prototype.Code = core.Int64Ptr(rule.icmpCode)
+	}
+	if rule.portMin != -1 {
+		prototype.PortMin = core.Int64Ptr(rule.portMin)
+	}
+	if rule.portMax != -1 {
+		prototype.PortMax = core.Int64Ptr(rule.portMax)
+	}
+	return prototype
+}
+
+func (rule securityGroupInlineRule) flatten() map[string]interface{} {
+	r := map[string]interface{}{
+		isSecurityGroupRuleDirection: rule.direction,
+		isSecurityGroupRuleIPVersion: rule.ipVersion,
+		isSecurityGroupRuleRemote:    rule.remote,
+	}
+	switch rule.protocol {
+	case isSecurityGroupRuleProtocolICMP:
+		r[isSecurityGroupRuleProtocolICMP] = []interface{}{map[string]interface{}{
+			isSecurityGroupRuleType: int(rule.icmpType),
+			isSecurityGroupRuleCode: int(rule.icmpCode),
+		}}
+	case isSecurityGroupRuleProtocolTCP, isSecurityGroupRuleProtocolUDP:
+		r[rule.protocol] = []interface{}{map[string]interface{}{
+			isSecurityGroupRulePortMin: int(rule.portMin),
+			isSecurityGroupRulePortMax: int(rule.portMax),
+		}}
+	}
+	return r
+}
+
+func normalizeSecurityGroupRuleRemote(remote string) string {
+	if remote == "" {
+		return "0.0.0.0/0"
+	}
+	if _, ipNet, err := net.ParseCIDR(remote); err == nil {
+		return ipNet.String()
+	}
+	if ip := net.ParseIP(remote); ip != nil {
+		return ip.String()
+	}
+	return remote
+}
+
+func suppressSecurityGroupRuleRemoteDiff(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeSecurityGroupRuleRemote(old) == normalizeSecurityGroupRuleRemote(new)
+}
+
+// expandSecurityGroupInlineRule normalizes a rule of the configuration or the
+// state, so that equivalent rules have the same key.
+func expandSecurityGroupInlineRule(r map[string]interface{}) securityGroupInlineRule {
+	rule := securityGroupInlineRule{
+		direction: strings.ToLower(r[isSecurityGroupRuleDirection].(string)),
+		ipVersion: isSecurityGroupRuleIPVersionDefault,
+		protocol:  "all",
+		icmpType:  -1,
+		icmpCode:  -1,
+		portMin:   -1,
+		portMax:   -1,
+	}
+	if ipVersion, ok := r[isSecurityGroupRuleIPVersion].(string); ok && ipVersion != "" {
+		rule.ipVersion = strings.ToLower(ipVersion)
+	}
+	remote, _ := r[isSecurityGroupRuleRemote].(string)
+	rule.remote = normalizeSecurityGroupRuleRemote(remote)
+
+	if icmp, ok := r[isSecurityGroupRuleProtocolICMP].([]interface{}); ok && len(icmp) > 0 {
+		rule.protocol = isSecurityGroupRuleProtocolICMP
+		if values, ok := icmp[0].(map[string]interface{}); ok {
+			if value, ok := values[isSecurityGroupRuleType].(int); ok {
+				rule.icmpType = int64(value)
+			}
+			if value, ok := values[isSecurityGroupRuleCode].(int); ok {
+				rule.icmpCode = int64(value)
+			}
+		}
+	}
+	for _, protocol := range []string{isSecurityGroupRuleProtocolTCP, isSecurityGroupRuleProtocolUDP} {
+		if ports, ok := r[protocol].([]interface{}); ok && len(ports) > 0 {
+			rule.protocol = protocol
+			rule.portMin = 1
+			rule.portMax = 65535
+			if values, ok := ports[0].(map[string]interface{}); ok {
+				if value, ok := values[isSecurityGroupRulePortMin].(int); ok && value != 0 {
+					rule.portMin = int64(value)
+				}
+				if value, ok := values[isSecurityGroupRulePortMax].(int); ok && value != 0 {
+					rule.portMax = int64(value)
+				}
+			}
+		}
+	}
+	return rule
+}
+
+// flattenSecurityGroupInlineRule returns the normalized form and the ID of a
+// rule returned by the API.
+func flattenSecurityGroupInlineRule(ruleIntf vpcv1.SecurityGroupRuleIntf) (securityGroupInlineRule, string, bool) {
+	rule := securityGroupInlineRule{
+		icmpType: -1,
+		icmpCode: -1,
+		portMin:  -1,
+		portMax:  -1,
+	}
+	var id string
+	var remoteIntf vpcv1.SecurityGroupRuleRemoteIntf
+	switch r := ruleIntf.(type) {
+	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolIcmp:
+		id, rule.direction, rule.ipVersion, rule.protocol, remoteIntf = *r.ID, *r.Direction, *r.IPVersion, *r.Protocol, r.Remote
+		if r.Type != nil {
+			rule.icmpType = *r.Type
+		}
+		if r.Code != nil {
+			rule.icmpCode = *r.Code
+		}
+	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll:
+		id, rule.direction, rule.ipVersion, rule.protocol, remoteIntf = *r.ID, *r.Direction, *r.IPVersion, *r.Protocol, r.Remote
+	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp:
+		id, rule.direction, rule.ipVersion, rule.protocol, remoteIntf = *r.ID, *r.Direction, *r.IPVersion, *r.Protocol, r.Remote
+		rule.portMin, rule.portMax = 1, 65535
+		if r.PortMin != nil {
+			rule.portMin = *r.PortMin
+		}
+		if r.PortMax != nil {
+			rule.portMax = *r.PortMax
+		}
+	default:
+		return rule, "", false
+	}
+	rule.direction = strings.ToLower(rule.direction)
+	rule.ipVersion = strings.ToLower(rule.ipVersion)
+	rule.protocol = strings.ToLower(rule.protocol)
+	remote := ""
+	if r, ok := remoteIntf.(*vpcv1.SecurityGroupRuleRemote); ok && r != nil {
+		if r.ID != nil {
+			remote = *r.ID
+		} else if r.Address != nil {
+			remote = *r.Address
+		} else if r.CIDRBlock != nil {
+			remote = *r.CIDRBlock
+		}
+	}
+	rule.remote = normalizeSecurityGroupRuleRemote(remote)
+	return rule, id, true
+}
+
+func resourceIBMISSecurityGroupRuleHash(v interface{}) int {
+	return conns.String(expandSecurityGroupInlineRule(v.(map[string]interface{})).key())
+}
+
+func resourceIBMISSecurityGroupRuleCustomizeDiff(diff *schema.ResourceDiff) error {
+	rules, ok := diff.GetOk(isSecurityGroupRule)
+	if !ok {
+		return nil
+	}
+	for _, ruleIntf := range rules.(*schema.Set).List() {
+		r := ruleIntf.(map[string]interface{})
+		protocols := 0
+		for _, protocol := range []string{isSecurityGroupRuleProtocolICMP, isSecurityGroupRuleProtocolTCP, isSecurityGroupRuleProtocolUDP} {
+			if blocks, ok := r[protocol].([]interface{}); ok && len(blocks) > 0 {
+				protocols++
+			}
+		}
+		if protocols > 1 {
+			return fmt.Errorf("[ERROR] Security Group rule %s can have only one of icmp, tcp or udp", expandSecurityGroupInlineRule(r).key())
+		}
+		rule := expandSecurityGroupInlineRule(r)
+		if rule.icmpCode != -1 && rule.icmpType == -1 {
+			return fmt.Errorf("[ERROR] Security Group rule %s: icmp code requires icmp type", rule.key())
+		}
+		if rule.portMin > rule.portMax {
+			return fmt.Errorf("[ERROR] Security Group rule %s: port_min must not be greater than port_max", rule.key())
+		}
+	}
+	return nil
+}
+
+// resourceIBMISSecurityGroupReconcileRules makes the rules of the security
+// group match newRules. Rules that are not in newRules are deleted, including
+// rules created out of band, unless newRules is empty: then the rules are no
+// longer managed and only the rules of oldRules are deleted.
+func resourceIBMISSecurityGroupReconcileRules(sess *vpcv1.VpcV1, id string, oldRules, newRules *schema.Set) error {
+	isSecurityGroupRuleKey := "security_group_rule_key_" + id
+	conns.IbmMutexKV.Lock(isSecurityGroupRuleKey)
+	defer conns.IbmMutexKV.Unlock(isSecurityGroupRuleKey)
+
+	group, response, err := sess.GetSecurityGroup(&vpcv1.GetSecurityGroupOptions{
+		ID: &id,
+	})
+	if err != nil {
+		return fmt.Errorf("[ERROR] Error getting Security Group : %s\n%s", err, response)
+	}
+
+	desired := map[string]securityGroupInlineRule{}
+	for _, ruleIntf := range newRules.List() {
+		rule := expandSecurityGroupInlineRule(ruleIntf.(map[string]interface{}))
+		desired[rule.key()] = rule
+	}
+	previous := map[string]bool{}
+	for _, ruleIntf := range oldRules.List() {
+		previous[expandSecurityGroupInlineRule(ruleIntf.(map[string]interface{})).key()] = true
+	}
+
+	existing := map[string]bool{}
+	deletions := []string{}
+	for _, ruleIntf := range group.Rules {
+		rule, ruleID, ok := flattenSecurityGroupInlineRule(ruleIntf)
+		if !ok {
+			continue
+		}
+		key := rule.key()
+		if _, ok := desired[key]; ok && !existing[key] {
+			existing[key] = true
+			continue
+		}
+		if newRules.Len() == 0 && !previous[key] {
+			continue
+		}
+		deletions = append(deletions, ruleID)
+	}
+
+	// Rules are added before the others are deleted, so that traffic that is
+	// allowed before and after the update is not interrupted.
+	for key, rule := range desired {
+		if existing[key] {
+			continue
+		}
+		options := &vpcv1.CreateSecurityGroupRuleOptions{
+			SecurityGroupID:            &id,
+			SecurityGroupRulePrototype: rule.prototype(),
+		}
+		_, response, err := sess.CreateSecurityGroupRule(options)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Error while creating Security Group Rule %s: %s\n%s", key, err, response)
+		}
+	}
+	for _, ruleID := range deletions {
+		ruleID := ruleID
+		options := &vpcv1.DeleteSecurityGroupRuleOptions{
+			SecurityGroupID: &id,
+			ID:              &ruleID,
+		}
+		response, err := sess.DeleteSecurityGroupRule(options)
+		if err != nil && (response == nil || response.StatusCode != 404) {
+			return fmt.Errorf("[ERROR] Error Deleting Security Group Rule (%s): %s\n%s", ruleID, err, response)
+		}
+	}
+	log.Printf("[DEBUG] Security Group (%s) rules reconciled: %d created, %d deleted", id, len(desired)-len(existing), len(deletions))
+	return nil
+}
@@ -0,0 +1,279 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package vpc
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestExpandSecurityGroupInlineRule(t *testing.T) {
+	icmp := func(icmpType, icmpCode int) []interface{} {
+		return []interface{}{map[string]interface{}{isSecurityGroupRuleType: icmpType, isSecurityGroupRuleCode: icmpCode}}
+	}
+	ports := func(portMin, portMax int) []interface{} {
+		return []interface{}{map[string]interface{}{isSecurityGroupRulePortMin: portMin, isSecurityGroupRulePortMax: portMax}}
+	}
+
+	testCases := []struct {
+		name string
+		rule map[string]interface{}
+		key  string
+	}{
+		{
+			name: "all protocols",
+			rule: map[string]interface{}{isSecurityGroupRuleDirection: "Inbound"},
+			key:  "inbound/ipv4/all/0.0.0.0/0/-1/-1/-1/-1",
+		},
+		{
+			name: "icmp echo reply",
+			rule: map[string]interface{}{isSecurityGroupRuleDirection: "inbound", isSecurityGroupRuleProtocolICMP: icmp(0, 0)},
+			key:  "inbound/ipv4/icmp/0.0.0.0/0/0/0/-1/-1",
+		},
+		{
+			name: "icmp type without code",
+			rule: map[string]interface{}{isSecurityGroupRuleDirection: "inbound", isSecurityGroupRuleProtocolICMP: icmp(8, -1)},
+			key:  "inbound/ipv4/icmp/0.0.0.0/0/8/-1/-1/-1",
+		},
+		{
+			name: "icmp without type and code",
+			rule: map[string]interface{}{isSecurityGroupRuleDirection: "inbound", isSecurityGroupRuleProtocolICMP: icmp(-1, -1)},
+			key:  "inbound/ipv4/icmp/0.0.0.0/0/-1/-1/-1/-1",
+		},
+		{
+			name: "empty icmp block",
+			rule: map[string]interface{}{isSecurityGroupRuleDirection: "inbound", isSecurityGroupRuleProtocolICMP: []interface{}{nil}},
+			key:  "inbound/ipv4/icmp/0.0.0.0/0/-1/-1/-1/-1",
+		},
+		{
+			name: "tcp ports",
+			rule: map[string]interface{}{isSecurityGroupRuleDirection: "outbound", isSecurityGroupRuleProtocolTCP: ports(22, 22)},
+			key:  "outbound/ipv4/tcp/0.0.0.0/0/-1/-1/22/22",
+		},
+		{
+			name: "empty udp block",
+			rule: map[string]interface{}{isSecurityGroupRuleDirection: "outbound", isSecurityGroupRuleProtocolUDP: []interface{}{nil}},
+			key:  "outbound/ipv4/udp/0.0.0.0/0/-1/-1/1/65535",
+		},
+		{
+			name: "remote address",
+			rule: map[string]interface{}{isSecurityGroupRuleDirection: "inbound", isSecurityGroupRuleRemote: "10.0.0.1"},
+			key:  "inbound/ipv4/all/10.0.0.1/-1/-1/-1/-1",
+		},
+		{
+			name: "remote cidr is normalized",
+			rule: map[string]interface{}{isSecurityGroupRuleDirection: "inbound", isSecurityGroupRuleRemote: "10.0.0.1/8"},
+			key:  "inbound/ipv4/all/10.0.0.0/8/-1/-1/-1/-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := expandSecurityGroupInlineRule(tc.rule)
+			if rule.key() != tc.key {
+				t.Fatalf("got key %s, want %s", rule.key(), tc.key)
+			}
+			// The flattened rule, as it is stored in the state, has the
+			// same key.
+			if key := expandSecurityGroupInlineRule(rule.flatten()).key(); key != tc.key {
+				t.Fatalf("got key %s for the flattened rule, want %s", key, tc.key)
+			}
+		})
+	}
+}
+
+func TestSecurityGroupInlineRulePrototype(t *testing.T) {
+	rule := expandSecurityGroupInlineRule(map[string]interface{}{
+		isSecurityGroupRuleDirection:    "inbound",
+		isSecurityGroupRuleProtocolICMP: []interface{}{map[string]interface{}{isSecurityGroupRuleType: 0, isSecurityGroupRuleCode: 0}},
+	})
+	prototype := rule.prototype()
+	if prototype.Type == nil || *prototype.Type != 0 || prototype.Code == nil || *prototype.Code != 0 {
+		t.Fatalf("got type %v and code %v, want 0 and 0", prototype.Type, prototype.Code)
+	}
+	if remote, ok := prototype.Remote.(*vpcv1.SecurityGroupRuleRemotePrototype); !ok || remote.CIDRBlock == nil || *remote.CIDRBlock != "0.0.0.0/0" {
+		t.Fatalf("got remote %+v, want 0.0.0.0/0", prototype.Remote)
+	}
+
+	rule = expandSecurityGroupInlineRule(map[string]interface{}{
+		isSecurityGroupRuleDirection:    "inbound",
+		isSecurityGroupRuleProtocolICMP: []interface{}{map[string]interface{}{isSecurityGroupRuleType: -1, isSecurityGroupRuleCode: -1}},
+	})
+	prototype = rule.prototype()
+	if prototype.Type != nil || prototype.Code != nil {
+		t.Fatalf("got type %v and code %v, want neither", prototype.Type, prototype.Code)
+	}
+}
+
+func TestFlattenSecurityGroupInlineRule(t *testing.T) {
+	rule, id, ok := flattenSecurityGroupInlineRule(&vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolIcmp{
+		ID:        core.StringPtr("r1"),
+		Direction: core.StringPtr("inbound"),
+		IPVersion: core.StringPtr("ipv4"),
+		Protocol:  core.StringPtr("icmp"),
+		Remote:    &vpcv1.SecurityGroupRuleRemote{CIDRBlock: core.StringPtr("0.0.0.0/0")},
+		Type:      core.Int64Ptr(0),
+		Code:      core.Int64Ptr(0),
+	})
+	if !ok || id != "r1" || rule.key() != "inbound/ipv4/icmp/0.0.0.0/0/0/0/-1/-1" {
+		t.Fatalf("got (%s, %s, %t), want the icmp echo reply rule r1", rule.key(), id, ok)
+	}
+
+	rule, id, ok = flattenSecurityGroupInlineRule(&vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp{
+		ID:        core.StringPtr("r2"),
+		Direction: core.StringPtr("outbound"),
+		IPVersion: core.StringPtr("ipv4"),
+		Protocol:  core.StringPtr("tcp"),
+		Remote:    &vpcv1.SecurityGroupRuleRemote{ID: core.StringPtr("r006-sg")},
+	})
+	if !ok || id != "r2" || rule.key() != "outbound/ipv4/tcp/r006-sg/-1/-1/1/65535" {
+		t.Fatalf("got (%s, %s, %t), want the tcp rule r2", rule.key(), id, ok)
+	}
+}
+
+// securityGroupRulesServer serves a security group with rules, and records
+// the rules that are created and deleted.
+type securityGroupRulesServer struct {
+	sync.Mutex
+	rules   []map[string]interface{}
+	created []string
+	deleted []string
+}
+
+func (s *securityGroupRulesServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/security_groups/sg1":
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "sg1", "rules": s.rules})
+	case r.Method == http.MethodPost && r.URL.Path == "/security_groups/sg1/rules":
+		body, _ := io.ReadAll(r.Body)
+		prototype := map[string]interface{}{}
+		json.Unmarshal(body, &prototype)
+		s.created = append(s.created, securityGroupInlineRuleFromPrototype(prototype).key())
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/security_groups/sg1/rules/"):
+		s.deleted = append(s.deleted, strings.TrimPrefix(r.URL.Path, "/security_groups/sg1/rules/"))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// securityGroupInlineRuleFromPrototype returns the normalized form of the
+// JSON body of a rule creation.
+func securityGroupInlineRuleFromPrototype(prototype map[string]interface{}) securityGroupInlineRule {
+	r := map[string]interface{}{
+		isSecurityGroupRuleDirection: prototype["direction"],
+		isSecurityGroupRuleIPVersion: prototype["ip_version"],
+	}
+	if remote, ok := prototype["remote"].(map[string]interface{}); ok {
+		r[isSecurityGroupRuleRemote], _ = remote["cidr_block"].(string)
+	}
+	values := map[string]interface{}{}
+	for key, field := range map[string]string{
+		"type":     isSecurityGroupRuleType,
+		"code":     isSecurityGroupRuleCode,
+		"port_min": isSecurityGroupRulePortMin,
+		"port_max": isSecurityGroupRulePortMax,
+	} {
+		if value, ok := prototype[key].(float64); ok {
+			values[field] = int(value)
+		}
+	}
+	if protocol := prototype["protocol"].(string); protocol != "all" {
+		r[protocol] = []interface{}{values}
+	}
+	return expandSecurityGroupInlineRule(r)
+}
+
+func TestResourceIBMISSecurityGroupReconcileRules(t *testing.T) {
+	remote := map[string]interface{}{"cidr_block": "0.0.0.0/0"}
+	existingRules := []map[string]interface{}{
+		// Managed rule that is kept.
+		{"id": "ssh", "direction": "inbound", "ip_version": "ipv4", "protocol": "tcp", "port_min": 22, "port_max": 22, "remote": remote},
+		// Duplicate of a managed rule.
+		{"id": "ssh-copy", "direction": "inbound", "ip_version": "ipv4", "protocol": "tcp", "port_min": 22, "port_max": 22, "remote": remote},
+		// Managed rule that is removed from the configuration.
+		{"id": "web", "direction": "inbound", "ip_version": "ipv4", "protocol": "tcp", "port_min": 80, "port_max": 80, "remote": remote},
+		// Rule created out of band.
+		{"id": "ping", "direction": "inbound", "ip_version": "ipv4", "protocol": "icmp", "type": 8, "remote": remote},
+	}
+
+	rule := func(direction string, protocol string, values map[string]interface{}) map[string]interface{} {
+		r := map[string]interface{}{
+			isSecurityGroupRuleDirection: direction,
+			isSecurityGroupRuleIPVersion: "ipv4",
+			isSecurityGroupRuleRemote:    "",
+		}
+		if protocol != "" {
+			r[protocol] = []interface{}{values}
+		}
+		return r
+	}
+	ssh := rule("inbound", isSecurityGroupRuleProtocolTCP, map[string]interface{}{isSecurityGroupRulePortMin: 22, isSecurityGroupRulePortMax: 22})
+	web := rule("inbound", isSecurityGroupRuleProtocolTCP, map[string]interface{}{isSecurityGroupRulePortMin: 80, isSecurityGroupRulePortMax: 80})
+	echoReply := rule("inbound", isSecurityGroupRuleProtocolICMP, map[string]interface{}{isSecurityGroupRuleType: 0, isSecurityGroupRuleCode: 0})
+
+	testCases := []struct {
+		name     string
+		oldRules []interface{}
+		newRules []interface{}
+		created  []string
+		deleted  []string
+	}{
+		{
+			name:     "rules are made authoritative",
+			oldRules: []interface{}{ssh, web},
+			newRules: []interface{}{ssh, echoReply},
+			created:  []string{"inbound/ipv4/icmp/0.0.0.0/0/0/0/-1/-1"},
+			deleted:  []string{"ping", "ssh-copy", "web"},
+		},
+		{
+			name:     "only the previous rules are deleted when the rules are no longer managed",
+			oldRules: []interface{}{ssh, web},
+			newRules: []interface{}{},
+			deleted:  []string{"ssh", "ssh-copy", "web"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &securityGroupRulesServer{rules: existingRules}
+			httpServer := httptest.NewServer(server)
+			defer httpServer.Close()
+
+			sess, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{URL: httpServer.URL, Authenticator: &core.NoAuthAuthenticator{}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			oldRules := schema.NewSet(resourceIBMISSecurityGroupRuleHash, tc.oldRules)
+			newRules := schema.NewSet(resourceIBMISSecurityGroupRuleHash, tc.newRules)
+			if err := resourceIBMISSecurityGroupReconcileRules(sess, "sg1", oldRules, newRules); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			sort.Strings(server.deleted)
+			if !reflect.DeepEqual(server.created, tc.created) {
+				t.Fatalf("got created rules %q, want %q", server.created, tc.created)
+			}
+			if !reflect.DeepEqual(server.deleted, tc.deleted) {
+				t.Fatalf("got deleted rules %q, want %q", server.deleted, tc.deleted)
+			}
+		})
+	}
+}
@@ -53,6 +53,84 @@ func TestAccIBMISSecurityGroup_basic(t *testing.T) {
 		},
 	})
 }
+func TestAccIBMISSecurityGroup_inlineRules(t *testing.T) {
+	var securityGroup string
+
+	vpcname := fmt.Sprintf("tfsg-vpc-%d", acctest.RandIntRange(10, 100))
+	name := fmt.Sprintf("tfsg-rules-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIBMISSecurityGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISsecurityGroupInlineRulesConfig(vpcname, name, `
+	rule {
+		direction = "inbound"
+		remote    = "10.0.0.5/24"
+		tcp {
+			port_min = 22
+			port_max = 22
+		}
+	}
+	rule {
+		direction = "inbound"
+		icmp {
+			type = 8
+		}
+	}
+	rule {
+		direction = "outbound"
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISSecurityGroupExists("ibm_is_security_group.testacc_security_group", securityGroup),
+					resource.TestCheckResourceAttr(
+						"ibm_is_security_group.testacc_security_group", "rule.#", "3"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_security_group.testacc_security_group", "rules.#", "3"),
+				),
+			},
+			{
+				Config: testAccCheckIBMISsecurityGroupInlineRulesConfig(vpcname, name, `
+	rule {
+		direction = "inbound"
+		remote    = "10.0.0.0/24"
+		tcp {
+			port_min = 22
+			port_max = 22
+		}
+	}
+	rule {
+		direction = "inbound"
+		udp {}
+	}
+	rule {
+		direction = "inbound"
+		icmp {
+			type = 0
+			code = 0
+		}
+	}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_is_security_group.testacc_security_group", "rule.#", "3"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_security_group.testacc_security_group", "rules.#", "3"),
+				),
+			},
+			{
+				ResourceName:      "ibm_is_security_group.testacc_security_group",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"rule",
+				},
+			},
+		},
+	})
+}
+
 func TestAccIBMISSecurityGroup_wait(t *testing.T) {
 	var securityGroup string
 
@@ -227,3 +305,17 @@ resource "ibm_is_security_group" "testacc_security_group" {
 }`, vpcname, name)
 
 }
+
+func testAccCheckIBMISsecurityGroupInlineRulesConfig(vpcname, name, rules string) string {
+	return fmt.Sprintf(`
+resource "ibm_is_vpc" "testacc_vpc" {
+	name = "%s"
+}
+
+resource "ibm_is_security_group" "testacc_security_group" {
+	name = "%s"
+	vpc  = ibm_is_vpc.testacc_vpc.id
+	%s
+}`, vpcname, name, rules)
+
+}
@@ -1,14 +1,25 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"os"
 
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/generate"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/provider"
 	"github.com/IBM-Cloud/terraform-provider-ibm/version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := generate.Run(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	log.Println("IBM Cloud Provider version", version.Version, version.VersionPrerelease, version.GitCommit)
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: provider.Provider,